@@ -1,13 +1,22 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"self-payrol/bankfile"
 	"self-payrol/config"
 	"self-payrol/delivery"
+	"self-payrol/model"
+	"self-payrol/notification"
+	"self-payrol/payslip"
 	"self-payrol/repository"
+	"self-payrol/request"
+	"self-payrol/scheduler"
+	"self-payrol/tax"
 	"self-payrol/usecase"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -17,6 +26,8 @@ type (
 	server struct {
 		httpServer *echo.Echo
 		cfg        config.Config
+		// idempotent is mounted on the routes that move money.
+		idempotent echo.MiddlewareFunc
 	}
 
 	Server interface {
@@ -32,9 +43,13 @@ func InitServer(cfg config.Config) Server {
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 
+	idempotencyRepo := repository.NewIdempotencyRepository(cfg)
+	idempotencyUsecase := usecase.NewIdempotencyUsecase(idempotencyRepo, cfg.IdempotencyTTL())
+
 	return &server{
 		httpServer: e,
 		cfg:        cfg,
+		idempotent: delivery.NewIdempotencyMiddleware(idempotencyUsecase),
 	}
 }
 
@@ -48,27 +63,138 @@ func (s *server) Run() {
 		})
 	})
 
+	txManager := repository.NewTxManager(s.cfg)
+
+	payslipRenderer := payslip.NewRenderer()
+	mailer := notification.NewLogMailer()
+	if s.cfg.MailerDriver() == "smtp" {
+		mailer = notification.NewSMTPMailer(s.cfg.SMTPAddr(), s.cfg.SMTPUsername(), s.cfg.SMTPPassword(), s.cfg.MailFrom())
+	}
+	mailQueue := notification.NewQueue(mailer, 100, 3, 10*time.Second)
+	defer mailQueue.Close()
+	notifier := notification.NewNotifier(mailQueue, payslipRenderer)
+
+	companyRepo := repository.NewCompanyRepository(s.cfg)
 	positionRepo := repository.NewPositionRepository(s.cfg)
-	positionUsecase := usecase.NewPositionUsecase(positionRepo)
+	salaryRepo := repository.NewPositionSalaryRepository(s.cfg)
+	userSalaryRepo := repository.NewUserSalaryRepository(s.cfg)
+	positionUsecase := usecase.NewPositionUsecase(positionRepo, salaryRepo, userSalaryRepo, companyRepo, txManager)
 	positionDelivery := delivery.NewPositionDelivery(positionUsecase)
 	positionGroup := s.httpServer.Group("/positions")
 	positionDelivery.Mount(positionGroup)
 
-	companyRepo := repository.NewCompanyRepository(s.cfg)
-	companyUsecase := usecase.NewCompanyUsecase(companyRepo)
-	companyDelivery := delivery.NewCompanyDelivery(companyUsecase)
+	componentRepo := repository.NewPositionComponentRepository(s.cfg)
+	componentUsecase := usecase.NewPositionComponentUsecase(componentRepo, positionRepo)
+	componentDelivery := delivery.NewPositionComponentDelivery(componentUsecase)
+	componentDelivery.Mount(positionGroup)
+
+	withdrawalRepo := repository.NewWithdrawalRepository(s.cfg)
+	companyUsecase := usecase.NewCompanyUsecase(companyRepo, withdrawalRepo, notifier)
+	companyDelivery := delivery.NewCompanyDelivery(companyUsecase, s.idempotent)
 	companyGroup := s.httpServer.Group("/company")
 	companyDelivery.Mount(companyGroup)
 
+	transactionRepo := repository.NewTransactionRepository(s.cfg)
+
 	// TODO(Rakamin): panggil user repository, user usecase, user derlivery, dan mount ke router
 	userRepo := repository.NewUserRepository(s.cfg)
-	userUsecase := usecase.NewUserUsecase(userRepo, positionRepo, companyRepo)
-	userDelivery := delivery.NewUserDelivery(userUsecase)
+	taxConfig, err := tax.LoadConfig(s.cfg.TaxConfigPath())
+	if err != nil {
+		log.Panic(err)
+	}
+	bpjsRateRepo := repository.NewBPJSRateRepository(s.cfg)
+	contributionRepo := repository.NewBPJSContributionRepository(s.cfg)
+	overtimeRepo := repository.NewOvertimeRepository(s.cfg)
+	paymentRepo := repository.NewPaymentRepository(s.cfg)
+	loanRepo := repository.NewLoanRepository(s.cfg)
+	reimbursementRepo := repository.NewReimbursementRepository(s.cfg)
+	leaveRepo := repository.NewLeaveRepository(s.cfg)
+	attendanceRepo := repository.NewAttendanceRepository(s.cfg)
+	exchangeRateRepo := repository.NewExchangeRateRepository(s.cfg)
+	payCalculator := usecase.NewPayCalculator(salaryRepo, userSalaryRepo, componentRepo, companyRepo, bpjsRateRepo, contributionRepo, overtimeRepo, paymentRepo, loanRepo, reimbursementRepo, leaveRepo, attendanceRepo, exchangeRateRepo, tax.NewPPh21Calculator(taxConfig))
+	payslipRepo := repository.NewPayslipRepository(s.cfg)
+	userUsecase := usecase.NewUserUsecase(userRepo, positionRepo, companyRepo, withdrawalRepo, transactionRepo, payslipRepo, userSalaryRepo, exchangeRateRepo, payCalculator, txManager, notifier)
+	userDelivery := delivery.NewUserDelivery(userUsecase, s.idempotent)
 	userGroup := s.httpServer.Group("/employee")
 	userDelivery.Mount(userGroup)
+
+	payslipUsecase := usecase.NewPayslipUsecase(userRepo, payslipRepo, payslipRenderer)
+	payslipDelivery := delivery.NewPayslipDelivery(payslipUsecase)
+	payslipDelivery.Mount(userGroup)
+
+	overtimeUsecase := usecase.NewOvertimeUsecase(userRepo, overtimeRepo, salaryRepo, userSalaryRepo)
+	overtimeDelivery := delivery.NewOvertimeDelivery(overtimeUsecase)
+	overtimeDelivery.Mount(userGroup)
+
+	paymentUsecase := usecase.NewPaymentUsecase(userRepo, paymentRepo, companyRepo, txManager)
+	paymentDelivery := delivery.NewPaymentDelivery(paymentUsecase, s.idempotent)
+	paymentDelivery.Mount(userGroup)
+
+	loanUsecase := usecase.NewLoanUsecase(userRepo, loanRepo, companyRepo, txManager)
+	loanDelivery := delivery.NewLoanDelivery(loanUsecase, s.idempotent)
+	loanDelivery.Mount(userGroup)
+
+	reimbursementUsecase := usecase.NewReimbursementUsecase(userRepo, reimbursementRepo, companyRepo, txManager)
+	reimbursementDelivery := delivery.NewReimbursementDelivery(reimbursementUsecase, s.idempotent)
+	reimbursementDelivery.Mount(userGroup)
+
+	attendanceUsecase := usecase.NewAttendanceUsecase(userRepo, attendanceRepo, companyRepo)
+	attendanceDelivery := delivery.NewAttendanceDelivery(attendanceUsecase)
+	attendanceDelivery.Mount(userGroup)
+
+	leaveUsecase := usecase.NewLeaveUsecase(userRepo, leaveRepo, companyRepo, txManager)
+	leaveDelivery := delivery.NewLeaveDelivery(leaveUsecase)
+	leaveDelivery.Mount(userGroup)
+
+	employmentEventRepo := repository.NewEmploymentEventRepository(s.cfg)
+	employmentUsecase := usecase.NewEmploymentUsecase(userRepo, employmentEventRepo, txManager)
+	employmentDelivery := delivery.NewEmploymentDelivery(employmentUsecase)
+	employmentDelivery.Mount(userGroup)
 	//EOL
 
-	transactionRepo := repository.NewTransactionRepository(s.cfg)
+	bpjsUsecase := usecase.NewBPJSUsecase(companyRepo, bpjsRateRepo, contributionRepo, txManager)
+	bpjsDelivery := delivery.NewBPJSDelivery(bpjsUsecase, s.idempotent)
+	bpjsGroup := s.httpServer.Group("/bpjs")
+	bpjsDelivery.Mount(bpjsGroup)
+
+	exchangeRateUsecase := usecase.NewExchangeRateUsecase(exchangeRateRepo, companyRepo, txManager)
+	exchangeRateDelivery := delivery.NewExchangeRateDelivery(exchangeRateUsecase)
+	exchangeRateGroup := s.httpServer.Group("/exchange-rates")
+	exchangeRateDelivery.Mount(exchangeRateGroup)
+
+	bankFileConfig, err := bankfile.LoadConfig(s.cfg.BankFileConfigPath())
+	if err != nil {
+		log.Panic(err)
+	}
+
+	payrollRunRepo := repository.NewPayrollRunRepository(s.cfg)
+	payrollRunUsecase := usecase.NewPayrollRunUsecase(userRepo, companyRepo, withdrawalRepo, payslipRepo, payrollRunRepo, exchangeRateRepo, payCalculator, txManager, notifier, bankfile.NewExporter(bankFileConfig))
+	payrollRunDelivery := delivery.NewPayrollRunDelivery(payrollRunUsecase, s.idempotent)
+	payrollRunGroup := s.httpServer.Group("/payroll-runs")
+	payrollRunDelivery.Mount(payrollRunGroup)
+
+	payrollUsecase := usecase.NewPayrollUsecase(userRepo, positionRepo, companyRepo, withdrawalRepo, exchangeRateRepo, payCalculator)
+	payrollDelivery := delivery.NewPayrollDelivery(payrollUsecase)
+	payrollGroup := s.httpServer.Group("/payroll")
+	payrollDelivery.Mount(payrollGroup)
+
+	// the schedule only drafts the run, nothing is paid until an admin
+	// submits, approves and disburses it
+	if expr := s.cfg.PayrollSchedule(); expr != "" {
+		schedule, err := scheduler.Parse(expr)
+		if err != nil {
+			log.Panic(err)
+		}
+		payrollScheduler := scheduler.New(schedule, func(ctx context.Context) error {
+			_, err := payrollRunUsecase.Draft(ctx, model.PayrollRunScheduled, &request.PayrollRunActionRequest{
+				Actor: model.PayrollRunScheduler,
+			})
+			return err
+		})
+		payrollScheduler.Start()
+		defer payrollScheduler.Stop()
+	}
+
 	transactionUsecase := usecase.NewTransactionUsecase(transactionRepo)
 	transactionDelivery := delivery.NewTransactionDelivery(transactionUsecase)
 	transactionGroup := s.httpServer.Group("/transactions")
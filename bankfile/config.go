@@ -0,0 +1,231 @@
+package bankfile
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"self-payrol/money"
+)
+
+// FormatCSV is the generic layout every bank file can be exported in, the
+// other formats are the templates of the config.
+const FormatCSV = "csv"
+
+//go:embed templates.json
+var defaultConfig []byte
+
+// fieldValues maps what a field can hold to whether it is a number, which
+// is right aligned and zero padded by default. Numbers cannot be negative.
+var fieldValues = map[string]bool{
+	"literal":         false,
+	"date":            false,
+	"batch_reference": false,
+	"currency":        false,
+	"record_count":    true,
+	"total":           true,
+	// The values below describe one transfer and only go in records.
+	"sequence":       true,
+	"bank_code":      false,
+	"account_number": false,
+	"account_name":   false,
+	"amount":         true,
+	"reference":      false,
+	"description":    false,
+}
+
+var recordValues = map[string]bool{
+	"sequence":       true,
+	"bank_code":      true,
+	"account_number": true,
+	"account_name":   true,
+	"amount":         true,
+	"reference":      true,
+	"description":    true,
+}
+
+type (
+	// Config holds the fixed-width templates banks take bulk transfers in.
+	Config struct {
+		Templates []Template `json:"templates"`
+	}
+
+	// Template lays a batch out as an optional header line, a record line
+	// per transfer and an optional trailer line.
+	Template struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		// Currency restricts the template to batches in one currency,
+		// empty takes any.
+		Currency  string `json:"currency"`
+		Extension string `json:"extension"`
+		// LineEnding is "crlf", the default, or "lf".
+		LineEnding string  `json:"line_ending"`
+		Header     []Field `json:"header"`
+		Record     []Field `json:"record"`
+		Trailer    []Field `json:"trailer"`
+	}
+
+	// Field is Width characters of a line holding Value. Text is written
+	// as is by "literal" fields, whose width defaults to its length.
+	// Longer text is cut at the width, numbers that do not fit are an
+	// error.
+	Field struct {
+		Value string `json:"value"`
+		Text  string `json:"text,omitempty"`
+		Width int    `json:"width"`
+		// Align is "left" or "right", Pad the character filling the rest
+		// of the width. Text is left aligned and space padded, numbers
+		// right aligned and zero padded unless set.
+		Align string `json:"align,omitempty"`
+		Pad   string `json:"pad,omitempty"`
+		Upper bool   `json:"upper,omitempty"`
+		// Format is the Go layout of "date" fields, 20060102 by default.
+		Format string `json:"format,omitempty"`
+		// Decimals are the implied decimal places of "amount" and "total"
+		// fields, the ones of the currency by default.
+		Decimals *int `json:"decimals,omitempty"`
+	}
+)
+
+// LoadConfig reads the templates from path, or the bundled ones when path is
+// empty.
+func LoadConfig(path string) (*Config, error) {
+	raw := defaultConfig
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		raw = b
+	}
+
+	return ParseConfig(raw)
+}
+
+func ParseConfig(raw []byte) (*Config, error) {
+	cfg := new(Config)
+	if err := json.Unmarshal(raw, cfg); err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	for i := range cfg.Templates {
+		t := &cfg.Templates[i]
+
+		if t.Name == "" || t.Name == FormatCSV {
+			return nil, fmt.Errorf("bank file template %d: name must be set and not %q", i, FormatCSV)
+		}
+		if names[t.Name] {
+			return nil, fmt.Errorf("bank file template %s: defined twice", t.Name)
+		}
+		names[t.Name] = true
+
+		if t.Currency != "" {
+			if _, err := money.Exponent(t.Currency); err != nil {
+				return nil, fmt.Errorf("bank file template %s: %w", t.Name, err)
+			}
+		}
+		if t.Extension == "" {
+			t.Extension = "txt"
+		}
+		switch t.LineEnding {
+		case "":
+			t.LineEnding = "crlf"
+		case "crlf", "lf":
+		default:
+			return nil, fmt.Errorf("bank file template %s: unknown line ending %q", t.Name, t.LineEnding)
+		}
+
+		if len(t.Record) == 0 {
+			return nil, fmt.Errorf("bank file template %s: record has no fields", t.Name)
+		}
+		for _, line := range []struct {
+			name    string
+			fields  []Field
+			records bool
+		}{
+			{"header", t.Header, false},
+			{"record", t.Record, true},
+			{"trailer", t.Trailer, false},
+		} {
+			for j := range line.fields {
+				if err := line.fields[j].check(line.records); err != nil {
+					return nil, fmt.Errorf("bank file template %s: %s field %d: %w", t.Name, line.name, j+1, err)
+				}
+			}
+		}
+	}
+
+	return cfg, nil
+}
+
+// check validates the field and fills in its defaults.
+func (f *Field) check(inRecord bool) error {
+	numeric, ok := fieldValues[f.Value]
+	if !ok {
+		return fmt.Errorf("unknown value %q", f.Value)
+	}
+	if recordValues[f.Value] && !inRecord {
+		return fmt.Errorf("%s only goes in records", f.Value)
+	}
+
+	if f.Value == "literal" && f.Width == 0 {
+		f.Width = len(f.Text)
+	}
+	if f.Width <= 0 {
+		return errors.New("width must be positive")
+	}
+	if f.Value == "literal" && len(f.Text) > f.Width {
+		return errors.New("text is longer than the width")
+	}
+
+	switch f.Align {
+	case "":
+		f.Align = "left"
+		if numeric {
+			f.Align = "right"
+		}
+	case "left", "right":
+	default:
+		return fmt.Errorf("unknown align %q", f.Align)
+	}
+
+	switch {
+	case f.Pad == "" && numeric:
+		f.Pad = "0"
+	case f.Pad == "":
+		f.Pad = " "
+	case len(f.Pad) != 1 || f.Pad[0] < ' ' || f.Pad[0] > '~':
+		return errors.New("pad must be one printable ASCII character")
+	}
+
+	if f.Format != "" && f.Value != "date" {
+		return errors.New("only date fields take a format")
+	}
+	if f.Value == "date" && f.Format == "" {
+		f.Format = "20060102"
+	}
+
+	if f.Decimals != nil {
+		if f.Value != "amount" && f.Value != "total" {
+			return errors.New("only amount and total fields take decimals")
+		}
+		if *f.Decimals < 0 || *f.Decimals > 4 {
+			return errors.New("decimals must be between 0 and 4")
+		}
+	}
+
+	return nil
+}
+
+// template returns the template called name.
+func (c *Config) template(name string) (*Template, bool) {
+	for i := range c.Templates {
+		if c.Templates[i].Name == name {
+			return &c.Templates[i], true
+		}
+	}
+	return nil, false
+}
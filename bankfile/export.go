@@ -0,0 +1,234 @@
+package bankfile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"self-payrol/model"
+	"self-payrol/money"
+	"strconv"
+	"strings"
+)
+
+var csvHeader = []string{"record_type", "sequence", "bank_code", "account_number", "account_name", "amount", "currency", "reference", "description"}
+
+type exporter struct {
+	cfg *Config
+}
+
+func NewExporter(cfg *Config) model.BankFileExporter {
+	return &exporter{cfg: cfg}
+}
+
+func (e *exporter) Export(batch *model.BankTransferBatch, format string) (*model.BankFile, error) {
+	total, err := batch.Total()
+	if err != nil {
+		return nil, err
+	}
+
+	if format == FormatCSV {
+		return exportCSV(batch, total)
+	}
+
+	t, ok := e.cfg.template(format)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", model.ErrUnknownBankFileFormat, format)
+	}
+	if t.Currency != "" && t.Currency != batch.Currency {
+		return nil, fmt.Errorf("%w: %s takes %s, not %s", model.ErrBankFileCurrency, t.Name, t.Currency, batch.Currency)
+	}
+
+	return t.export(batch, total)
+}
+
+// exportCSV writes a row per transfer followed by a "total" row whose
+// sequence is the record count and amount the control total.
+func exportCSV(batch *model.BankTransferBatch, total money.Money) (*model.BankFile, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(csvHeader)
+	for i, transfer := range batch.Transfers {
+		_ = w.Write([]string{
+			"transfer",
+			strconv.Itoa(i + 1),
+			transfer.BankCode,
+			transfer.AccountNumber,
+			transfer.AccountName,
+			transfer.Amount.Decimal(),
+			transfer.Amount.Currency,
+			transfer.Reference,
+			transfer.Description,
+		})
+	}
+	_ = w.Write([]string{"total", strconv.Itoa(len(batch.Transfers)), "", "", "", total.Decimal(), total.Currency, batch.Reference, ""})
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return &model.BankFile{
+		Name:        fileName(batch.Reference, "csv"),
+		ContentType: "text/csv",
+		Content:     buf.Bytes(),
+		Records:     len(batch.Transfers),
+		Total:       total,
+	}, nil
+}
+
+// line is what the fields of one line are filled from, transfer is nil in
+// the header and trailer.
+type line struct {
+	batch    *model.BankTransferBatch
+	total    money.Money
+	transfer *model.BankTransfer
+	sequence int
+}
+
+func (t *Template) export(batch *model.BankTransferBatch, total money.Money) (*model.BankFile, error) {
+	eol := "\r\n"
+	if t.LineEnding == "lf" {
+		eol = "\n"
+	}
+
+	var buf bytes.Buffer
+	write := func(name string, fields []Field, l *line) error {
+		if len(fields) == 0 {
+			return nil
+		}
+		for i := range fields {
+			s, err := fields[i].render(l)
+			if err != nil {
+				return fmt.Errorf("%s %s field %d (%s): %w", t.Name, name, i+1, fields[i].Value, err)
+			}
+			buf.WriteString(s)
+		}
+		buf.WriteString(eol)
+		return nil
+	}
+
+	if err := write("header", t.Header, &line{batch: batch, total: total}); err != nil {
+		return nil, err
+	}
+	for i := range batch.Transfers {
+		l := &line{batch: batch, total: total, transfer: &batch.Transfers[i], sequence: i + 1}
+		if err := write("record "+strconv.Itoa(i+1), t.Record, l); err != nil {
+			return nil, err
+		}
+	}
+	if err := write("trailer", t.Trailer, &line{batch: batch, total: total}); err != nil {
+		return nil, err
+	}
+
+	return &model.BankFile{
+		Name:        fileName(batch.Reference+"-"+t.Name, t.Extension),
+		ContentType: "text/plain",
+		Content:     buf.Bytes(),
+		Records:     len(batch.Transfers),
+		Total:       total,
+	}, nil
+}
+
+func (f *Field) render(l *line) (string, error) {
+	switch f.Value {
+	case "literal":
+		return f.text(f.Text), nil
+	case "date":
+		return f.text(l.batch.Date.Format(f.Format)), nil
+	case "batch_reference":
+		return f.text(l.batch.Reference), nil
+	case "currency":
+		return f.text(l.batch.Currency), nil
+	case "record_count":
+		return f.number(int64(len(l.batch.Transfers)))
+	case "total":
+		return f.amount(l.total)
+	case "sequence":
+		return f.number(int64(l.sequence))
+	case "bank_code":
+		return f.text(l.transfer.BankCode), nil
+	case "account_number":
+		return f.text(l.transfer.AccountNumber), nil
+	case "account_name":
+		return f.text(l.transfer.AccountName), nil
+	case "amount":
+		return f.amount(l.transfer.Amount)
+	case "reference":
+		return f.text(l.transfer.Reference), nil
+	case "description":
+		return f.text(l.transfer.Description), nil
+	}
+	return "", fmt.Errorf("unknown value %q", f.Value)
+}
+
+// text writes s as printable ASCII, cut at the width of the field.
+func (f *Field) text(s string) string {
+	ascii := strings.Map(func(r rune) rune {
+		if r < ' ' || r > '~' {
+			return ' '
+		}
+		return r
+	}, s)
+	if f.Upper {
+		ascii = strings.ToUpper(ascii)
+	}
+	if len(ascii) > f.Width {
+		ascii = ascii[:f.Width]
+	}
+	return f.pad(ascii)
+}
+
+// amount writes m in minor units of the decimals of the field, which
+// cannot drop any of the currency.
+func (f *Field) amount(m money.Money) (string, error) {
+	exponent, err := money.Exponent(m.Currency)
+	if err != nil {
+		return "", err
+	}
+	decimals := exponent
+	if f.Decimals != nil {
+		decimals = *f.Decimals
+	}
+	if decimals < exponent {
+		return "", fmt.Errorf("%d decimals would cut %s amounts", decimals, m.Currency)
+	}
+
+	for ; decimals > exponent; decimals-- {
+		if m, err = m.Mul(10); err != nil {
+			return "", err
+		}
+	}
+	return f.number(m.Amount)
+}
+
+func (f *Field) number(n int64) (string, error) {
+	if n < 0 {
+		return "", errors.New("cannot write a negative number")
+	}
+	s := strconv.FormatInt(n, 10)
+	if len(s) > f.Width {
+		return "", fmt.Errorf("%s does not fit in %d digits", s, f.Width)
+	}
+	return f.pad(s), nil
+}
+
+func (f *Field) pad(s string) string {
+	padding := strings.Repeat(f.Pad, f.Width-len(s))
+	if f.Align == "right" {
+		return padding + s
+	}
+	return s + padding
+}
+
+func fileName(reference, extension string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return '-'
+	}, reference)
+	return name + "." + extension
+}
@@ -0,0 +1,127 @@
+package bankfile_test
+
+import (
+	"self-payrol/bankfile"
+	"self-payrol/model"
+	"self-payrol/money"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func batch(currency string, amounts ...int64) *model.BankTransferBatch {
+	b := &model.BankTransferBatch{
+		Reference: "PAYROLL-7",
+		Date:      time.Date(2026, time.October, 25, 9, 0, 0, 0, time.UTC),
+		Currency:  currency,
+	}
+	names := []string{"Siti Rahayu", "Budi Santoso"}
+	for i, amount := range amounts {
+		b.Transfers = append(b.Transfers, model.BankTransfer{
+			BankCode:      "014",
+			AccountNumber: "12345678" + string(rune('0'+i)),
+			AccountName:   names[i],
+			Amount:        money.New(amount, currency),
+			Reference:     "PS-2026-10-000" + string(rune('1'+i)),
+			Description:   "Salary 2026-10",
+		})
+	}
+	return b
+}
+
+func TestExporter_Export_CSV(t *testing.T) {
+	cfg, err := bankfile.LoadConfig("")
+	require.NoError(t, err)
+
+	file, err := bankfile.NewExporter(cfg).Export(batch("USD", 125050, 99), bankfile.FormatCSV)
+	require.NoError(t, err)
+
+	assert.Equal(t, "payroll-7.csv", file.Name)
+	assert.Equal(t, 2, file.Records)
+	assert.Equal(t, money.New(125149, "USD"), file.Total)
+	assert.Equal(t, "record_type,sequence,bank_code,account_number,account_name,amount,currency,reference,description\n"+
+		"transfer,1,014,123456780,Siti Rahayu,1250.50,USD,PS-2026-10-0001,Salary 2026-10\n"+
+		"transfer,2,014,123456781,Budi Santoso,0.99,USD,PS-2026-10-0002,Salary 2026-10\n"+
+		"total,2,,,,1251.49,USD,PAYROLL-7,\n", string(file.Content))
+}
+
+func TestExporter_Export_FixedWidth(t *testing.T) {
+	cfg, err := bankfile.LoadConfig("")
+	require.NoError(t, err)
+
+	file, err := bankfile.NewExporter(cfg).Export(batch("IDR", 8500000, 4250000), "idr_fixed")
+	require.NoError(t, err)
+
+	assert.Equal(t, "payroll-7-idr_fixed.txt", file.Name)
+	assert.Equal(t, 2, file.Records)
+	assert.Equal(t, money.New(12750000, "IDR"), file.Total)
+
+	lines := strings.Split(strings.TrimSuffix(string(file.Content), "\r\n"), "\r\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "H20261025PAYROLL-7           IDR0000200000001275000000", lines[0])
+	assert.Equal(t, "D00001014123456780           SITI RAHAYU                        IDR00000000850000000PS-2026-10-0001     Salary 2026-10                          ", lines[1])
+	assert.Equal(t, "T0000200000001275000000", lines[3])
+	for _, line := range lines[1:3] {
+		assert.Len(t, line, 144)
+	}
+
+	_, err = bankfile.NewExporter(cfg).Export(batch("USD", 1000), "idr_fixed")
+	assert.ErrorIs(t, err, model.ErrBankFileCurrency)
+}
+
+func TestExporter_Export_Errors(t *testing.T) {
+	cfg, err := bankfile.ParseConfig([]byte(`{"templates": [{
+		"name": "narrow",
+		"record": [
+			{"value": "account_number", "width": 10},
+			{"value": "amount", "width": 6}
+		]
+	}, {
+		"name": "whole",
+		"record": [{"value": "amount", "width": 12, "decimals": 0}]
+	}]}`))
+	require.NoError(t, err)
+	exporter := bankfile.NewExporter(cfg)
+
+	_, err = exporter.Export(batch("IDR", 1000), "mt940")
+	assert.ErrorIs(t, err, model.ErrUnknownBankFileFormat)
+
+	_, err = exporter.Export(batch("IDR", 1000000), "narrow")
+	assert.EqualError(t, err, "narrow record 1 field 2 (amount): 1000000 does not fit in 6 digits")
+
+	_, err = exporter.Export(batch("IDR", -1), "narrow")
+	assert.EqualError(t, err, "narrow record 1 field 2 (amount): cannot write a negative number")
+
+	_, err = exporter.Export(batch("USD", 1250), "whole")
+	assert.EqualError(t, err, "whole record 1 field 1 (amount): 0 decimals would cut USD amounts")
+
+	file, err := exporter.Export(batch("IDR", 1000), "whole")
+	require.NoError(t, err)
+	assert.Equal(t, "000000001000\r\n", string(file.Content))
+}
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         string
+		expectedErr string
+	}{
+		{name: "Reserved name", raw: `{"templates": [{"name": "csv", "record": [{"value": "amount", "width": 10}]}]}`, expectedErr: `bank file template 0: name must be set and not "csv"`},
+		{name: "Unknown currency", raw: `{"templates": [{"name": "a", "currency": "XYZ", "record": [{"value": "amount", "width": 10}]}]}`, expectedErr: `bank file template a: currency not supported: "XYZ"`},
+		{name: "No record", raw: `{"templates": [{"name": "a"}]}`, expectedErr: "bank file template a: record has no fields"},
+		{name: "Unknown value", raw: `{"templates": [{"name": "a", "record": [{"value": "iban", "width": 10}]}]}`, expectedErr: `bank file template a: record field 1: unknown value "iban"`},
+		{name: "Transfer value in header", raw: `{"templates": [{"name": "a", "header": [{"value": "amount", "width": 10}], "record": [{"value": "amount", "width": 10}]}]}`, expectedErr: "bank file template a: header field 1: amount only goes in records"},
+		{name: "No width", raw: `{"templates": [{"name": "a", "record": [{"value": "account_name"}]}]}`, expectedErr: "bank file template a: record field 1: width must be positive"},
+		{name: "Decimals on text", raw: `{"templates": [{"name": "a", "record": [{"value": "reference", "width": 10, "decimals": 2}]}]}`, expectedErr: "bank file template a: record field 1: only amount and total fields take decimals"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := bankfile.ParseConfig([]byte(tt.raw))
+
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -5,10 +5,14 @@ import (
 	"os"
 	"self-payrol/config/postgres"
 	"strconv"
+	"sync"
+	"time"
 )
 
 type (
 	config struct {
+		db     *gorm.DB
+		dbOnce sync.Once
 	}
 
 	Config interface {
@@ -16,6 +20,15 @@ type (
 		ServicePort() int
 		ServiceEnvironment() string
 		Database() *gorm.DB
+		IdempotencyTTL() time.Duration
+		TaxConfigPath() string
+		BankFileConfigPath() string
+		MailerDriver() string
+		SMTPAddr() string
+		SMTPUsername() string
+		SMTPPassword() string
+		MailFrom() string
+		PayrollSchedule() string
 	}
 )
 
@@ -23,8 +36,13 @@ func NewConfig() Config {
 	return &config{}
 }
 
+// Database opens the connection pool once and shares it, so a transaction
+// started by one repository is visible to the others through the context.
 func (c *config) Database() *gorm.DB {
-	return postgres.InitGorm()
+	c.dbOnce.Do(func() {
+		c.db = postgres.InitGorm()
+	})
+	return c.db
 }
 
 func (c *config) ServiceName() string {
@@ -41,3 +59,58 @@ func (c *config) ServicePort() int {
 func (c *config) ServiceEnvironment() string {
 	return os.Getenv("ENV")
 }
+
+// IdempotencyTTL is how long a stored Idempotency-Key response is replayed,
+// read from IDEMPOTENCY_TTL as a Go duration such as "24h".
+func (c *config) IdempotencyTTL() time.Duration {
+	v := os.Getenv("IDEMPOTENCY_TTL")
+	ttl, err := time.ParseDuration(v)
+	if err != nil || ttl <= 0 {
+		return 24 * time.Hour
+	}
+
+	return ttl
+}
+
+// TaxConfigPath points at a PPh 21 rule file, empty means the bundled rules.
+func (c *config) TaxConfigPath() string {
+	return os.Getenv("TAX_CONFIG_PATH")
+}
+
+// BankFileConfigPath points at a file of bank file templates, empty means
+// the bundled templates.
+func (c *config) BankFileConfigPath() string {
+	return os.Getenv("BANK_FILE_CONFIG_PATH")
+}
+
+// MailerDriver picks how email goes out, "smtp" or "log". Anything else
+// falls back to "log", which only writes emails to the log.
+func (c *config) MailerDriver() string {
+	if os.Getenv("MAILER") == "smtp" {
+		return "smtp"
+	}
+	return "log"
+}
+
+// SMTPAddr is the host:port of the SMTP server.
+func (c *config) SMTPAddr() string {
+	return os.Getenv("SMTP_ADDR")
+}
+
+func (c *config) SMTPUsername() string {
+	return os.Getenv("SMTP_USERNAME")
+}
+
+func (c *config) SMTPPassword() string {
+	return os.Getenv("SMTP_PASSWORD")
+}
+
+func (c *config) MailFrom() string {
+	return os.Getenv("MAIL_FROM")
+}
+
+// PayrollSchedule is the cron expression of the scheduled payroll run, such
+// as "0 9 25 * *" for nine in the morning on the 25th. Empty turns it off.
+func (c *config) PayrollSchedule() string {
+	return os.Getenv("PAYROLL_SCHEDULE")
+}
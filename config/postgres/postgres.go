@@ -18,11 +18,61 @@ func InitGorm() *gorm.DB {
 		log.Error().Msgf("cant connect to database %s", err)
 	}
 
+	// amounts of money moved to an amount and a currency column
+	renames := []struct {
+		model    interface{}
+		from, to string
+	}{
+		{&model.Company{}, "balance", "balance_amount"},
+		{&model.Position{}, "salary", "salary_amount"},
+		{&model.Position{}, "min_salary", "min_salary_amount"},
+		{&model.Position{}, "max_salary", "max_salary_amount"},
+		{&model.PositionSalary{}, "salary", "salary_amount"},
+		{&model.UserSalary{}, "salary", "salary_amount"},
+		{&model.Overtime{}, "hourly_rate", "hourly_rate_amount"},
+		{&model.Loan{}, "principal", "principal_amount"},
+		{&model.Loan{}, "installment", "installment_amount"},
+		{&model.Loan{}, "outstanding", "outstanding_amount"},
+		{&model.PayrollRun{}, "total", "total_amount"},
+		{&model.PayrollRunItem{}, "salary", "salary_amount"},
+		{&model.BPJSRate{}, "wage_cap", "wage_cap_amount"},
+		{&model.BPJSContribution{}, "wage", "wage_amount"},
+	}
+	for _, r := range renames {
+		m := db.Migrator()
+		if m.HasColumn(r.model, r.from) && !m.HasColumn(r.model, r.to) {
+			if err := m.RenameColumn(r.model, r.from, r.to); err != nil {
+				log.Fatal().Msgf("cant rename column %s %s", r.from, err)
+			}
+		}
+	}
+
 	if err := db.AutoMigrate(
 		&model.Position{},
 		&model.User{},
 		&model.Company{},
 		&model.Transaction{},
+		&model.TransactionLine{},
+		&model.Withdrawal{},
+		&model.IdempotencyKey{},
+		&model.PositionComponent{},
+		&model.BPJSRate{},
+		&model.BPJSContribution{},
+		&model.Payslip{},
+		&model.Overtime{},
+		&model.Payment{},
+		&model.PositionSalary{},
+		&model.UserSalary{},
+		&model.EmploymentEvent{},
+		&model.PayrollRun{},
+		&model.PayrollRunItem{},
+		&model.PayrollRunEvent{},
+		&model.Loan{},
+		&model.LoanRepayment{},
+		&model.Reimbursement{},
+		&model.Attendance{},
+		&model.Leave{},
+		&model.ExchangeRate{},
 	); err != nil {
 		log.Fatal().Msgf("cant automigrate %s", err)
 	}
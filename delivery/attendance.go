@@ -0,0 +1,89 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"self-payrol/helper"
+	"self-payrol/model"
+	"self-payrol/request"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type attendanceDelivery struct {
+	attendanceUsecase model.AttendanceUsecase
+}
+
+type AttendanceDelivery interface {
+	Mount(group *echo.Group)
+}
+
+func NewAttendanceDelivery(attendanceUsecase model.AttendanceUsecase) AttendanceDelivery {
+	return &attendanceDelivery{attendanceUsecase: attendanceUsecase}
+}
+
+// Mount expects the /employee group, attendance lives under an employee.
+func (a *attendanceDelivery) Mount(group *echo.Group) {
+	group.GET("/:id/attendance", a.FetchAttendanceHandler)
+	group.POST("/:id/attendance/clock-in", a.ClockHandler(a.attendanceUsecase.ClockIn))
+	group.POST("/:id/attendance/clock-out", a.ClockHandler(a.attendanceUsecase.ClockOut))
+}
+
+func (a *attendanceDelivery) FetchAttendanceHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+
+	attendance, err := a.attendanceUsecase.FetchAttendance(ctx, userID, c.QueryParam("period"))
+	if err != nil {
+		return attendanceError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", attendance)
+}
+
+// ClockHandler clocks the employee in or out at the time of the request.
+func (a *attendanceDelivery) ClockHandler(clock func(ctx context.Context, userID int, req *request.ClockRequest) (*model.Attendance, error)) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var req request.ClockRequest
+
+		if err := c.Bind(&req); err != nil {
+			return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+		}
+
+		if err := req.Validate(); err != nil {
+			errVal := err.(validation.Errors)
+			return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
+		}
+
+		userID, _ := strconv.Atoi(c.Param("id"))
+
+		attendance, err := clock(ctx, userID, &req)
+		if err != nil {
+			return attendanceError(c, err)
+		}
+
+		return helper.ResponseSuccessJson(c, "success", attendance)
+	}
+}
+
+func attendanceError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+	case errors.Is(err, model.ErrInvalidPayrollPeriod):
+		return helper.ResponseErrorJson(c, http.StatusBadRequest, err)
+	case errors.Is(err, model.ErrEmployeeNotActive):
+		return helper.ResponseErrorJson(c, http.StatusForbidden, err)
+	case errors.Is(err, model.ErrAlreadyClockedIn), errors.Is(err, model.ErrNotClockedIn),
+		errors.Is(err, model.ErrAlreadyClockedOut):
+		return helper.ResponseErrorJson(c, http.StatusConflict, err)
+	}
+	return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+}
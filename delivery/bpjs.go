@@ -0,0 +1,136 @@
+package delivery
+
+import (
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"self-payrol/helper"
+	"self-payrol/model"
+	"self-payrol/request"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+)
+
+type bpjsDelivery struct {
+	bpjsUsecase model.BPJSUsecase
+	idempotent  echo.MiddlewareFunc
+}
+
+type BPJSDelivery interface {
+	Mount(group *echo.Group)
+}
+
+func NewBPJSDelivery(bpjsUsecase model.BPJSUsecase, idempotent echo.MiddlewareFunc) BPJSDelivery {
+	return &bpjsDelivery{bpjsUsecase: bpjsUsecase, idempotent: idempotent}
+}
+
+func (b *bpjsDelivery) Mount(group *echo.Group) {
+	group.GET("/rates", b.FetchRatesHandler)
+	group.PUT("/rates", b.UpdateRatesHandler)
+	group.GET("/contributions", b.ReportHandler)
+	group.POST("/contributions/pay", b.PayContributionsHandler, b.idempotent)
+}
+
+func (b *bpjsDelivery) FetchRatesHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	rates, err := b.bpjsUsecase.FetchRates(ctx)
+	if err != nil {
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", rates)
+}
+
+func (b *bpjsDelivery) UpdateRatesHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req request.BPJSRateRequest
+
+	if err := c.Bind(&req); err != nil {
+		return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
+	}
+
+	rates, err := b.bpjsUsecase.UpdateRates(ctx, &req)
+	if err != nil {
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", rates)
+}
+
+// ReportHandler returns the contributions of ?month=2026-10, as JSON or as
+// CSV with ?format=csv.
+func (b *bpjsDelivery) ReportHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	report, err := b.bpjsUsecase.Report(ctx, c.QueryParam("month"))
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidPayrollPeriod) {
+			return helper.ResponseErrorJson(c, http.StatusBadRequest, err)
+		}
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
+
+	if c.QueryParam("format") != "csv" {
+		return helper.ResponseSuccessJson(c, "success", report)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv")
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="bpjs-`+report.Month+`.csv"`)
+	c.Response().WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(c.Response())
+	w.Write([]string{"user_id", "name", "period", "program", "wage", "employee_amount", "employer_amount", "currency", "paid"})
+	for _, row := range report.Rows {
+		w.Write([]string{
+			strconv.Itoa(row.UserID),
+			row.Name,
+			row.Period,
+			row.Program,
+			row.Wage.Decimal(),
+			row.EmployeeAmount.Decimal(),
+			row.EmployerAmount.Decimal(),
+			row.Wage.Currency,
+			strconv.FormatBool(row.Paid),
+		})
+	}
+	w.Flush()
+
+	return w.Error()
+}
+
+func (b *bpjsDelivery) PayContributionsHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req request.BPJSPayRequest
+
+	if err := c.Bind(&req); err != nil {
+		return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
+	}
+
+	trx, err := b.bpjsUsecase.PayContributions(ctx, req.Month)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrInvalidPayrollPeriod):
+			return helper.ResponseErrorJson(c, http.StatusBadRequest, err)
+		case errors.Is(err, model.ErrNoContributionsToPay), errors.Is(err, model.ErrInsufficientBalance):
+			return helper.ResponseErrorJson(c, http.StatusConflict, err)
+		}
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", trx)
+}
@@ -11,14 +11,15 @@ import (
 
 type companyDelivery struct {
 	companyUsecase model.CompanyUsecase
+	idempotent     echo.MiddlewareFunc
 }
 
 type CompanyDelivery interface {
 	Mount(group *echo.Group)
 }
 
-func NewCompanyDelivery(companyUsecase model.CompanyUsecase) CompanyDelivery {
-	return &companyDelivery{companyUsecase: companyUsecase}
+func NewCompanyDelivery(companyUsecase model.CompanyUsecase, idempotent echo.MiddlewareFunc) CompanyDelivery {
+	return &companyDelivery{companyUsecase: companyUsecase, idempotent: idempotent}
 }
 
 func (comp *companyDelivery) Mount(group *echo.Group) {
@@ -30,7 +31,7 @@ func (comp *companyDelivery) Mount(group *echo.Group) {
 	group.POST("", comp.UpdateOrCreateCompanyHandler)
 	//EOL
 
-	group.POST("/topup", comp.TopupBalanceHandler)
+	group.POST("/topup", comp.TopupBalanceHandler, comp.idempotent)
 
 }
 
@@ -0,0 +1,84 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"self-payrol/helper"
+	"self-payrol/model"
+	"self-payrol/request"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type employmentDelivery struct {
+	employmentUsecase model.EmploymentUsecase
+}
+
+type EmploymentDelivery interface {
+	Mount(group *echo.Group)
+}
+
+func NewEmploymentDelivery(employmentUsecase model.EmploymentUsecase) EmploymentDelivery {
+	return &employmentDelivery{employmentUsecase: employmentUsecase}
+}
+
+// Mount expects the /employee group, every transition takes a reason.
+func (e *employmentDelivery) Mount(group *echo.Group) {
+	group.POST("/:id/leave", e.ChangeStatusHandler(model.EmploymentOnLeave))
+	group.POST("/:id/suspend", e.ChangeStatusHandler(model.EmploymentSuspended))
+	group.POST("/:id/reinstate", e.ChangeStatusHandler(model.EmploymentActive))
+	group.POST("/:id/terminate", e.ChangeStatusHandler(model.EmploymentTerminated))
+	group.GET("/:id/status-history", e.StatusHistoryHandler)
+}
+
+func (e *employmentDelivery) ChangeStatusHandler(status string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var req request.EmploymentStatusRequest
+
+		if err := c.Bind(&req); err != nil {
+			return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+		}
+
+		if err := req.Validate(); err != nil {
+			errVal := err.(validation.Errors)
+			return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
+		}
+
+		id, _ := strconv.Atoi(c.Param("id"))
+
+		user, err := e.employmentUsecase.ChangeStatus(ctx, id, status, &req)
+		if err != nil {
+			return employmentError(c, err)
+		}
+
+		return helper.ResponseSuccessJson(c, "success", user)
+	}
+}
+
+func (e *employmentDelivery) StatusHistoryHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	events, err := e.employmentUsecase.FetchStatusHistory(ctx, id)
+	if err != nil {
+		return employmentError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", events)
+}
+
+func employmentError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+	case errors.Is(err, model.ErrInvalidStatusTransition):
+		return helper.ResponseErrorJson(c, http.StatusConflict, err)
+	}
+	return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+}
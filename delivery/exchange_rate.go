@@ -0,0 +1,153 @@
+package delivery
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"self-payrol/helper"
+	"self-payrol/model"
+	"self-payrol/request"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+)
+
+type exchangeRateDelivery struct {
+	exchangeRateUsecase model.ExchangeRateUsecase
+}
+
+type ExchangeRateDelivery interface {
+	Mount(group *echo.Group)
+}
+
+func NewExchangeRateDelivery(exchangeRateUsecase model.ExchangeRateUsecase) ExchangeRateDelivery {
+	return &exchangeRateDelivery{exchangeRateUsecase: exchangeRateUsecase}
+}
+
+func (e *exchangeRateDelivery) Mount(group *echo.Group) {
+	group.GET("", e.FetchRatesHandler)
+	group.POST("", e.StoreRatesHandler)
+	group.POST("/import", e.ImportRatesHandler)
+}
+
+// FetchRatesHandler lists the rates newest first, of ?currency=USD only
+// when given.
+func (e *exchangeRateDelivery) FetchRatesHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	limitInt, _ := strconv.Atoi(c.QueryParam("limit"))
+	offsetInt, _ := strconv.Atoi(c.QueryParam("offset"))
+
+	rates, err := e.exchangeRateUsecase.FetchRates(ctx, strings.ToUpper(c.QueryParam("currency")), limitInt, offsetInt)
+	if err != nil {
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", rates)
+}
+
+func (e *exchangeRateDelivery) StoreRatesHandler(c echo.Context) error {
+	var req request.ExchangeRateRequest
+
+	if err := c.Bind(&req); err != nil {
+		return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+	}
+
+	return e.storeRates(c, &req)
+}
+
+// ImportRatesHandler takes the rates as a CSV `file` with a header row
+// naming the columns currency, quote_currency (optional), rate and
+// effective_from.
+func (e *exchangeRateDelivery) ImportRatesHandler(c echo.Context) error {
+	file, err := c.FormFile("file")
+	if err != nil {
+		return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
+	defer src.Close()
+
+	req, err := readExchangeRates(src)
+	if err != nil {
+		return helper.ResponseValidationErrorJson(c, "Error validation", validation.Errors{"file": err})
+	}
+
+	return e.storeRates(c, req)
+}
+
+func (e *exchangeRateDelivery) storeRates(c echo.Context, req *request.ExchangeRateRequest) error {
+	ctx := c.Request().Context()
+
+	if err := req.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
+	}
+
+	rates, err := e.exchangeRateUsecase.StoreRates(ctx, req)
+	if err != nil {
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", rates)
+}
+
+// readExchangeRates reads the rows of a rates CSV, the columns are found
+// by the names in its header row.
+func readExchangeRates(r io.Reader) (*request.ExchangeRateRequest, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"currency", "rate", "effective_from"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("has no %s column", name)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	req := new(request.ExchangeRateRequest)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		req.Rates = append(req.Rates, request.ExchangeRateItem{
+			Currency:      strings.ToUpper(field(record, "currency")),
+			QuoteCurrency: strings.ToUpper(field(record, "quote_currency")),
+			Rate:          field(record, "rate"),
+			EffectiveFrom: field(record, "effective_from"),
+		})
+	}
+
+	return req, nil
+}
@@ -0,0 +1,107 @@
+package delivery
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"self-payrol/helper"
+	"self-payrol/model"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	// maxIdempotentBody bounds the body read into memory to fingerprint a
+	// request, the routes guarded only take small JSON bodies.
+	maxIdempotentBody = 1 << 20
+)
+
+type (
+	idempotencyDelivery struct {
+		idempotencyUsecase model.IdempotencyUsecase
+	}
+
+	// responseRecorder keeps a copy of everything the handler writes so it
+	// can be replayed for a retried request.
+	responseRecorder struct {
+		http.ResponseWriter
+		body *bytes.Buffer
+	}
+)
+
+// NewIdempotencyMiddleware makes POST requests carrying an Idempotency-Key
+// header safe to retry: the first response is stored and replayed for later
+// requests with the same key and body. It is mounted route by route on the
+// ones that move money, not on the whole server.
+func NewIdempotencyMiddleware(idempotencyUsecase model.IdempotencyUsecase) echo.MiddlewareFunc {
+	d := &idempotencyDelivery{idempotencyUsecase: idempotencyUsecase}
+	return d.Handle
+}
+
+func (d *idempotencyDelivery) Handle(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		key := c.Request().Header.Get(HeaderIdempotencyKey)
+		if key == "" || c.Request().Method != http.MethodPost {
+			return next(c)
+		}
+
+		ctx := c.Request().Context()
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Response(), c.Request().Body, maxIdempotentBody))
+		if err != nil {
+			return helper.ResponseErrorJson(c, http.StatusRequestEntityTooLarge, err)
+		}
+		c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+		record, err := d.idempotencyUsecase.Begin(ctx, key, fingerprint(c.Request(), body))
+		if err != nil {
+			if errors.Is(err, model.ErrIdempotencyKeyReused) || errors.Is(err, model.ErrIdempotencyKeyInProgress) {
+				return helper.ResponseErrorJson(c, http.StatusConflict, err)
+			}
+			return helper.ResponseErrorJson(c, http.StatusInternalServerError, err)
+		}
+
+		if record != nil {
+			c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+			return c.JSONBlob(record.StatusCode, record.Response)
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Response().Writer, body: new(bytes.Buffer)}
+		c.Response().Writer = recorder
+
+		err = next(c)
+
+		// errors and server failures are not stored, the client may retry them
+		status := c.Response().Status
+		if err != nil || status >= http.StatusInternalServerError {
+			if errRelease := d.idempotencyUsecase.Release(ctx, key); errRelease != nil {
+				c.Logger().Error(errRelease)
+			}
+			return err
+		}
+
+		if errComplete := d.idempotencyUsecase.Complete(ctx, key, status, recorder.body.Bytes()); errComplete != nil {
+			c.Logger().Error(errComplete)
+		}
+
+		return nil
+	}
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func fingerprint(req *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
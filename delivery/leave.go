@@ -0,0 +1,140 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"self-payrol/helper"
+	"self-payrol/model"
+	"self-payrol/request"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type leaveDelivery struct {
+	leaveUsecase model.LeaveUsecase
+}
+
+type LeaveDelivery interface {
+	Mount(group *echo.Group)
+}
+
+func NewLeaveDelivery(leaveUsecase model.LeaveUsecase) LeaveDelivery {
+	return &leaveDelivery{leaveUsecase: leaveUsecase}
+}
+
+// Mount expects the /employee group, leave lives under an employee.
+// /:id/leave itself puts the employee on leave, see EmploymentDelivery.
+func (l *leaveDelivery) Mount(group *echo.Group) {
+	group.GET("/:id/leave-balance", l.LeaveBalanceHandler)
+	group.GET("/:id/leave-requests", l.FetchLeaveHandler)
+	group.POST("/:id/leave-requests", l.RequestLeaveHandler)
+	group.GET("/:id/leave-requests/:leave_id", l.DetailLeaveHandler)
+	group.POST("/:id/leave-requests/:leave_id/approve", l.ApproveLeaveHandler)
+	group.POST("/:id/leave-requests/:leave_id/reject", l.RejectLeaveHandler)
+}
+
+func (l *leaveDelivery) LeaveBalanceHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+
+	balance, err := l.leaveUsecase.GetBalance(ctx, userID)
+	if err != nil {
+		return leaveError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", balance)
+}
+
+func (l *leaveDelivery) FetchLeaveHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+
+	leaves, err := l.leaveUsecase.FetchLeave(ctx, userID)
+	if err != nil {
+		return leaveError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", leaves)
+}
+
+func (l *leaveDelivery) RequestLeaveHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req request.LeaveRequest
+
+	if err := c.Bind(&req); err != nil {
+		return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
+	}
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+
+	leave, err := l.leaveUsecase.RequestLeave(ctx, userID, &req)
+	if err != nil {
+		return leaveError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", leave)
+}
+
+func (l *leaveDelivery) DetailLeaveHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("leave_id"))
+
+	leave, err := l.leaveUsecase.GetByID(ctx, userID, id)
+	if err != nil {
+		return leaveError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", leave)
+}
+
+func (l *leaveDelivery) ApproveLeaveHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("leave_id"))
+
+	leave, err := l.leaveUsecase.ApproveLeave(ctx, userID, id)
+	if err != nil {
+		return leaveError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", leave)
+}
+
+func (l *leaveDelivery) RejectLeaveHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("leave_id"))
+
+	leave, err := l.leaveUsecase.RejectLeave(ctx, userID, id)
+	if err != nil {
+		return leaveError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", leave)
+}
+
+func leaveError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+	case errors.Is(err, model.ErrLeaveNotPending), errors.Is(err, model.ErrLeaveOverlaps),
+		errors.Is(err, model.ErrInsufficientLeave):
+		return helper.ResponseErrorJson(c, http.StatusConflict, err)
+	}
+	return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+}
@@ -0,0 +1,114 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"self-payrol/helper"
+	"self-payrol/model"
+	"self-payrol/request"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type loanDelivery struct {
+	loanUsecase model.LoanUsecase
+	idempotent  echo.MiddlewareFunc
+}
+
+type LoanDelivery interface {
+	Mount(group *echo.Group)
+}
+
+func NewLoanDelivery(loanUsecase model.LoanUsecase, idempotent echo.MiddlewareFunc) LoanDelivery {
+	return &loanDelivery{loanUsecase: loanUsecase, idempotent: idempotent}
+}
+
+// Mount expects the /employee group, loans live under an employee.
+func (l *loanDelivery) Mount(group *echo.Group) {
+	group.GET("/:id/loans", l.FetchLoanHandler)
+	group.POST("/:id/loans", l.GrantLoanHandler, l.idempotent)
+	group.GET("/:id/loans/:loan_id", l.DetailLoanHandler)
+	group.POST("/:id/loans/:loan_id/payoff", l.PayOffLoanHandler, l.idempotent)
+}
+
+func (l *loanDelivery) FetchLoanHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+
+	loans, err := l.loanUsecase.FetchLoan(ctx, userID)
+	if err != nil {
+		return loanError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", loans)
+}
+
+func (l *loanDelivery) GrantLoanHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req request.LoanRequest
+
+	if err := c.Bind(&req); err != nil {
+		return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
+	}
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+
+	loan, err := l.loanUsecase.GrantLoan(ctx, userID, &req)
+	if err != nil {
+		return loanError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", loan)
+}
+
+func (l *loanDelivery) DetailLoanHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("loan_id"))
+
+	loan, err := l.loanUsecase.GetByID(ctx, userID, id)
+	if err != nil {
+		return loanError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", loan)
+}
+
+func (l *loanDelivery) PayOffLoanHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("loan_id"))
+
+	loan, err := l.loanUsecase.PayOffLoan(ctx, userID, id)
+	if err != nil {
+		return loanError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", loan)
+}
+
+func loanError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+	case errors.Is(err, model.ErrInvalidPayrollPeriod), errors.Is(err, model.ErrLoanTooSmall):
+		return helper.ResponseErrorJson(c, http.StatusBadRequest, err)
+	case errors.Is(err, model.ErrEmployeeNotActive):
+		return helper.ResponseErrorJson(c, http.StatusForbidden, err)
+	case errors.Is(err, model.ErrLoanRepaid), errors.Is(err, model.ErrInsufficientBalance):
+		return helper.ResponseErrorJson(c, http.StatusConflict, err)
+	}
+	return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+}
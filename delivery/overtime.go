@@ -0,0 +1,139 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"self-payrol/helper"
+	"self-payrol/model"
+	"self-payrol/request"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type overtimeDelivery struct {
+	overtimeUsecase model.OvertimeUsecase
+}
+
+type OvertimeDelivery interface {
+	Mount(group *echo.Group)
+}
+
+func NewOvertimeDelivery(overtimeUsecase model.OvertimeUsecase) OvertimeDelivery {
+	return &overtimeDelivery{overtimeUsecase: overtimeUsecase}
+}
+
+// Mount expects the /employee group, overtime lives under an employee.
+func (o *overtimeDelivery) Mount(group *echo.Group) {
+	group.GET("/:id/overtime", o.FetchOvertimeHandler)
+	group.POST("/:id/overtime", o.StoreOvertimeHandler)
+	group.GET("/:id/overtime/:overtime_id", o.DetailOvertimeHandler)
+	group.DELETE("/:id/overtime/:overtime_id", o.DeleteOvertimeHandler)
+	group.POST("/:id/overtime/:overtime_id/approve", o.ApproveOvertimeHandler)
+	group.POST("/:id/overtime/:overtime_id/reject", o.RejectOvertimeHandler)
+}
+
+func (o *overtimeDelivery) FetchOvertimeHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+
+	overtime, err := o.overtimeUsecase.FetchOvertime(ctx, userID)
+	if err != nil {
+		return overtimeError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", overtime)
+}
+
+func (o *overtimeDelivery) StoreOvertimeHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req request.OvertimeRequest
+
+	if err := c.Bind(&req); err != nil {
+		return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
+	}
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+
+	overtime, err := o.overtimeUsecase.StoreOvertime(ctx, userID, &req)
+	if err != nil {
+		return overtimeError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", overtime)
+}
+
+func (o *overtimeDelivery) DetailOvertimeHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("overtime_id"))
+
+	overtime, err := o.overtimeUsecase.GetByID(ctx, userID, id)
+	if err != nil {
+		return overtimeError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", overtime)
+}
+
+func (o *overtimeDelivery) DeleteOvertimeHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("overtime_id"))
+
+	err := o.overtimeUsecase.DestroyOvertime(ctx, userID, id)
+	if err != nil {
+		return overtimeError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", nil)
+}
+
+func (o *overtimeDelivery) ApproveOvertimeHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("overtime_id"))
+
+	overtime, err := o.overtimeUsecase.ApproveOvertime(ctx, userID, id)
+	if err != nil {
+		return overtimeError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", overtime)
+}
+
+func (o *overtimeDelivery) RejectOvertimeHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("overtime_id"))
+
+	overtime, err := o.overtimeUsecase.RejectOvertime(ctx, userID, id)
+	if err != nil {
+		return overtimeError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", overtime)
+}
+
+func overtimeError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+	case errors.Is(err, model.ErrOvertimeNotPending), errors.Is(err, model.ErrOvertimePaid):
+		return helper.ResponseErrorJson(c, http.StatusConflict, err)
+	}
+	return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+}
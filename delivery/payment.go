@@ -0,0 +1,159 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"self-payrol/helper"
+	"self-payrol/model"
+	"self-payrol/request"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type paymentDelivery struct {
+	paymentUsecase model.PaymentUsecase
+	idempotent     echo.MiddlewareFunc
+}
+
+type PaymentDelivery interface {
+	Mount(group *echo.Group)
+}
+
+func NewPaymentDelivery(paymentUsecase model.PaymentUsecase, idempotent echo.MiddlewareFunc) PaymentDelivery {
+	return &paymentDelivery{paymentUsecase: paymentUsecase, idempotent: idempotent}
+}
+
+// Mount expects the /employee group, payments live under an employee.
+func (p *paymentDelivery) Mount(group *echo.Group) {
+	group.GET("/:id/payments", p.FetchPaymentHandler)
+	group.POST("/:id/payments", p.StorePaymentHandler)
+	group.GET("/:id/payments/:payment_id", p.DetailPaymentHandler)
+	group.DELETE("/:id/payments/:payment_id", p.DeletePaymentHandler)
+	group.POST("/:id/payments/:payment_id/approve", p.ApprovePaymentHandler)
+	group.POST("/:id/payments/:payment_id/reject", p.RejectPaymentHandler)
+	group.POST("/:id/payments/:payment_id/disburse", p.DisbursePaymentHandler, p.idempotent)
+}
+
+func (p *paymentDelivery) FetchPaymentHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+
+	payment, err := p.paymentUsecase.FetchPayment(ctx, userID)
+	if err != nil {
+		return paymentError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", payment)
+}
+
+func (p *paymentDelivery) StorePaymentHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req request.PaymentRequest
+
+	if err := c.Bind(&req); err != nil {
+		return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
+	}
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+
+	payment, err := p.paymentUsecase.StorePayment(ctx, userID, &req)
+	if err != nil {
+		return paymentError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", payment)
+}
+
+func (p *paymentDelivery) DetailPaymentHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("payment_id"))
+
+	payment, err := p.paymentUsecase.GetByID(ctx, userID, id)
+	if err != nil {
+		return paymentError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", payment)
+}
+
+func (p *paymentDelivery) DeletePaymentHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("payment_id"))
+
+	err := p.paymentUsecase.DestroyPayment(ctx, userID, id)
+	if err != nil {
+		return paymentError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", nil)
+}
+
+func (p *paymentDelivery) ApprovePaymentHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("payment_id"))
+
+	payment, err := p.paymentUsecase.ApprovePayment(ctx, userID, id)
+	if err != nil {
+		return paymentError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", payment)
+}
+
+func (p *paymentDelivery) RejectPaymentHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("payment_id"))
+
+	payment, err := p.paymentUsecase.RejectPayment(ctx, userID, id)
+	if err != nil {
+		return paymentError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", payment)
+}
+
+func (p *paymentDelivery) DisbursePaymentHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("payment_id"))
+
+	trx, err := p.paymentUsecase.DisbursePayment(ctx, userID, id)
+	if err != nil {
+		return paymentError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", trx)
+}
+
+func paymentError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+	case errors.Is(err, model.ErrInvalidPayrollPeriod):
+		return helper.ResponseErrorJson(c, http.StatusBadRequest, err)
+	case errors.Is(err, model.ErrPaymentNotPending), errors.Is(err, model.ErrPaymentNotApproved),
+		errors.Is(err, model.ErrPaymentPaid), errors.Is(err, model.ErrPaymentPaidWithSalary),
+		errors.Is(err, model.ErrInsufficientBalance):
+		return helper.ResponseErrorJson(c, http.StatusConflict, err)
+	}
+	return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+}
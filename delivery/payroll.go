@@ -0,0 +1,40 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"self-payrol/helper"
+	"self-payrol/model"
+
+	"github.com/labstack/echo/v4"
+)
+
+type payrollDelivery struct {
+	payrollUsecase model.PayrollUsecase
+}
+
+type PayrollDelivery interface {
+	Mount(group *echo.Group)
+}
+
+func NewPayrollDelivery(payrollUsecase model.PayrollUsecase) PayrollDelivery {
+	return &payrollDelivery{payrollUsecase: payrollUsecase}
+}
+
+func (p *payrollDelivery) Mount(group *echo.Group) {
+	group.GET("/preview", p.PreviewHandler)
+}
+
+func (p *payrollDelivery) PreviewHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	preview, err := p.payrollUsecase.Preview(ctx, c.QueryParam("period"))
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidPayrollPeriod) {
+			return helper.ResponseErrorJson(c, http.StatusBadRequest, err)
+		}
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", preview)
+}
@@ -0,0 +1,193 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"self-payrol/bankfile"
+	"self-payrol/helper"
+	"self-payrol/model"
+	"self-payrol/request"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type payrollRunDelivery struct {
+	payrollRunUsecase model.PayrollRunUsecase
+	idempotent        echo.MiddlewareFunc
+}
+
+type PayrollRunDelivery interface {
+	Mount(group *echo.Group)
+}
+
+func NewPayrollRunDelivery(payrollRunUsecase model.PayrollRunUsecase, idempotent echo.MiddlewareFunc) PayrollRunDelivery {
+	return &payrollRunDelivery{payrollRunUsecase: payrollRunUsecase, idempotent: idempotent}
+}
+
+// Mount expects the /payroll-runs group, every change names the acting
+// admin.
+func (p *payrollRunDelivery) Mount(group *echo.Group) {
+	group.POST("", p.DraftPayrollRunHandler)
+	group.GET("", p.FetchPayrollRunHandler)
+	group.GET("/:id", p.DetailPayrollRunHandler)
+	group.GET("/:id/bank-file", p.BankFileHandler)
+	group.PUT("/:id/items/:item_id", p.EditItemHandler)
+	group.POST("/:id/submit", p.TransitionHandler(p.payrollRunUsecase.Submit))
+	group.POST("/:id/approve", p.TransitionHandler(p.payrollRunUsecase.Approve))
+	group.POST("/:id/reject", p.TransitionHandler(p.payrollRunUsecase.Reject))
+	group.POST("/:id/disburse", p.TransitionHandler(p.payrollRunUsecase.Disburse), p.idempotent)
+}
+
+// DraftPayrollRunHandler drafts a payroll run by hand, outside the schedule.
+func (p *payrollRunDelivery) DraftPayrollRunHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req request.PayrollRunActionRequest
+
+	if err := c.Bind(&req); err != nil {
+		return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
+	}
+
+	run, err := p.payrollRunUsecase.Draft(ctx, model.PayrollRunManual, &req)
+	if err != nil {
+		return payrollRunError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", run)
+}
+
+func (p *payrollRunDelivery) FetchPayrollRunHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	limit := c.QueryParam("limit")
+	offset := c.QueryParam("offset")
+
+	limitInt, _ := strconv.Atoi(limit)
+	offsetInt, _ := strconv.Atoi(offset)
+
+	runs, err := p.payrollRunUsecase.FetchPayrollRuns(ctx, limitInt, offsetInt)
+	if err != nil {
+		return payrollRunError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", runs)
+}
+
+func (p *payrollRunDelivery) DetailPayrollRunHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	run, err := p.payrollRunUsecase.GetByID(ctx, id)
+	if err != nil {
+		return payrollRunError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", run)
+}
+
+// BankFileHandler downloads the payouts of a disbursed run as a bank bulk
+// transfer file, ?format=csv by default or the name of a template, for
+// the ?currency they were paid in. The record count and control total are
+// repeated in the X-Record-Count and X-Control-Total headers.
+func (p *payrollRunDelivery) BankFileHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = bankfile.FormatCSV
+	}
+
+	file, err := p.payrollRunUsecase.BankFile(ctx, id, format, strings.ToUpper(c.QueryParam("currency")))
+	if err != nil {
+		return payrollRunError(c, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+file.Name+`"`)
+	c.Response().Header().Set("X-Record-Count", strconv.Itoa(file.Records))
+	c.Response().Header().Set("X-Control-Total", file.Total.String())
+
+	return c.Blob(http.StatusOK, file.ContentType, file.Content)
+}
+
+func (p *payrollRunDelivery) EditItemHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req request.PayrollRunItemRequest
+
+	if err := c.Bind(&req); err != nil {
+		return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
+	}
+
+	id, _ := strconv.Atoi(c.Param("id"))
+	itemID, _ := strconv.Atoi(c.Param("item_id"))
+
+	run, err := p.payrollRunUsecase.EditItem(ctx, id, itemID, &req)
+	if err != nil {
+		return payrollRunError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", run)
+}
+
+func (p *payrollRunDelivery) TransitionHandler(transition func(ctx context.Context, id int, req *request.PayrollRunActionRequest) (*model.PayrollRun, error)) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+
+		var req request.PayrollRunActionRequest
+
+		if err := c.Bind(&req); err != nil {
+			return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+		}
+
+		if err := req.Validate(); err != nil {
+			errVal := err.(validation.Errors)
+			return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
+		}
+
+		id, _ := strconv.Atoi(c.Param("id"))
+
+		run, err := transition(ctx, id, &req)
+		if err != nil {
+			return payrollRunError(c, err)
+		}
+
+		return helper.ResponseSuccessJson(c, "success", run)
+	}
+}
+
+func payrollRunError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+	case errors.Is(err, model.ErrUnknownBankFileFormat),
+		errors.Is(err, model.ErrBankFileCurrency):
+		return helper.ResponseErrorJson(c, http.StatusBadRequest, err)
+	case errors.Is(err, model.ErrPayrollRunSelfApproval):
+		return helper.ResponseErrorJson(c, http.StatusForbidden, err)
+	case errors.Is(err, model.ErrPayrollRunNotDraft),
+		errors.Is(err, model.ErrInvalidPayrollRunTransition),
+		errors.Is(err, model.ErrInsufficientBalance),
+		errors.Is(err, model.ErrNoExchangeRate),
+		errors.Is(err, model.ErrPayrollRunNotDisbursed):
+		return helper.ResponseErrorJson(c, http.StatusConflict, err)
+	}
+	return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+}
@@ -0,0 +1,66 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"self-payrol/helper"
+	"self-payrol/model"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type payslipDelivery struct {
+	payslipUsecase model.PayslipUsecase
+}
+
+type PayslipDelivery interface {
+	Mount(group *echo.Group)
+}
+
+func NewPayslipDelivery(payslipUsecase model.PayslipUsecase) PayslipDelivery {
+	return &payslipDelivery{payslipUsecase: payslipUsecase}
+}
+
+// Mount expects the /employee group, payslips live under an employee.
+func (p *payslipDelivery) Mount(group *echo.Group) {
+	group.GET("/:id/payslips", p.FetchPayslipHandler)
+	group.GET("/:id/payslips/:period", p.DownloadPayslipHandler)
+}
+
+func (p *payslipDelivery) FetchPayslipHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	payslips, err := p.payslipUsecase.FetchPayslips(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+		}
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", payslips)
+}
+
+func (p *payslipDelivery) DownloadPayslipHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	payslip, pdf, err := p.payslipUsecase.RenderPayslip(ctx, id, c.Param("period"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+		}
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
+
+	filename := "payslip-" + strings.ReplaceAll(payslip.Number, "/", "-") + ".pdf"
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+
+	return c.Blob(http.StatusOK, "application/pdf", pdf)
+}
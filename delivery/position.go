@@ -1,6 +1,7 @@
 package delivery
 
 import (
+	"errors"
 	"net/http"
 	"self-payrol/helper"
 	"self-payrol/model"
@@ -9,6 +10,7 @@ import (
 
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 type positionDelivery struct {
@@ -29,6 +31,7 @@ func (p *positionDelivery) Mount(group *echo.Group) {
 	group.GET("/:id", p.DetailPositionHandler)
 	group.DELETE("/:id", p.DeletePositionHandler)
 	group.PATCH("/:id", p.EditPositionHandler)
+	group.GET("/:id/salary-history", p.SalaryHistoryHandler)
 }
 
 func (p *positionDelivery) FetchPositionHandler(c echo.Context) error {
@@ -64,6 +67,9 @@ func (p *positionDelivery) StorePositionHandler(c echo.Context) error {
 	}
 
 	position, err := p.positionUsecase.StorePosition(ctx, &req)
+	if errors.Is(err, model.ErrSalaryCurrency) {
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err)
 	}
@@ -129,3 +135,19 @@ func (p *positionDelivery) EditPositionHandler(c echo.Context) error {
 
 	return helper.ResponseSuccessJson(c, "Success edit", position)
 }
+
+func (p *positionDelivery) SalaryHistoryHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	history, err := p.positionUsecase.FetchSalaryHistory(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+		}
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", history)
+}
@@ -0,0 +1,131 @@
+package delivery
+
+import (
+	"errors"
+	"net/http"
+	"self-payrol/helper"
+	"self-payrol/model"
+	"self-payrol/request"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type positionComponentDelivery struct {
+	componentUsecase model.PositionComponentUsecase
+}
+
+type PositionComponentDelivery interface {
+	Mount(group *echo.Group)
+}
+
+func NewPositionComponentDelivery(componentUsecase model.PositionComponentUsecase) PositionComponentDelivery {
+	return &positionComponentDelivery{componentUsecase: componentUsecase}
+}
+
+// Mount expects the /positions group, components live under a position.
+func (p *positionComponentDelivery) Mount(group *echo.Group) {
+	group.GET("/:id/components", p.FetchComponentHandler)
+	group.POST("/:id/components", p.StoreComponentHandler)
+	group.GET("/:id/components/:component_id", p.DetailComponentHandler)
+	group.DELETE("/:id/components/:component_id", p.DeleteComponentHandler)
+	group.PATCH("/:id/components/:component_id", p.EditComponentHandler)
+}
+
+func (p *positionComponentDelivery) FetchComponentHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	positionID, _ := strconv.Atoi(c.Param("id"))
+
+	componentList, err := p.componentUsecase.FetchComponent(ctx, positionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+		}
+		return helper.ResponseErrorJson(c, http.StatusBadRequest, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", componentList)
+}
+
+func (p *positionComponentDelivery) StoreComponentHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req request.PositionComponentRequest
+
+	if err := c.Bind(&req); err != nil {
+		return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
+	}
+
+	positionID, _ := strconv.Atoi(c.Param("id"))
+
+	component, err := p.componentUsecase.StoreComponent(ctx, positionID, &req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+		}
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", component)
+}
+
+func (p *positionComponentDelivery) DetailComponentHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	positionID, _ := strconv.Atoi(c.Param("id"))
+	componentID, _ := strconv.Atoi(c.Param("component_id"))
+
+	component, err := p.componentUsecase.GetByID(ctx, positionID, componentID)
+	if err != nil {
+		return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "", component)
+}
+
+func (p *positionComponentDelivery) DeleteComponentHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	positionID, _ := strconv.Atoi(c.Param("id"))
+	componentID, _ := strconv.Atoi(c.Param("component_id"))
+
+	err := p.componentUsecase.DestroyComponent(ctx, positionID, componentID)
+	if err != nil {
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "", "")
+}
+
+func (p *positionComponentDelivery) EditComponentHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req request.PositionComponentRequest
+
+	if err := c.Bind(&req); err != nil {
+		return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
+	}
+
+	positionID, _ := strconv.Atoi(c.Param("id"))
+	componentID, _ := strconv.Atoi(c.Param("component_id"))
+
+	component, err := p.componentUsecase.EditComponent(ctx, positionID, componentID, &req)
+	if err != nil {
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "Success edit", component)
+}
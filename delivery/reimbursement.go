@@ -0,0 +1,234 @@
+package delivery
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"self-payrol/helper"
+	"self-payrol/model"
+	"self-payrol/request"
+	"strconv"
+	"strings"
+	"unicode"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxReceiptSize caps an uploaded receipt at 5 MB.
+	maxReceiptSize = 5 << 20
+	// maxReceiptName caps the stored file name of a receipt, in runes.
+	maxReceiptName = 255
+)
+
+type reimbursementDelivery struct {
+	reimbursementUsecase model.ReimbursementUsecase
+	idempotent           echo.MiddlewareFunc
+}
+
+type ReimbursementDelivery interface {
+	Mount(group *echo.Group)
+}
+
+func NewReimbursementDelivery(reimbursementUsecase model.ReimbursementUsecase, idempotent echo.MiddlewareFunc) ReimbursementDelivery {
+	return &reimbursementDelivery{reimbursementUsecase: reimbursementUsecase, idempotent: idempotent}
+}
+
+// Mount expects the /employee group, claims live under an employee.
+func (r *reimbursementDelivery) Mount(group *echo.Group) {
+	group.GET("/:id/reimbursements", r.FetchReimbursementHandler)
+	group.POST("/:id/reimbursements", r.StoreReimbursementHandler)
+	group.GET("/:id/reimbursements/:claim_id", r.DetailReimbursementHandler)
+	group.DELETE("/:id/reimbursements/:claim_id", r.DeleteReimbursementHandler)
+	group.GET("/:id/reimbursements/:claim_id/receipt", r.DownloadReceiptHandler)
+	group.POST("/:id/reimbursements/:claim_id/approve", r.ApproveReimbursementHandler, r.idempotent)
+	group.POST("/:id/reimbursements/:claim_id/reject", r.RejectReimbursementHandler)
+}
+
+func (r *reimbursementDelivery) FetchReimbursementHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+
+	claims, err := r.reimbursementUsecase.FetchReimbursement(ctx, userID)
+	if err != nil {
+		return reimbursementError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", claims)
+}
+
+func (r *reimbursementDelivery) StoreReimbursementHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req request.ReimbursementRequest
+
+	if err := c.Bind(&req); err != nil {
+		return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+	}
+
+	file, err := c.FormFile("receipt")
+	switch {
+	case errors.Is(err, http.ErrMissingFile):
+		// left empty for the validation to report
+	case err != nil:
+		return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+	case file.Size > maxReceiptSize:
+		return helper.ResponseValidationErrorJson(c, "Error validation", validation.Errors{
+			"receipt": errors.New("must be at most 5 MB"),
+		})
+	default:
+		src, err := file.Open()
+		if err != nil {
+			return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+		}
+		defer src.Close()
+
+		req.Receipt, err = io.ReadAll(io.LimitReader(src, maxReceiptSize))
+		if err != nil {
+			return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+		}
+
+		// the type is sniffed from the file, not taken from the client
+		req.ReceiptName = receiptName(file.Filename)
+		req.ReceiptType = http.DetectContentType(req.Receipt)
+	}
+
+	if err := req.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
+	}
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+
+	claim, err := r.reimbursementUsecase.StoreReimbursement(ctx, userID, &req)
+	if err != nil {
+		return reimbursementError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", claim)
+}
+
+func (r *reimbursementDelivery) DetailReimbursementHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("claim_id"))
+
+	claim, err := r.reimbursementUsecase.GetByID(ctx, userID, id)
+	if err != nil {
+		return reimbursementError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", claim)
+}
+
+func (r *reimbursementDelivery) DeleteReimbursementHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("claim_id"))
+
+	err := r.reimbursementUsecase.DestroyReimbursement(ctx, userID, id)
+	if err != nil {
+		return reimbursementError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", nil)
+}
+
+func (r *reimbursementDelivery) DownloadReceiptHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("claim_id"))
+
+	claim, err := r.reimbursementUsecase.GetReceipt(ctx, userID, id)
+	if err != nil {
+		return reimbursementError(c, err)
+	}
+
+	// the name came from the client, FormatMediaType quotes and encodes it
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": receiptName(claim.ReceiptName)})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, disposition)
+
+	return c.Blob(http.StatusOK, claim.ReceiptType, claim.Receipt)
+}
+
+func (r *reimbursementDelivery) ApproveReimbursementHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req request.ReimbursementReviewRequest
+
+	if err := c.Bind(&req); err != nil {
+		return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
+	}
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("claim_id"))
+
+	claim, err := r.reimbursementUsecase.ApproveReimbursement(ctx, userID, id, &req)
+	if err != nil {
+		return reimbursementError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", claim)
+}
+
+func (r *reimbursementDelivery) RejectReimbursementHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, _ := strconv.Atoi(c.Param("id"))
+	id, _ := strconv.Atoi(c.Param("claim_id"))
+
+	claim, err := r.reimbursementUsecase.RejectReimbursement(ctx, userID, id)
+	if err != nil {
+		return reimbursementError(c, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", claim)
+}
+
+func reimbursementError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+	case errors.Is(err, model.ErrReimbursementNotPending), errors.Is(err, model.ErrReimbursementPaid),
+		errors.Is(err, model.ErrInsufficientBalance):
+		return helper.ResponseErrorJson(c, http.StatusConflict, err)
+	}
+	return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+}
+
+// receiptName keeps the base name of an uploaded file without control
+// characters, whatever path the client sent, or "receipt" when nothing is
+// left.
+func receiptName(filename string) string {
+	filename = filepath.Base(strings.ReplaceAll(filename, `\`, "/"))
+	name := strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == unicode.ReplacementChar {
+			return -1
+		}
+		return r
+	}, filename))
+
+	if runes := []rune(name); len(runes) > maxReceiptName {
+		name = string(runes[:maxReceiptName])
+	}
+	if name == "" || name == "." || name == ".." || name == "/" {
+		return "receipt"
+	}
+	return name
+}
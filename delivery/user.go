@@ -1,8 +1,10 @@
 package delivery
 
 import (
+	"errors"
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 	"net/http"
 	"self-payrol/helper"
 	"self-payrol/model"
@@ -12,14 +14,15 @@ import (
 
 type userDelivery struct {
 	userUsecase model.UserUsecase
+	idempotent  echo.MiddlewareFunc
 }
 
 type UserDelivery interface {
 	Mount(group *echo.Group)
 }
 
-func NewUserDelivery(userUsecase model.UserUsecase) UserDelivery {
-	return &userDelivery{userUsecase: userUsecase}
+func NewUserDelivery(userUsecase model.UserUsecase, idempotent echo.MiddlewareFunc) UserDelivery {
+	return &userDelivery{userUsecase: userUsecase, idempotent: idempotent}
 }
 
 func (p *userDelivery) Mount(group *echo.Group) {
@@ -28,7 +31,11 @@ func (p *userDelivery) Mount(group *echo.Group) {
 	group.GET("/:id", p.DetailUserHandler)
 	group.DELETE("/:id", p.DeleteUserHandler)
 	group.PATCH("/:id", p.EditUserHandler)
-	group.POST("/withdraw", p.WithdrawHandler)
+	group.POST("/withdraw", p.WithdrawHandler, p.idempotent)
+	group.GET("/:id/transactions", p.FetchUserTransactionHandler)
+	group.GET("/:id/tax-preview", p.TaxPreviewHandler)
+	group.PUT("/:id/salary", p.SetSalaryHandler)
+	group.GET("/:id/salary-history", p.SalaryHistoryHandler)
 }
 
 func (p *userDelivery) FetchUserHandler(c echo.Context) error {
@@ -143,11 +150,104 @@ func (p *userDelivery) WithdrawHandler(c echo.Context) error {
 		return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
 	}
 
-	err := p.userUsecase.WithdrawSalary(ctx, &req)
+	summary, err := p.userUsecase.WithdrawSalary(ctx, &req)
 	if err != nil {
+		if errors.Is(err, model.ErrSalaryAlreadyWithdrawn) || errors.Is(err, model.ErrInsufficientBalance) ||
+			errors.Is(err, model.ErrNoExchangeRate) {
+			return helper.ResponseErrorJson(c, http.StatusConflict, err)
+		}
+		if errors.Is(err, model.ErrEmployeeNotActive) {
+			return helper.ResponseErrorJson(c, http.StatusForbidden, err)
+		}
 		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
 	}
 
-	return helper.ResponseSuccessJson(c, "Success withdraw salary", "")
+	return helper.ResponseSuccessJson(c, "Success withdraw salary", summary)
 
 }
+
+func (p *userDelivery) FetchUserTransactionHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id := c.Param("id")
+	IdInt, _ := strconv.Atoi(id)
+
+	limit := c.QueryParam("limit")
+	offset := c.QueryParam("offset")
+
+	limitInt, _ := strconv.Atoi(limit)
+	offsetInt, _ := strconv.Atoi(offset)
+
+	transactions, err := p.userUsecase.FetchTransactions(ctx, IdInt, limitInt, offsetInt)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+		}
+		return helper.ResponseErrorJson(c, http.StatusInternalServerError, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", transactions)
+}
+
+func (p *userDelivery) TaxPreviewHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id := c.Param("id")
+	IdInt, _ := strconv.Atoi(id)
+
+	breakdown, err := p.userUsecase.TaxPreview(ctx, IdInt, c.QueryParam("period"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+		}
+		if errors.Is(err, model.ErrInvalidPayrollPeriod) {
+			return helper.ResponseErrorJson(c, http.StatusBadRequest, err)
+		}
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", breakdown)
+}
+
+func (p *userDelivery) SetSalaryHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req request.UserSalaryRequest
+
+	if err := c.Bind(&req); err != nil {
+		return helper.ResponseValidationErrorJson(c, "Error binding struct", err.Error())
+	}
+
+	if err := req.Validate(); err != nil {
+		errVal := err.(validation.Errors)
+		return helper.ResponseValidationErrorJson(c, "Error validation", errVal)
+	}
+
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	salary, err := p.userUsecase.SetSalary(ctx, id, &req)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+		}
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", salary)
+}
+
+func (p *userDelivery) SalaryHistoryHandler(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	history, err := p.userUsecase.FetchSalaryHistory(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helper.ResponseErrorJson(c, http.StatusNotFound, err)
+		}
+		return helper.ResponseErrorJson(c, http.StatusUnprocessableEntity, err)
+	}
+
+	return helper.ResponseSuccessJson(c, "success", history)
+}
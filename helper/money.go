@@ -0,0 +1,36 @@
+package helper
+
+import (
+	"self-payrol/money"
+	"strings"
+)
+
+// FormatMoney writes m the Indonesian way, with dots between thousands and
+// a decimal comma, e.g. Rp 5.001.000 or USD 1.250,50.
+func FormatMoney(m money.Money) string {
+	decimal := m.Decimal()
+
+	sign := ""
+	if strings.HasPrefix(decimal, "-") {
+		sign, decimal = "-", decimal[1:]
+	}
+
+	digits, fraction, _ := strings.Cut(decimal, ".")
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(d)
+	}
+	if fraction != "" {
+		b.WriteString("," + fraction)
+	}
+
+	symbol := m.Currency + " "
+	if m.Currency == "IDR" {
+		symbol = "Rp "
+	}
+
+	return sign + symbol + b.String()
+}
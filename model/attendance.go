@@ -0,0 +1,76 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"self-payrol/request"
+	"time"
+)
+
+const PayCodeAbsence = "absence"
+
+var (
+	ErrAlreadyClockedIn  = errors.New("employee has already clocked in today")
+	ErrNotClockedIn      = errors.New("employee has not clocked in today")
+	ErrAlreadyClockedOut = errors.New("employee has already clocked out today")
+)
+
+type (
+	// Attendance is one working day of an employee, from clocking in to
+	// clocking out.
+	Attendance struct {
+		ID        int        `json:"id"`
+		UserID    int        `json:"user_id" gorm:"uniqueIndex:idx_attendances_user_date"`
+		User      *User      `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+		Date      time.Time  `json:"date" gorm:"type:date;uniqueIndex:idx_attendances_user_date"`
+		ClockIn   time.Time  `json:"clock_in"`
+		ClockOut  *time.Time `json:"clock_out"`
+		Note      string     `json:"note"`
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt time.Time  `json:"updated_at"`
+	}
+
+	AttendanceRepository interface {
+		Create(ctx context.Context, attendance *Attendance) (*Attendance, error)
+		FindByDate(ctx context.Context, userID int, date time.Time) (*Attendance, error)
+		UpdateByID(ctx context.Context, id int, attendance *Attendance) (*Attendance, error)
+		// FetchByUserID returns the days from from up to to, newest first.
+		FetchByUserID(ctx context.Context, userID int, from, to time.Time) ([]*Attendance, error)
+	}
+
+	AttendanceUsecase interface {
+		ClockIn(ctx context.Context, userID int, req *request.ClockRequest) (*Attendance, error)
+		ClockOut(ctx context.Context, userID int, req *request.ClockRequest) (*Attendance, error)
+		// FetchAttendance lists the days of a payroll period, the current
+		// one when period is empty.
+		FetchAttendance(ctx context.Context, userID int, period string) ([]*Attendance, error)
+	}
+)
+
+// Absences lists the working days from from up to to that the employee
+// neither clocked in on nor spent on approved leave.
+func Absences(from, to time.Time, attendance []*Attendance, leaves []*Leave) []time.Time {
+	present := make(map[string]bool, len(attendance))
+	for _, day := range attendance {
+		present[day.Date.Format("2006-01-02")] = true
+	}
+
+	var absences []time.Time
+	for d := from; d.Before(to); d = d.AddDate(0, 0, 1) {
+		if IsRestDay(d) || present[d.Format("2006-01-02")] || onLeave(d, leaves) {
+			continue
+		}
+		absences = append(absences, d)
+	}
+
+	return absences
+}
+
+func onLeave(d time.Time, leaves []*Leave) bool {
+	for _, leave := range leaves {
+		if leave.Covers(d) {
+			return true
+		}
+	}
+	return false
+}
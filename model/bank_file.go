@@ -0,0 +1,63 @@
+package model
+
+import (
+	"errors"
+	"self-payrol/money"
+	"time"
+)
+
+var (
+	ErrUnknownBankFileFormat  = errors.New("bank file format not known")
+	ErrBankFileCurrency       = errors.New("bank file format does not take transfers in that currency")
+	ErrPayrollRunNotDisbursed = errors.New("payroll run has not been disbursed yet")
+	ErrNoBankAccount          = errors.New("employee has no bank account")
+)
+
+type (
+	// BankTransfer is one credit to an employee's bank account.
+	BankTransfer struct {
+		BankCode      string
+		AccountNumber string
+		AccountName   string
+		Amount        money.Money
+		Reference     string
+		Description   string
+	}
+
+	// BankTransferBatch is a set of transfers in Currency keyed into
+	// internet banking at once.
+	BankTransferBatch struct {
+		Reference string
+		Date      time.Time
+		Currency  string
+		Transfers []BankTransfer
+	}
+
+	// BankFile is a batch laid out in a bank's bulk transfer format, with
+	// the record count and control total it carries.
+	BankFile struct {
+		Name        string
+		ContentType string
+		Content     []byte
+		Records     int
+		Total       money.Money
+	}
+
+	// BankFileExporter lays a batch out in format, "csv" or the name of a
+	// fixed-width template.
+	BankFileExporter interface {
+		Export(batch *BankTransferBatch, format string) (*BankFile, error)
+	}
+)
+
+// Total adds up the transfers of the batch.
+func (b *BankTransferBatch) Total() (money.Money, error) {
+	total := money.New(0, b.Currency)
+	for _, transfer := range b.Transfers {
+		var err error
+		if total, err = total.Add(transfer.Amount); err != nil {
+			return money.Money{}, err
+		}
+	}
+	return total, nil
+}
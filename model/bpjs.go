@@ -0,0 +1,115 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"self-payrol/money"
+	"self-payrol/request"
+	"time"
+)
+
+const (
+	BPJSProgramJHT       = "jht"
+	BPJSProgramJP        = "jp"
+	BPJSProgramJKK       = "jkk"
+	BPJSProgramJKM       = "jkm"
+	BPJSProgramKesehatan = "kesehatan"
+)
+
+var ErrNoContributionsToPay = errors.New("no unpaid bpjs contributions for this period")
+
+type (
+	// BPJSRate is the company setting of one program. Rates are in basis
+	// points of the monthly wage, WageCap limits that wage when non-zero.
+	BPJSRate struct {
+		ID              int         `json:"id"`
+		CompanyID       int         `json:"company_id" gorm:"uniqueIndex:idx_bpjs_rates_company_program"`
+		Program         string      `json:"program" gorm:"uniqueIndex:idx_bpjs_rates_company_program"`
+		EmployeeRateBps int         `json:"employee_rate_bps"`
+		EmployerRateBps int         `json:"employer_rate_bps"`
+		WageCap         money.Money `json:"wage_cap" gorm:"embedded;embeddedPrefix:wage_cap_"`
+		CreatedAt       time.Time   `json:"created_at"`
+		UpdatedAt       time.Time   `json:"updated_at"`
+	}
+
+	// BPJSContribution is what is owed to BPJS for one employee, program and
+	// period. It stays a liability of the company until PaidTransactionID is
+	// set by paying the period.
+	BPJSContribution struct {
+		ID                int          `json:"id"`
+		UserID            int          `json:"user_id" gorm:"uniqueIndex:idx_bpjs_contributions_user_period_program"`
+		User              *User        `json:"-" gorm:"constraint:OnDelete:SET NULL;"`
+		Period            string       `json:"period" gorm:"uniqueIndex:idx_bpjs_contributions_user_period_program"`
+		Program           string       `json:"program" gorm:"uniqueIndex:idx_bpjs_contributions_user_period_program"`
+		Wage              money.Money  `json:"wage" gorm:"embedded;embeddedPrefix:wage_"`
+		EmployeeAmount    money.Money  `json:"employee_amount" gorm:"embedded;embeddedPrefix:employee_"`
+		EmployerAmount    money.Money  `json:"employer_amount" gorm:"embedded;embeddedPrefix:employer_"`
+		TransactionID     *int         `json:"transaction_id"`
+		Transaction       *Transaction `json:"-" gorm:"constraint:OnDelete:SET NULL;"`
+		PaidTransactionID *int         `json:"paid_transaction_id"`
+		PaidTransaction   *Transaction `json:"-" gorm:"constraint:OnDelete:SET NULL;"`
+		CreatedAt         time.Time    `json:"created_at"`
+		UpdatedAt         time.Time    `json:"updated_at"`
+	}
+
+	BPJSReportRow struct {
+		UserID         int         `json:"user_id"`
+		Name           string      `json:"name"`
+		Period         string      `json:"period"`
+		Program        string      `json:"program"`
+		Wage           money.Money `json:"wage"`
+		EmployeeAmount money.Money `json:"employee_amount"`
+		EmployerAmount money.Money `json:"employer_amount"`
+		Paid           bool        `json:"paid"`
+	}
+
+	// BPJSReport lists the contributions of one month, both halves of a
+	// semimonthly month included.
+	BPJSReport struct {
+		Month         string          `json:"month"`
+		Rows          []BPJSReportRow `json:"rows"`
+		TotalEmployee money.Money     `json:"total_employee"`
+		TotalEmployer money.Money     `json:"total_employer"`
+		Total         money.Money     `json:"total"`
+		Outstanding   money.Money     `json:"outstanding"`
+	}
+
+	BPJSRateRepository interface {
+		FetchByCompanyID(ctx context.Context, companyID int) ([]*BPJSRate, error)
+		Upsert(ctx context.Context, rates []*BPJSRate) ([]*BPJSRate, error)
+	}
+
+	BPJSContributionRepository interface {
+		CreateMany(ctx context.Context, contributions []*BPJSContribution) error
+		FetchByMonth(ctx context.Context, month string) ([]*BPJSContribution, error)
+		// LockUnpaidByMonth locks the unpaid contributions of month so they
+		// are paid once.
+		LockUnpaidByMonth(ctx context.Context, month string) ([]*BPJSContribution, error)
+		MarkPaid(ctx context.Context, ids []int, transactionID int) error
+	}
+
+	BPJSUsecase interface {
+		FetchRates(ctx context.Context) ([]*BPJSRate, error)
+		UpdateRates(ctx context.Context, req *request.BPJSRateRequest) ([]*BPJSRate, error)
+		Report(ctx context.Context, month string) (*BPJSReport, error)
+		PayContributions(ctx context.Context, month string) (*Transaction, error)
+	}
+)
+
+// DefaultBPJSRates are the statutory rates used until a company saves its
+// own. JKK is the lowest risk class, JP and health wage caps in rupiah as
+// of 2025.
+func DefaultBPJSRates(companyID int) []*BPJSRate {
+	return []*BPJSRate{
+		{CompanyID: companyID, Program: BPJSProgramJHT, EmployeeRateBps: 200, EmployerRateBps: 370},
+		{CompanyID: companyID, Program: BPJSProgramJP, EmployeeRateBps: 100, EmployerRateBps: 200, WageCap: money.New(10547400, "IDR")},
+		{CompanyID: companyID, Program: BPJSProgramJKK, EmployerRateBps: 24},
+		{CompanyID: companyID, Program: BPJSProgramJKM, EmployerRateBps: 30},
+		{CompanyID: companyID, Program: BPJSProgramKesehatan, EmployeeRateBps: 100, EmployerRateBps: 400, WageCap: money.New(12000000, "IDR")},
+	}
+}
+
+// Owed is what is paid to BPJS for the contribution, both shares together.
+func (c *BPJSContribution) Owed() (money.Money, error) {
+	return c.EmployeeAmount.Add(c.EmployerAmount)
+}
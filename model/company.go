@@ -2,25 +2,50 @@ package model
 
 import (
 	"context"
+	"errors"
+	"self-payrol/money"
 	"self-payrol/request"
 	"time"
 )
 
+var (
+	ErrInsufficientBalance    = errors.New("company balance is not sufficient")
+	ErrBalanceCurrencyChanged = errors.New("the balance currency cannot change once the company exists")
+	ErrPayrollCycleInUse      = errors.New("the payroll cycle cannot change while the current period has withdrawals")
+)
+
 type (
 	Company struct {
-		ID        int       `json:"id"`
-		Name      string    `json:"name"`
-		Address   string    `json:"address"`
-		Balance   int       `json:"balance"`
-		CreatedAt time.Time `json:"created_at"`
-		UpdatedAt time.Time `json:"updated_at"`
+		ID      int    `json:"id"`
+		Name    string `json:"name"`
+		Address string `json:"address"`
+		// Email receives top-up confirmations, it is optional.
+		Email string `json:"email"`
+		// Balance is in the currency the company pays out of.
+		Balance money.Money `json:"balance" gorm:"embedded;embeddedPrefix:balance_"`
+		// PayrollCycle decides how pay periods are cut, see PayrollPeriodOf.
+		PayrollCycle string `json:"payroll_cycle" gorm:"default:monthly"`
+		// ProrationMethod decides how pay is cut for employees who join or
+		// leave during a period, see PayrollPeriod.Proration.
+		ProrationMethod string `json:"proration_method" gorm:"default:working_days"`
+		// AnnualLeaveDays accrue over a year of service, see
+		// AnnualLeaveAccrued.
+		AnnualLeaveDays int `json:"annual_leave_days" gorm:"default:12"`
+		// TrackAttendance deducts the working days nobody clocked in on,
+		// without it only unpaid leave is deducted.
+		TrackAttendance *bool     `json:"track_attendance" gorm:"default:false"`
+		CreatedAt       time.Time `json:"created_at"`
+		UpdatedAt       time.Time `json:"updated_at"`
 	}
 
 	CompanyRepository interface {
 		Get(ctx context.Context) (*Company, error)
 		CreateOrUpdate(ctx context.Context, Company *Company) (*Company, error)
-		AddBalance(ctx context.Context, balance int) (*Company, error)
-		DebitBalance(ctx context.Context, amount int, note string) error
+		AddBalance(ctx context.Context, amount money.Money) (*Company, error)
+		DebitBalance(ctx context.Context, trx *Transaction) (*Transaction, error)
+		// CreditBalance adds money paid back to the company, such as a loan
+		// repaid early, and records trx.
+		CreditBalance(ctx context.Context, trx *Transaction) (*Transaction, error)
 	}
 
 	CompanyUsecase interface {
@@ -29,3 +54,8 @@ type (
 		TopupBalance(ctx context.Context, req request.TopupCompanyBalance) (*Company, int, error)
 	}
 )
+
+// TracksAttendance reports whether absences are deducted from pay.
+func (c *Company) TracksAttendance() bool {
+	return c.TrackAttendance != nil && *c.TrackAttendance
+}
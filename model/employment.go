@@ -0,0 +1,68 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"self-payrol/request"
+	"time"
+)
+
+const (
+	EmploymentActive     = "active"
+	EmploymentOnLeave    = "on_leave"
+	EmploymentSuspended  = "suspended"
+	EmploymentTerminated = "terminated"
+)
+
+var (
+	ErrEmployeeNotActive       = errors.New("employee is not active")
+	ErrInvalidStatusTransition = errors.New("employment status cannot change that way")
+	ErrTerminationBeforeStart  = errors.New("termination date is before the employment start date")
+)
+
+// employmentStatusTransitions lists where each status can move to.
+var employmentStatusTransitions = map[string][]string{
+	EmploymentActive:    {EmploymentOnLeave, EmploymentSuspended, EmploymentTerminated},
+	EmploymentOnLeave:   {EmploymentActive, EmploymentTerminated},
+	EmploymentSuspended: {EmploymentActive, EmploymentTerminated},
+}
+
+type (
+	// EmploymentEvent records a change of an employee's status and why.
+	EmploymentEvent struct {
+		ID     int    `json:"id"`
+		UserID int    `json:"user_id" gorm:"index"`
+		User   *User  `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+		From   string `json:"from"`
+		To     string `json:"to"`
+		Reason string `json:"reason"`
+		// EffectiveDate is the last day worked on termination, the day of
+		// the change otherwise.
+		EffectiveDate time.Time `json:"effective_date" gorm:"type:date"`
+		CreatedAt     time.Time `json:"created_at"`
+	}
+
+	EmploymentEventRepository interface {
+		Create(ctx context.Context, event *EmploymentEvent) (*EmploymentEvent, error)
+		// FetchByUserID returns the events newest first.
+		FetchByUserID(ctx context.Context, userID int) ([]*EmploymentEvent, error)
+	}
+
+	EmploymentUsecase interface {
+		// ChangeStatus moves the employee to status if the lifecycle allows
+		// it, terminating also ends the employment on the request date.
+		ChangeStatus(ctx context.Context, userID int, status string, req *request.EmploymentStatusRequest) (*User, error)
+		FetchStatusHistory(ctx context.Context, userID int) ([]*EmploymentEvent, error)
+	}
+)
+
+// CanChangeStatus reports whether an employee can go from one status to the
+// other. Termination is final.
+func CanChangeStatus(from, to string) bool {
+	for _, next := range employmentStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
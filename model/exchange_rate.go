@@ -0,0 +1,74 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"self-payrol/money"
+	"self-payrol/request"
+	"time"
+)
+
+var (
+	ErrNoExchangeRate           = errors.New("no exchange rate of the pay currency in effect on that date")
+	ErrExchangeRateSameCurrency = errors.New("exchange rate between a currency and itself")
+)
+
+type (
+	// ExchangeRate is what one unit of Currency is worth in QuoteCurrency
+	// from EffectiveFrom until the next rate of the pair takes over.
+	ExchangeRate struct {
+		ID            int    `json:"id"`
+		Currency      string `json:"currency" gorm:"size:3;uniqueIndex:idx_exchange_rates_pair_effective"`
+		QuoteCurrency string `json:"quote_currency" gorm:"size:3;uniqueIndex:idx_exchange_rates_pair_effective"`
+		// Rate is a decimal string such as "15825.5", kept exact.
+		Rate          string    `json:"rate" gorm:"type:numeric(24,10)"`
+		EffectiveFrom time.Time `json:"effective_from" gorm:"type:date;uniqueIndex:idx_exchange_rates_pair_effective"`
+		CreatedAt     time.Time `json:"created_at"`
+		UpdatedAt     time.Time `json:"updated_at"`
+	}
+
+	// Conversion records a payment to an employee paid in another currency
+	// than the company balance: Paid in the pay currency was Debited from
+	// the balance at Rate, the balance currency one unit of the pay
+	// currency is worth, as effective from RateDate.
+	Conversion struct {
+		Paid     money.Money `json:"paid"`
+		Debited  money.Money `json:"debited"`
+		Rate     string      `json:"rate"`
+		RateDate time.Time   `json:"rate_date"`
+	}
+
+	ExchangeRateRepository interface {
+		// Upsert records rate from its EffectiveFrom, replacing a rate of
+		// the pair already effective on that date.
+		Upsert(ctx context.Context, rate *ExchangeRate) (*ExchangeRate, error)
+		// Fetch returns the rates newest first, of currency only unless it
+		// is empty.
+		Fetch(ctx context.Context, currency string, limit, offset int) ([]*ExchangeRate, error)
+		// FindEffective returns the rate of the pair in effect on t.
+		FindEffective(ctx context.Context, currency, quoteCurrency string, t time.Time) (*ExchangeRate, error)
+	}
+
+	ExchangeRateUsecase interface {
+		FetchRates(ctx context.Context, currency string, limit, offset int) ([]*ExchangeRate, error)
+		StoreRates(ctx context.Context, req *request.ExchangeRateRequest) ([]*ExchangeRate, error)
+	}
+)
+
+// Convert converts amount, in either currency of the pair, into the other.
+func (r *ExchangeRate) Convert(amount money.Money) (money.Money, error) {
+	rate, ok := new(big.Rat).SetString(r.Rate)
+	if !ok || rate.Sign() <= 0 {
+		return money.Money{}, money.ErrInvalidAmount
+	}
+
+	switch amount.Currency {
+	case r.Currency:
+		return amount.Convert(r.QuoteCurrency, rate)
+	case r.QuoteCurrency:
+		return amount.Convert(r.Currency, rate.Inv(rate))
+	}
+
+	return money.Money{}, money.ErrCurrencyMismatch
+}
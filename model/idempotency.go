@@ -0,0 +1,44 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrIdempotencyKeyExists     = errors.New("idempotency key already exists")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key already used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("request with this idempotency key is still in progress")
+)
+
+type (
+	IdempotencyKey struct {
+		ID  int    `json:"id"`
+		Key string `json:"key" gorm:"uniqueIndex"`
+		// Fingerprint is a hash of method, path and body of the first request.
+		Fingerprint string `json:"fingerprint"`
+		// StatusCode stays zero until the first request has finished.
+		StatusCode int       `json:"status_code"`
+		Response   []byte    `json:"-"`
+		ExpiresAt  time.Time `json:"expires_at" gorm:"index"`
+		CreatedAt  time.Time `json:"created_at"`
+		UpdatedAt  time.Time `json:"updated_at"`
+	}
+
+	IdempotencyRepository interface {
+		Create(ctx context.Context, key *IdempotencyKey) (*IdempotencyKey, error)
+		FindByKey(ctx context.Context, key string) (*IdempotencyKey, error)
+		UpdateByKey(ctx context.Context, key string, idempotencyKey *IdempotencyKey) error
+		DeleteByKey(ctx context.Context, key string) error
+	}
+
+	IdempotencyUsecase interface {
+		// Begin claims key for a new request. It returns the stored record when
+		// the key was already completed with the same fingerprint, nil when the
+		// caller should go ahead and process the request.
+		Begin(ctx context.Context, key, fingerprint string) (*IdempotencyKey, error)
+		Complete(ctx context.Context, key string, statusCode int, response []byte) error
+		Release(ctx context.Context, key string) error
+	}
+)
@@ -0,0 +1,136 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"self-payrol/request"
+	"time"
+)
+
+const (
+	LeaveAnnual = "annual"
+	LeaveSick   = "sick"
+	LeaveUnpaid = "unpaid"
+
+	LeaveStatusPending  = "pending"
+	LeaveStatusApproved = "approved"
+	LeaveStatusRejected = "rejected"
+
+	PayCodeUnpaidLeave = "unpaid_leave"
+)
+
+var (
+	ErrLeaveNotPending    = errors.New("leave request has already been reviewed")
+	ErrLeaveOverlaps      = errors.New("leave overlaps another leave request")
+	ErrLeaveNoWorkingDays = errors.New("leave has no working days")
+	ErrInsufficientLeave  = errors.New("not enough annual leave left")
+)
+
+type (
+	// Leave is a request to be away from StartDate to EndDate, both
+	// inclusive. Annual leave is taken from the balance, sick leave is paid
+	// and unpaid leave is deducted from the pay of its days.
+	Leave struct {
+		ID        int       `json:"id"`
+		UserID    int       `json:"user_id" gorm:"index"`
+		User      *User     `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+		Type      string    `json:"type"`
+		StartDate time.Time `json:"start_date" gorm:"type:date"`
+		EndDate   time.Time `json:"end_date" gorm:"type:date"`
+		// Days counts the working days of the leave.
+		Days       int        `json:"days"`
+		Reason     string     `json:"reason"`
+		Status     string     `json:"status" gorm:"default:pending"`
+		ReviewedAt *time.Time `json:"reviewed_at"`
+		CreatedAt  time.Time  `json:"created_at"`
+		UpdatedAt  time.Time  `json:"updated_at"`
+	}
+
+	// LeaveBalance is the annual leave of a year. Leave counts against the
+	// year it starts in.
+	LeaveBalance struct {
+		Year      int `json:"year"`
+		Accrued   int `json:"accrued"`
+		Taken     int `json:"taken"`
+		Pending   int `json:"pending"`
+		Remaining int `json:"remaining"`
+	}
+
+	LeaveRepository interface {
+		Create(ctx context.Context, leave *Leave) (*Leave, error)
+		FindByID(ctx context.Context, userID, id int) (*Leave, error)
+		// Lock returns the leave and holds a row lock until the transaction
+		// ends.
+		Lock(ctx context.Context, userID, id int) (*Leave, error)
+		UpdateByID(ctx context.Context, id int, leave *Leave) (*Leave, error)
+		FetchByUserID(ctx context.Context, userID int) ([]*Leave, error)
+		// FetchBetween returns the leave with any of the statuses that
+		// overlaps the days from from up to to.
+		FetchBetween(ctx context.Context, userID int, from, to time.Time, statuses ...string) ([]*Leave, error)
+	}
+
+	LeaveUsecase interface {
+		GetByID(ctx context.Context, userID, id int) (*Leave, error)
+		FetchLeave(ctx context.Context, userID int) ([]*Leave, error)
+		RequestLeave(ctx context.Context, userID int, req *request.LeaveRequest) (*Leave, error)
+		ApproveLeave(ctx context.Context, userID, id int) (*Leave, error)
+		RejectLeave(ctx context.Context, userID, id int) (*Leave, error)
+		// GetBalance returns the annual leave of the current year.
+		GetBalance(ctx context.Context, userID int) (*LeaveBalance, error)
+	}
+)
+
+// Covers reports whether the day of t is one of the leave days.
+func (l *Leave) Covers(t time.Time) bool {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	start := time.Date(l.StartDate.Year(), l.StartDate.Month(), l.StartDate.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(l.EndDate.Year(), l.EndDate.Month(), l.EndDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	return !day.Before(start) && !day.After(end)
+}
+
+// DaysBetween counts the working days of the leave from from up to to.
+func (l *Leave) DaysBetween(from, to time.Time) int {
+	start := time.Date(l.StartDate.Year(), l.StartDate.Month(), l.StartDate.Day(), 0, 0, 0, 0, from.Location())
+	end := time.Date(l.EndDate.Year(), l.EndDate.Month(), l.EndDate.Day(), 0, 0, 0, 0, from.Location()).AddDate(0, 0, 1)
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+
+	return countWorkingDays(start, end)
+}
+
+// LeaveDays counts the working days from start to end, both inclusive.
+func LeaveDays(start, end time.Time) int {
+	return countWorkingDays(start, end.AddDate(0, 0, 1))
+}
+
+// AnnualLeaveAccrued is the annual leave earned in year by t. A twelfth of
+// daysPerYear accrues for every completed month of service in the year,
+// counted from the employment start when it falls in the year.
+func AnnualLeaveAccrued(daysPerYear int, start *time.Time, year int, t time.Time) int {
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, t.Location())
+	if start != nil {
+		if day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, t.Location()); day.After(from) {
+			from = day
+		}
+	}
+
+	to := t
+	if end := time.Date(year+1, time.January, 1, 0, 0, 0, 0, t.Location()); to.After(end) {
+		to = end
+	}
+
+	months := (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+	if to.Day() < from.Day() {
+		months--
+	}
+	if months <= 0 {
+		return 0
+	}
+
+	return daysPerYear * months / 12
+}
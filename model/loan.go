@@ -0,0 +1,173 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"self-payrol/money"
+	"self-payrol/request"
+	"time"
+)
+
+const (
+	LoanStatusActive = "active"
+	LoanStatusRepaid = "repaid"
+
+	LoanRepaymentInstallment = "installment"
+	LoanRepaymentPayoff      = "payoff"
+
+	PayCodeLoan            = "loan"
+	PayCodeLoanInstallment = "loan_installment"
+)
+
+var (
+	ErrLoanRepaid   = errors.New("loan has already been repaid")
+	ErrLoanTooSmall = errors.New("loan is too small to split in that many installments")
+)
+
+type (
+	// Loan is money advanced to an employee out of the company balance. It
+	// is paid back in Installments equal installments, one taken off the
+	// withdrawal that settles each payroll period from FirstPeriod on, the
+	// remainder of the split going a minor unit each to the first ones.
+	Loan struct {
+		ID           int         `json:"id"`
+		UserID       int         `json:"user_id" gorm:"index"`
+		User         *User       `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+		Principal    money.Money `json:"principal" gorm:"embedded;embeddedPrefix:principal_"`
+		Reason       string      `json:"reason"`
+		Installments int         `json:"installments"`
+		// Installment is the principal split in Installments rounded down.
+		Installment money.Money `json:"installment" gorm:"embedded;embeddedPrefix:installment_"`
+		// FirstPeriod is the period of the first installment, FirstDue its
+		// start so due loans can be found by date.
+		FirstPeriod string    `json:"first_period"`
+		FirstDue    time.Time `json:"first_due" gorm:"index"`
+		// Outstanding is the principal still to be repaid.
+		Outstanding   money.Money      `json:"outstanding" gorm:"embedded;embeddedPrefix:outstanding_"`
+		Status        string           `json:"status" gorm:"default:active"`
+		TransactionID *int             `json:"transaction_id"`
+		Transaction   *Transaction     `json:"-" gorm:"constraint:OnDelete:SET NULL;"`
+		RepaidAt      *time.Time       `json:"repaid_at"`
+		Repayments    []*LoanRepayment `json:"repayments,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+		// Schedule lists the installments still to come, it is worked out
+		// when a single loan is read.
+		Schedule  []LoanInstallment `json:"schedule,omitempty" gorm:"-"`
+		CreatedAt time.Time         `json:"created_at"`
+		UpdatedAt time.Time         `json:"updated_at"`
+	}
+
+	// LoanRepayment is an installment taken off a withdrawal, or the rest
+	// of the loan paid back early. TransactionID points at the withdrawal
+	// or the repayment transaction.
+	LoanRepayment struct {
+		ID            int          `json:"id"`
+		LoanID        int          `json:"loan_id" gorm:"index"`
+		Kind          string       `json:"kind"`
+		Period        string       `json:"period" gorm:"index"`
+		Amount        money.Money  `json:"amount" gorm:"embedded"`
+		TransactionID int          `json:"transaction_id"`
+		Transaction   *Transaction `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+		CreatedAt     time.Time    `json:"created_at"`
+	}
+
+	LoanInstallment struct {
+		Period string      `json:"period"`
+		Amount money.Money `json:"amount"`
+	}
+
+	LoanRepository interface {
+		Create(ctx context.Context, loan *Loan) (*Loan, error)
+		// FindByID returns the loan with its repayments.
+		FindByID(ctx context.Context, userID, id int) (*Loan, error)
+		// Lock returns the loan and holds a row lock until the transaction
+		// ends.
+		Lock(ctx context.Context, id int) (*Loan, error)
+		UpdateByID(ctx context.Context, id int, loan *Loan) (*Loan, error)
+		FetchByUserID(ctx context.Context, userID int) ([]*Loan, error)
+		// FetchDue returns the active loans of the user with an installment
+		// due in period, those already repaid in it are left out.
+		FetchDue(ctx context.Context, userID int, period PayrollPeriod) ([]*Loan, error)
+		// FetchInstallmentsIn returns the installments of the user's loans
+		// taken off the salary of period.
+		FetchInstallmentsIn(ctx context.Context, userID int, period string) ([]*LoanRepayment, error)
+		CreateRepayment(ctx context.Context, repayment *LoanRepayment) (*LoanRepayment, error)
+	}
+
+	LoanUsecase interface {
+		GetByID(ctx context.Context, userID, id int) (*Loan, error)
+		FetchLoan(ctx context.Context, userID int) ([]*Loan, error)
+		// GrantLoan pays the loan out of the company balance.
+		GrantLoan(ctx context.Context, userID int, req *request.LoanRequest) (*Loan, error)
+		// PayOffLoan takes the outstanding principal back into the company
+		// balance and closes the loan.
+		PayOffLoan(ctx context.Context, userID, id int) (*Loan, error)
+	}
+)
+
+// InstallmentDue is what the next installment takes, see dueAfter.
+func (l *Loan) InstallmentDue() money.Money {
+	return l.dueAfter(l.Outstanding)
+}
+
+// dueAfter is what the next installment takes when outstanding is left. An
+// installment cut short by a small pay is finished by the next one, and the
+// last one takes whatever is left.
+func (l *Loan) dueAfter(outstanding money.Money) money.Money {
+	count := int64(l.Installments)
+	repaid := l.Principal.Amount - outstanding.Amount
+	for k := int64(1); k < count; k++ {
+		// the first k installments of the split
+		planned := k*(l.Principal.Amount/count) + min64(k, l.Principal.Amount%count)
+		if planned > repaid {
+			return money.New(planned-repaid, outstanding.Currency)
+		}
+	}
+	return outstanding
+}
+
+// Repay takes amount off the outstanding principal and closes the loan
+// once it is repaid.
+func (l *Loan) Repay(amount money.Money, at time.Time) error {
+	outstanding, err := l.Outstanding.Sub(amount)
+	if err != nil {
+		return err
+	}
+
+	l.Outstanding = outstanding
+	if l.Outstanding.Amount <= 0 {
+		l.Outstanding.Amount = 0
+		l.Status = LoanStatusRepaid
+		l.RepaidAt = &at
+	}
+	return nil
+}
+
+// ScheduleFrom lays out the installments still to come, one a period of
+// cycle from period on, or from the first period when that is later.
+func (l *Loan) ScheduleFrom(cycle string, period PayrollPeriod) ([]LoanInstallment, error) {
+	if period.Start.Before(l.FirstDue) {
+		period = PayrollPeriodOf(cycle, l.FirstDue)
+	}
+
+	var schedule []LoanInstallment
+	for outstanding := l.Outstanding; outstanding.Amount > 0; {
+		amount := l.dueAfter(outstanding)
+
+		var err error
+		if outstanding, err = outstanding.Sub(amount); err != nil {
+			return nil, err
+		}
+
+		schedule = append(schedule, LoanInstallment{Period: period.Code, Amount: amount})
+		period = PayrollPeriodOf(cycle, period.End)
+	}
+
+	return schedule, nil
+}
+
+func min64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,135 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AttendanceRepository is an autogenerated mock type for the AttendanceRepository type
+type AttendanceRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, attendance
+func (_m *AttendanceRepository) Create(ctx context.Context, attendance *model.Attendance) (*model.Attendance, error) {
+	ret := _m.Called(ctx, attendance)
+
+	var r0 *model.Attendance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Attendance) (*model.Attendance, error)); ok {
+		return rf(ctx, attendance)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Attendance) *model.Attendance); ok {
+		r0 = rf(ctx, attendance)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Attendance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Attendance) error); ok {
+		r1 = rf(ctx, attendance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchByUserID provides a mock function with given fields: ctx, userID, from, to
+func (_m *AttendanceRepository) FetchByUserID(ctx context.Context, userID int, from time.Time, to time.Time) ([]*model.Attendance, error) {
+	ret := _m.Called(ctx, userID, from, to)
+
+	var r0 []*model.Attendance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Time) ([]*model.Attendance, error)); ok {
+		return rf(ctx, userID, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Time) []*model.Attendance); ok {
+		r0 = rf(ctx, userID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Attendance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, userID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByDate provides a mock function with given fields: ctx, userID, date
+func (_m *AttendanceRepository) FindByDate(ctx context.Context, userID int, date time.Time) (*model.Attendance, error) {
+	ret := _m.Called(ctx, userID, date)
+
+	var r0 *model.Attendance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) (*model.Attendance, error)); ok {
+		return rf(ctx, userID, date)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) *model.Attendance); ok {
+		r0 = rf(ctx, userID, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Attendance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = rf(ctx, userID, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateByID provides a mock function with given fields: ctx, id, attendance
+func (_m *AttendanceRepository) UpdateByID(ctx context.Context, id int, attendance *model.Attendance) (*model.Attendance, error) {
+	ret := _m.Called(ctx, id, attendance)
+
+	var r0 *model.Attendance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.Attendance) (*model.Attendance, error)); ok {
+		return rf(ctx, id, attendance)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.Attendance) *model.Attendance); ok {
+		r0 = rf(ctx, id, attendance)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Attendance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *model.Attendance) error); ok {
+		r1 = rf(ctx, id, attendance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewAttendanceRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAttendanceRepository creates a new instance of AttendanceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAttendanceRepository(t mockConstructorTestingTNewAttendanceRepository) *AttendanceRepository {
+	mock := &AttendanceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,109 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	request "self-payrol/request"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AttendanceUsecase is an autogenerated mock type for the AttendanceUsecase type
+type AttendanceUsecase struct {
+	mock.Mock
+}
+
+// ClockIn provides a mock function with given fields: ctx, userID, req
+func (_m *AttendanceUsecase) ClockIn(ctx context.Context, userID int, req *request.ClockRequest) (*model.Attendance, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 *model.Attendance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.ClockRequest) (*model.Attendance, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.ClockRequest) *model.Attendance); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Attendance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *request.ClockRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClockOut provides a mock function with given fields: ctx, userID, req
+func (_m *AttendanceUsecase) ClockOut(ctx context.Context, userID int, req *request.ClockRequest) (*model.Attendance, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 *model.Attendance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.ClockRequest) (*model.Attendance, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.ClockRequest) *model.Attendance); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Attendance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *request.ClockRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchAttendance provides a mock function with given fields: ctx, userID, period
+func (_m *AttendanceUsecase) FetchAttendance(ctx context.Context, userID int, period string) ([]*model.Attendance, error) {
+	ret := _m.Called(ctx, userID, period)
+
+	var r0 []*model.Attendance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) ([]*model.Attendance, error)); ok {
+		return rf(ctx, userID, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) []*model.Attendance); ok {
+		r0 = rf(ctx, userID, period)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Attendance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, userID, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewAttendanceUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAttendanceUsecase creates a new instance of AttendanceUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAttendanceUsecase(t mockConstructorTestingTNewAttendanceUsecase) *AttendanceUsecase {
+	mock := &AttendanceUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,110 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BPJSContributionRepository is an autogenerated mock type for the BPJSContributionRepository type
+type BPJSContributionRepository struct {
+	mock.Mock
+}
+
+// CreateMany provides a mock function with given fields: ctx, contributions
+func (_m *BPJSContributionRepository) CreateMany(ctx context.Context, contributions []*model.BPJSContribution) error {
+	ret := _m.Called(ctx, contributions)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.BPJSContribution) error); ok {
+		r0 = rf(ctx, contributions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchByMonth provides a mock function with given fields: ctx, month
+func (_m *BPJSContributionRepository) FetchByMonth(ctx context.Context, month string) ([]*model.BPJSContribution, error) {
+	ret := _m.Called(ctx, month)
+
+	var r0 []*model.BPJSContribution
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*model.BPJSContribution, error)); ok {
+		return rf(ctx, month)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.BPJSContribution); ok {
+		r0 = rf(ctx, month)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.BPJSContribution)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, month)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LockUnpaidByMonth provides a mock function with given fields: ctx, month
+func (_m *BPJSContributionRepository) LockUnpaidByMonth(ctx context.Context, month string) ([]*model.BPJSContribution, error) {
+	ret := _m.Called(ctx, month)
+
+	var r0 []*model.BPJSContribution
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*model.BPJSContribution, error)); ok {
+		return rf(ctx, month)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.BPJSContribution); ok {
+		r0 = rf(ctx, month)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.BPJSContribution)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, month)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkPaid provides a mock function with given fields: ctx, ids, transactionID
+func (_m *BPJSContributionRepository) MarkPaid(ctx context.Context, ids []int, transactionID int) error {
+	ret := _m.Called(ctx, ids, transactionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int, int) error); ok {
+		r0 = rf(ctx, ids, transactionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewBPJSContributionRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewBPJSContributionRepository creates a new instance of BPJSContributionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewBPJSContributionRepository(t mockConstructorTestingTNewBPJSContributionRepository) *BPJSContributionRepository {
+	mock := &BPJSContributionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
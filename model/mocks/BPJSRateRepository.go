@@ -0,0 +1,82 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BPJSRateRepository is an autogenerated mock type for the BPJSRateRepository type
+type BPJSRateRepository struct {
+	mock.Mock
+}
+
+// FetchByCompanyID provides a mock function with given fields: ctx, companyID
+func (_m *BPJSRateRepository) FetchByCompanyID(ctx context.Context, companyID int) ([]*model.BPJSRate, error) {
+	ret := _m.Called(ctx, companyID)
+
+	var r0 []*model.BPJSRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.BPJSRate, error)); ok {
+		return rf(ctx, companyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.BPJSRate); ok {
+		r0 = rf(ctx, companyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.BPJSRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, companyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Upsert provides a mock function with given fields: ctx, rates
+func (_m *BPJSRateRepository) Upsert(ctx context.Context, rates []*model.BPJSRate) ([]*model.BPJSRate, error) {
+	ret := _m.Called(ctx, rates)
+
+	var r0 []*model.BPJSRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.BPJSRate) ([]*model.BPJSRate, error)); ok {
+		return rf(ctx, rates)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.BPJSRate) []*model.BPJSRate); ok {
+		r0 = rf(ctx, rates)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.BPJSRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*model.BPJSRate) error); ok {
+		r1 = rf(ctx, rates)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewBPJSRateRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewBPJSRateRepository creates a new instance of BPJSRateRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewBPJSRateRepository(t mockConstructorTestingTNewBPJSRateRepository) *BPJSRateRepository {
+	mock := &BPJSRateRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
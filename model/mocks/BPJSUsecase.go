@@ -0,0 +1,135 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	request "self-payrol/request"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BPJSUsecase is an autogenerated mock type for the BPJSUsecase type
+type BPJSUsecase struct {
+	mock.Mock
+}
+
+// FetchRates provides a mock function with given fields: ctx
+func (_m *BPJSUsecase) FetchRates(ctx context.Context) ([]*model.BPJSRate, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.BPJSRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*model.BPJSRate, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.BPJSRate); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.BPJSRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PayContributions provides a mock function with given fields: ctx, month
+func (_m *BPJSUsecase) PayContributions(ctx context.Context, month string) (*model.Transaction, error) {
+	ret := _m.Called(ctx, month)
+
+	var r0 *model.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.Transaction, error)); ok {
+		return rf(ctx, month)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Transaction); ok {
+		r0 = rf(ctx, month)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, month)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Report provides a mock function with given fields: ctx, month
+func (_m *BPJSUsecase) Report(ctx context.Context, month string) (*model.BPJSReport, error) {
+	ret := _m.Called(ctx, month)
+
+	var r0 *model.BPJSReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.BPJSReport, error)); ok {
+		return rf(ctx, month)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.BPJSReport); ok {
+		r0 = rf(ctx, month)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BPJSReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, month)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRates provides a mock function with given fields: ctx, req
+func (_m *BPJSUsecase) UpdateRates(ctx context.Context, req *request.BPJSRateRequest) ([]*model.BPJSRate, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 []*model.BPJSRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *request.BPJSRateRequest) ([]*model.BPJSRate, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *request.BPJSRateRequest) []*model.BPJSRate); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.BPJSRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *request.BPJSRateRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewBPJSUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewBPJSUsecase creates a new instance of BPJSUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewBPJSUsecase(t mockConstructorTestingTNewBPJSUsecase) *BPJSUsecase {
+	mock := &BPJSUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
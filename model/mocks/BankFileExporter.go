@@ -0,0 +1,55 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "self-payrol/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BankFileExporter is an autogenerated mock type for the BankFileExporter type
+type BankFileExporter struct {
+	mock.Mock
+}
+
+// Export provides a mock function with given fields: batch, format
+func (_m *BankFileExporter) Export(batch *model.BankTransferBatch, format string) (*model.BankFile, error) {
+	ret := _m.Called(batch, format)
+
+	var r0 *model.BankFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.BankTransferBatch, string) (*model.BankFile, error)); ok {
+		return rf(batch, format)
+	}
+	if rf, ok := ret.Get(0).(func(*model.BankTransferBatch, string) *model.BankFile); ok {
+		r0 = rf(batch, format)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BankFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.BankTransferBatch, string) error); ok {
+		r1 = rf(batch, format)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewBankFileExporter interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewBankFileExporter creates a new instance of BankFileExporter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewBankFileExporter(t mockConstructorTestingTNewBankFileExporter) *BankFileExporter {
+	mock := &BankFileExporter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
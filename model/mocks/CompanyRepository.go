@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 	model "self-payrol/model"
+	money "self-payrol/money"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -14,25 +15,25 @@ type CompanyRepository struct {
 	mock.Mock
 }
 
-// AddBalance provides a mock function with given fields: ctx, balance
-func (_m *CompanyRepository) AddBalance(ctx context.Context, balance int) (*model.Company, error) {
-	ret := _m.Called(ctx, balance)
+// AddBalance provides a mock function with given fields: ctx, amount
+func (_m *CompanyRepository) AddBalance(ctx context.Context, amount money.Money) (*model.Company, error) {
+	ret := _m.Called(ctx, amount)
 
 	var r0 *model.Company
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) (*model.Company, error)); ok {
-		return rf(ctx, balance)
+	if rf, ok := ret.Get(0).(func(context.Context, money.Money) (*model.Company, error)); ok {
+		return rf(ctx, amount)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) *model.Company); ok {
-		r0 = rf(ctx, balance)
+	if rf, ok := ret.Get(0).(func(context.Context, money.Money) *model.Company); ok {
+		r0 = rf(ctx, amount)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Company)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, balance)
+	if rf, ok := ret.Get(1).(func(context.Context, money.Money) error); ok {
+		r1 = rf(ctx, amount)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -66,18 +67,56 @@ func (_m *CompanyRepository) CreateOrUpdate(ctx context.Context, Company *model.
 	return r0, r1
 }
 
-// DebitBalance provides a mock function with given fields: ctx, amount, note
-func (_m *CompanyRepository) DebitBalance(ctx context.Context, amount int, note string) error {
-	ret := _m.Called(ctx, amount, note)
+// CreditBalance provides a mock function with given fields: ctx, trx
+func (_m *CompanyRepository) CreditBalance(ctx context.Context, trx *model.Transaction) (*model.Transaction, error) {
+	ret := _m.Called(ctx, trx)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, int, string) error); ok {
-		r0 = rf(ctx, amount, note)
+	var r0 *model.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Transaction) (*model.Transaction, error)); ok {
+		return rf(ctx, trx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Transaction) *model.Transaction); ok {
+		r0 = rf(ctx, trx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Transaction) error); ok {
+		r1 = rf(ctx, trx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DebitBalance provides a mock function with given fields: ctx, trx
+func (_m *CompanyRepository) DebitBalance(ctx context.Context, trx *model.Transaction) (*model.Transaction, error) {
+	ret := _m.Called(ctx, trx)
+
+	var r0 *model.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Transaction) (*model.Transaction, error)); ok {
+		return rf(ctx, trx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Transaction) *model.Transaction); ok {
+		r0 = rf(ctx, trx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Transaction) error); ok {
+		r1 = rf(ctx, trx)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
 // Get provides a mock function with given fields: ctx
@@ -0,0 +1,82 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EmploymentEventRepository is an autogenerated mock type for the EmploymentEventRepository type
+type EmploymentEventRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, event
+func (_m *EmploymentEventRepository) Create(ctx context.Context, event *model.EmploymentEvent) (*model.EmploymentEvent, error) {
+	ret := _m.Called(ctx, event)
+
+	var r0 *model.EmploymentEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.EmploymentEvent) (*model.EmploymentEvent, error)); ok {
+		return rf(ctx, event)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.EmploymentEvent) *model.EmploymentEvent); ok {
+		r0 = rf(ctx, event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EmploymentEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.EmploymentEvent) error); ok {
+		r1 = rf(ctx, event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchByUserID provides a mock function with given fields: ctx, userID
+func (_m *EmploymentEventRepository) FetchByUserID(ctx context.Context, userID int) ([]*model.EmploymentEvent, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*model.EmploymentEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.EmploymentEvent, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.EmploymentEvent); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.EmploymentEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewEmploymentEventRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewEmploymentEventRepository creates a new instance of EmploymentEventRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewEmploymentEventRepository(t mockConstructorTestingTNewEmploymentEventRepository) *EmploymentEventRepository {
+	mock := &EmploymentEventRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,83 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	request "self-payrol/request"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EmploymentUsecase is an autogenerated mock type for the EmploymentUsecase type
+type EmploymentUsecase struct {
+	mock.Mock
+}
+
+// ChangeStatus provides a mock function with given fields: ctx, userID, status, req
+func (_m *EmploymentUsecase) ChangeStatus(ctx context.Context, userID int, status string, req *request.EmploymentStatusRequest) (*model.User, error) {
+	ret := _m.Called(ctx, userID, status, req)
+
+	var r0 *model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, *request.EmploymentStatusRequest) (*model.User, error)); ok {
+		return rf(ctx, userID, status, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, *request.EmploymentStatusRequest) *model.User); ok {
+		r0 = rf(ctx, userID, status, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, *request.EmploymentStatusRequest) error); ok {
+		r1 = rf(ctx, userID, status, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchStatusHistory provides a mock function with given fields: ctx, userID
+func (_m *EmploymentUsecase) FetchStatusHistory(ctx context.Context, userID int) ([]*model.EmploymentEvent, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*model.EmploymentEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.EmploymentEvent, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.EmploymentEvent); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.EmploymentEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewEmploymentUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewEmploymentUsecase creates a new instance of EmploymentUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewEmploymentUsecase(t mockConstructorTestingTNewEmploymentUsecase) *EmploymentUsecase {
+	mock := &EmploymentUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,109 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExchangeRateRepository is an autogenerated mock type for the ExchangeRateRepository type
+type ExchangeRateRepository struct {
+	mock.Mock
+}
+
+// Fetch provides a mock function with given fields: ctx, currency, limit, offset
+func (_m *ExchangeRateRepository) Fetch(ctx context.Context, currency string, limit int, offset int) ([]*model.ExchangeRate, error) {
+	ret := _m.Called(ctx, currency, limit, offset)
+
+	var r0 []*model.ExchangeRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*model.ExchangeRate, error)); ok {
+		return rf(ctx, currency, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []*model.ExchangeRate); ok {
+		r0 = rf(ctx, currency, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ExchangeRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, currency, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindEffective provides a mock function with given fields: ctx, currency, quoteCurrency, t
+func (_m *ExchangeRateRepository) FindEffective(ctx context.Context, currency string, quoteCurrency string, t time.Time) (*model.ExchangeRate, error) {
+	ret := _m.Called(ctx, currency, quoteCurrency, t)
+
+	var r0 *model.ExchangeRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) (*model.ExchangeRate, error)); ok {
+		return rf(ctx, currency, quoteCurrency, t)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) *model.ExchangeRate); ok {
+		r0 = rf(ctx, currency, quoteCurrency, t)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ExchangeRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Time) error); ok {
+		r1 = rf(ctx, currency, quoteCurrency, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Upsert provides a mock function with given fields: ctx, rate
+func (_m *ExchangeRateRepository) Upsert(ctx context.Context, rate *model.ExchangeRate) (*model.ExchangeRate, error) {
+	ret := _m.Called(ctx, rate)
+
+	var r0 *model.ExchangeRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ExchangeRate) (*model.ExchangeRate, error)); ok {
+		return rf(ctx, rate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.ExchangeRate) *model.ExchangeRate); ok {
+		r0 = rf(ctx, rate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ExchangeRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.ExchangeRate) error); ok {
+		r1 = rf(ctx, rate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewExchangeRateRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewExchangeRateRepository creates a new instance of ExchangeRateRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewExchangeRateRepository(t mockConstructorTestingTNewExchangeRateRepository) *ExchangeRateRepository {
+	mock := &ExchangeRateRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
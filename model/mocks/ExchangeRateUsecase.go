@@ -0,0 +1,83 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	request "self-payrol/request"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ExchangeRateUsecase is an autogenerated mock type for the ExchangeRateUsecase type
+type ExchangeRateUsecase struct {
+	mock.Mock
+}
+
+// FetchRates provides a mock function with given fields: ctx, currency, limit, offset
+func (_m *ExchangeRateUsecase) FetchRates(ctx context.Context, currency string, limit int, offset int) ([]*model.ExchangeRate, error) {
+	ret := _m.Called(ctx, currency, limit, offset)
+
+	var r0 []*model.ExchangeRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]*model.ExchangeRate, error)); ok {
+		return rf(ctx, currency, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []*model.ExchangeRate); ok {
+		r0 = rf(ctx, currency, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ExchangeRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, currency, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StoreRates provides a mock function with given fields: ctx, req
+func (_m *ExchangeRateUsecase) StoreRates(ctx context.Context, req *request.ExchangeRateRequest) ([]*model.ExchangeRate, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 []*model.ExchangeRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *request.ExchangeRateRequest) ([]*model.ExchangeRate, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *request.ExchangeRateRequest) []*model.ExchangeRate); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ExchangeRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *request.ExchangeRateRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewExchangeRateUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewExchangeRateUsecase creates a new instance of ExchangeRateUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewExchangeRateUsecase(t mockConstructorTestingTNewExchangeRateUsecase) *ExchangeRateUsecase {
+	mock := &ExchangeRateUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
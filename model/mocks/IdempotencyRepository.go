@@ -0,0 +1,110 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdempotencyRepository is an autogenerated mock type for the IdempotencyRepository type
+type IdempotencyRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, key
+func (_m *IdempotencyRepository) Create(ctx context.Context, key *model.IdempotencyKey) (*model.IdempotencyKey, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *model.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.IdempotencyKey) (*model.IdempotencyKey, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.IdempotencyKey) *model.IdempotencyKey); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.IdempotencyKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.IdempotencyKey) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteByKey provides a mock function with given fields: ctx, key
+func (_m *IdempotencyRepository) DeleteByKey(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByKey provides a mock function with given fields: ctx, key
+func (_m *IdempotencyRepository) FindByKey(ctx context.Context, key string) (*model.IdempotencyKey, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *model.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.IdempotencyKey, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.IdempotencyKey); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.IdempotencyKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateByKey provides a mock function with given fields: ctx, key, idempotencyKey
+func (_m *IdempotencyRepository) UpdateByKey(ctx context.Context, key string, idempotencyKey *model.IdempotencyKey) error {
+	ret := _m.Called(ctx, key, idempotencyKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.IdempotencyKey) error); ok {
+		r0 = rf(ctx, key, idempotencyKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewIdempotencyRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIdempotencyRepository creates a new instance of IdempotencyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIdempotencyRepository(t mockConstructorTestingTNewIdempotencyRepository) *IdempotencyRepository {
+	mock := &IdempotencyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IdempotencyUsecase is an autogenerated mock type for the IdempotencyUsecase type
+type IdempotencyUsecase struct {
+	mock.Mock
+}
+
+// Begin provides a mock function with given fields: ctx, key, fingerprint
+func (_m *IdempotencyUsecase) Begin(ctx context.Context, key string, fingerprint string) (*model.IdempotencyKey, error) {
+	ret := _m.Called(ctx, key, fingerprint)
+
+	var r0 *model.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*model.IdempotencyKey, error)); ok {
+		return rf(ctx, key, fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *model.IdempotencyKey); ok {
+		r0 = rf(ctx, key, fingerprint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.IdempotencyKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, key, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Complete provides a mock function with given fields: ctx, key, statusCode, response
+func (_m *IdempotencyUsecase) Complete(ctx context.Context, key string, statusCode int, response []byte) error {
+	ret := _m.Called(ctx, key, statusCode, response)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, []byte) error); ok {
+		r0 = rf(ctx, key, statusCode, response)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Release provides a mock function with given fields: ctx, key
+func (_m *IdempotencyUsecase) Release(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewIdempotencyUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIdempotencyUsecase creates a new instance of IdempotencyUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIdempotencyUsecase(t mockConstructorTestingTNewIdempotencyUsecase) *IdempotencyUsecase {
+	mock := &IdempotencyUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,194 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LeaveRepository is an autogenerated mock type for the LeaveRepository type
+type LeaveRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, leave
+func (_m *LeaveRepository) Create(ctx context.Context, leave *model.Leave) (*model.Leave, error) {
+	ret := _m.Called(ctx, leave)
+
+	var r0 *model.Leave
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Leave) (*model.Leave, error)); ok {
+		return rf(ctx, leave)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Leave) *model.Leave); ok {
+		r0 = rf(ctx, leave)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Leave)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Leave) error); ok {
+		r1 = rf(ctx, leave)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchBetween provides a mock function with given fields: ctx, userID, from, to, statuses
+func (_m *LeaveRepository) FetchBetween(ctx context.Context, userID int, from time.Time, to time.Time, statuses ...string) ([]*model.Leave, error) {
+	_va := make([]interface{}, len(statuses))
+	for _i := range statuses {
+		_va[_i] = statuses[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, userID, from, to)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []*model.Leave
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Time, ...string) ([]*model.Leave, error)); ok {
+		return rf(ctx, userID, from, to, statuses...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time, time.Time, ...string) []*model.Leave); ok {
+		r0 = rf(ctx, userID, from, to, statuses...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Leave)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time, time.Time, ...string) error); ok {
+		r1 = rf(ctx, userID, from, to, statuses...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchByUserID provides a mock function with given fields: ctx, userID
+func (_m *LeaveRepository) FetchByUserID(ctx context.Context, userID int) ([]*model.Leave, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*model.Leave
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.Leave, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.Leave); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Leave)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, userID, id
+func (_m *LeaveRepository) FindByID(ctx context.Context, userID int, id int) (*model.Leave, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Leave
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Leave, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Leave); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Leave)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Lock provides a mock function with given fields: ctx, userID, id
+func (_m *LeaveRepository) Lock(ctx context.Context, userID int, id int) (*model.Leave, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Leave
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Leave, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Leave); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Leave)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateByID provides a mock function with given fields: ctx, id, leave
+func (_m *LeaveRepository) UpdateByID(ctx context.Context, id int, leave *model.Leave) (*model.Leave, error) {
+	ret := _m.Called(ctx, id, leave)
+
+	var r0 *model.Leave
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.Leave) (*model.Leave, error)); ok {
+		return rf(ctx, id, leave)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.Leave) *model.Leave); ok {
+		r0 = rf(ctx, id, leave)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Leave)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *model.Leave) error); ok {
+		r1 = rf(ctx, id, leave)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewLeaveRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLeaveRepository creates a new instance of LeaveRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLeaveRepository(t mockConstructorTestingTNewLeaveRepository) *LeaveRepository {
+	mock := &LeaveRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,187 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	request "self-payrol/request"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LeaveUsecase is an autogenerated mock type for the LeaveUsecase type
+type LeaveUsecase struct {
+	mock.Mock
+}
+
+// ApproveLeave provides a mock function with given fields: ctx, userID, id
+func (_m *LeaveUsecase) ApproveLeave(ctx context.Context, userID int, id int) (*model.Leave, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Leave
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Leave, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Leave); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Leave)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchLeave provides a mock function with given fields: ctx, userID
+func (_m *LeaveUsecase) FetchLeave(ctx context.Context, userID int) ([]*model.Leave, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*model.Leave
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.Leave, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.Leave); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Leave)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBalance provides a mock function with given fields: ctx, userID
+func (_m *LeaveUsecase) GetBalance(ctx context.Context, userID int) (*model.LeaveBalance, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 *model.LeaveBalance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*model.LeaveBalance, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.LeaveBalance); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LeaveBalance)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, userID, id
+func (_m *LeaveUsecase) GetByID(ctx context.Context, userID int, id int) (*model.Leave, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Leave
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Leave, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Leave); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Leave)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RejectLeave provides a mock function with given fields: ctx, userID, id
+func (_m *LeaveUsecase) RejectLeave(ctx context.Context, userID int, id int) (*model.Leave, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Leave
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Leave, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Leave); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Leave)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestLeave provides a mock function with given fields: ctx, userID, req
+func (_m *LeaveUsecase) RequestLeave(ctx context.Context, userID int, req *request.LeaveRequest) (*model.Leave, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 *model.Leave
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.LeaveRequest) (*model.Leave, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.LeaveRequest) *model.Leave); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Leave)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *request.LeaveRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewLeaveUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLeaveUsecase creates a new instance of LeaveUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLeaveUsecase(t mockConstructorTestingTNewLeaveUsecase) *LeaveUsecase {
+	mock := &LeaveUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
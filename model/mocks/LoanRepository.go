@@ -0,0 +1,238 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LoanRepository is an autogenerated mock type for the LoanRepository type
+type LoanRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, loan
+func (_m *LoanRepository) Create(ctx context.Context, loan *model.Loan) (*model.Loan, error) {
+	ret := _m.Called(ctx, loan)
+
+	var r0 *model.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Loan) (*model.Loan, error)); ok {
+		return rf(ctx, loan)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Loan) *model.Loan); ok {
+		r0 = rf(ctx, loan)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Loan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Loan) error); ok {
+		r1 = rf(ctx, loan)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateRepayment provides a mock function with given fields: ctx, repayment
+func (_m *LoanRepository) CreateRepayment(ctx context.Context, repayment *model.LoanRepayment) (*model.LoanRepayment, error) {
+	ret := _m.Called(ctx, repayment)
+
+	var r0 *model.LoanRepayment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.LoanRepayment) (*model.LoanRepayment, error)); ok {
+		return rf(ctx, repayment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.LoanRepayment) *model.LoanRepayment); ok {
+		r0 = rf(ctx, repayment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.LoanRepayment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.LoanRepayment) error); ok {
+		r1 = rf(ctx, repayment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchByUserID provides a mock function with given fields: ctx, userID
+func (_m *LoanRepository) FetchByUserID(ctx context.Context, userID int) ([]*model.Loan, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*model.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.Loan, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.Loan); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Loan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchDue provides a mock function with given fields: ctx, userID, period
+func (_m *LoanRepository) FetchDue(ctx context.Context, userID int, period model.PayrollPeriod) ([]*model.Loan, error) {
+	ret := _m.Called(ctx, userID, period)
+
+	var r0 []*model.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.PayrollPeriod) ([]*model.Loan, error)); ok {
+		return rf(ctx, userID, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, model.PayrollPeriod) []*model.Loan); ok {
+		r0 = rf(ctx, userID, period)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Loan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, model.PayrollPeriod) error); ok {
+		r1 = rf(ctx, userID, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchInstallmentsIn provides a mock function with given fields: ctx, userID, period
+func (_m *LoanRepository) FetchInstallmentsIn(ctx context.Context, userID int, period string) ([]*model.LoanRepayment, error) {
+	ret := _m.Called(ctx, userID, period)
+
+	var r0 []*model.LoanRepayment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) ([]*model.LoanRepayment, error)); ok {
+		return rf(ctx, userID, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) []*model.LoanRepayment); ok {
+		r0 = rf(ctx, userID, period)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.LoanRepayment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, userID, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, userID, id
+func (_m *LoanRepository) FindByID(ctx context.Context, userID int, id int) (*model.Loan, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Loan, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Loan); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Loan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Lock provides a mock function with given fields: ctx, id
+func (_m *LoanRepository) Lock(ctx context.Context, id int) (*model.Loan, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*model.Loan, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.Loan); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Loan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateByID provides a mock function with given fields: ctx, id, loan
+func (_m *LoanRepository) UpdateByID(ctx context.Context, id int, loan *model.Loan) (*model.Loan, error) {
+	ret := _m.Called(ctx, id, loan)
+
+	var r0 *model.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.Loan) (*model.Loan, error)); ok {
+		return rf(ctx, id, loan)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.Loan) *model.Loan); ok {
+		r0 = rf(ctx, id, loan)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Loan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *model.Loan) error); ok {
+		r1 = rf(ctx, id, loan)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewLoanRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLoanRepository creates a new instance of LoanRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLoanRepository(t mockConstructorTestingTNewLoanRepository) *LoanRepository {
+	mock := &LoanRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,135 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	request "self-payrol/request"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LoanUsecase is an autogenerated mock type for the LoanUsecase type
+type LoanUsecase struct {
+	mock.Mock
+}
+
+// FetchLoan provides a mock function with given fields: ctx, userID
+func (_m *LoanUsecase) FetchLoan(ctx context.Context, userID int) ([]*model.Loan, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*model.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.Loan, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.Loan); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Loan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, userID, id
+func (_m *LoanUsecase) GetByID(ctx context.Context, userID int, id int) (*model.Loan, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Loan, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Loan); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Loan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantLoan provides a mock function with given fields: ctx, userID, req
+func (_m *LoanUsecase) GrantLoan(ctx context.Context, userID int, req *request.LoanRequest) (*model.Loan, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 *model.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.LoanRequest) (*model.Loan, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.LoanRequest) *model.Loan); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Loan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *request.LoanRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PayOffLoan provides a mock function with given fields: ctx, userID, id
+func (_m *LoanUsecase) PayOffLoan(ctx context.Context, userID int, id int) (*model.Loan, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Loan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Loan, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Loan); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Loan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewLoanUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLoanUsecase creates a new instance of LoanUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLoanUsecase(t mockConstructorTestingTNewLoanUsecase) *LoanUsecase {
+	mock := &LoanUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,41 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	money "self-payrol/money"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Notifier is an autogenerated mock type for the Notifier type
+type Notifier struct {
+	mock.Mock
+}
+
+// BalanceToppedUp provides a mock function with given fields: ctx, company, amount
+func (_m *Notifier) BalanceToppedUp(ctx context.Context, company *model.Company, amount money.Money) {
+	_m.Called(ctx, company, amount)
+}
+
+// SalaryWithdrawn provides a mock function with given fields: ctx, user, payslip
+func (_m *Notifier) SalaryWithdrawn(ctx context.Context, user *model.User, payslip *model.Payslip) {
+	_m.Called(ctx, user, payslip)
+}
+
+type mockConstructorTestingTNewNotifier interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewNotifier creates a new instance of Notifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewNotifier(t mockConstructorTestingTNewNotifier) *Notifier {
+	mock := &Notifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,215 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OvertimeRepository is an autogenerated mock type for the OvertimeRepository type
+type OvertimeRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, overtime
+func (_m *OvertimeRepository) Create(ctx context.Context, overtime *model.Overtime) (*model.Overtime, error) {
+	ret := _m.Called(ctx, overtime)
+
+	var r0 *model.Overtime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Overtime) (*model.Overtime, error)); ok {
+		return rf(ctx, overtime)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Overtime) *model.Overtime); ok {
+		r0 = rf(ctx, overtime)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Overtime)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Overtime) error); ok {
+		r1 = rf(ctx, overtime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, userID, id
+func (_m *OvertimeRepository) Delete(ctx context.Context, userID int, id int) error {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchByUserID provides a mock function with given fields: ctx, userID
+func (_m *OvertimeRepository) FetchByUserID(ctx context.Context, userID int) ([]*model.Overtime, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*model.Overtime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.Overtime, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.Overtime); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Overtime)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchPaidIn provides a mock function with given fields: ctx, userID, period
+func (_m *OvertimeRepository) FetchPaidIn(ctx context.Context, userID int, period string) ([]*model.Overtime, error) {
+	ret := _m.Called(ctx, userID, period)
+
+	var r0 []*model.Overtime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) ([]*model.Overtime, error)); ok {
+		return rf(ctx, userID, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) []*model.Overtime); ok {
+		r0 = rf(ctx, userID, period)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Overtime)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, userID, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchPayable provides a mock function with given fields: ctx, userID, before
+func (_m *OvertimeRepository) FetchPayable(ctx context.Context, userID int, before time.Time) ([]*model.Overtime, error) {
+	ret := _m.Called(ctx, userID, before)
+
+	var r0 []*model.Overtime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) ([]*model.Overtime, error)); ok {
+		return rf(ctx, userID, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) []*model.Overtime); ok {
+		r0 = rf(ctx, userID, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Overtime)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = rf(ctx, userID, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, userID, id
+func (_m *OvertimeRepository) FindByID(ctx context.Context, userID int, id int) (*model.Overtime, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Overtime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Overtime, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Overtime); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Overtime)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkPaid provides a mock function with given fields: ctx, ids, transactionID
+func (_m *OvertimeRepository) MarkPaid(ctx context.Context, ids []int, transactionID int) error {
+	ret := _m.Called(ctx, ids, transactionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int, int) error); ok {
+		r0 = rf(ctx, ids, transactionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateByID provides a mock function with given fields: ctx, id, overtime
+func (_m *OvertimeRepository) UpdateByID(ctx context.Context, id int, overtime *model.Overtime) (*model.Overtime, error) {
+	ret := _m.Called(ctx, id, overtime)
+
+	var r0 *model.Overtime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.Overtime) (*model.Overtime, error)); ok {
+		return rf(ctx, id, overtime)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.Overtime) *model.Overtime); ok {
+		r0 = rf(ctx, id, overtime)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Overtime)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *model.Overtime) error); ok {
+		r1 = rf(ctx, id, overtime)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewOvertimeRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewOvertimeRepository creates a new instance of OvertimeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewOvertimeRepository(t mockConstructorTestingTNewOvertimeRepository) *OvertimeRepository {
+	mock := &OvertimeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,175 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	request "self-payrol/request"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OvertimeUsecase is an autogenerated mock type for the OvertimeUsecase type
+type OvertimeUsecase struct {
+	mock.Mock
+}
+
+// ApproveOvertime provides a mock function with given fields: ctx, userID, id
+func (_m *OvertimeUsecase) ApproveOvertime(ctx context.Context, userID int, id int) (*model.Overtime, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Overtime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Overtime, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Overtime); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Overtime)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DestroyOvertime provides a mock function with given fields: ctx, userID, id
+func (_m *OvertimeUsecase) DestroyOvertime(ctx context.Context, userID int, id int) error {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchOvertime provides a mock function with given fields: ctx, userID
+func (_m *OvertimeUsecase) FetchOvertime(ctx context.Context, userID int) ([]*model.Overtime, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*model.Overtime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.Overtime, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.Overtime); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Overtime)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, userID, id
+func (_m *OvertimeUsecase) GetByID(ctx context.Context, userID int, id int) (*model.Overtime, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Overtime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Overtime, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Overtime); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Overtime)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RejectOvertime provides a mock function with given fields: ctx, userID, id
+func (_m *OvertimeUsecase) RejectOvertime(ctx context.Context, userID int, id int) (*model.Overtime, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Overtime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Overtime, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Overtime); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Overtime)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StoreOvertime provides a mock function with given fields: ctx, userID, req
+func (_m *OvertimeUsecase) StoreOvertime(ctx context.Context, userID int, req *request.OvertimeRequest) (*model.Overtime, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 *model.Overtime
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.OvertimeRequest) (*model.Overtime, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.OvertimeRequest) *model.Overtime); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Overtime)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *request.OvertimeRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewOvertimeUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewOvertimeUsecase creates a new instance of OvertimeUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewOvertimeUsecase(t mockConstructorTestingTNewOvertimeUsecase) *OvertimeUsecase {
+	mock := &OvertimeUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,70 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PayCalculator is an autogenerated mock type for the PayCalculator type
+type PayCalculator struct {
+	mock.Mock
+}
+
+// Calculate provides a mock function with given fields: ctx, user, period
+func (_m *PayCalculator) Calculate(ctx context.Context, user *model.User, period model.PayrollPeriod) (*model.PayBreakdown, error) {
+	ret := _m.Called(ctx, user, period)
+
+	var r0 *model.PayBreakdown
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, model.PayrollPeriod) (*model.PayBreakdown, error)); ok {
+		return rf(ctx, user, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.User, model.PayrollPeriod) *model.PayBreakdown); ok {
+		r0 = rf(ctx, user, period)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PayBreakdown)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.User, model.PayrollPeriod) error); ok {
+		r1 = rf(ctx, user, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Settle provides a mock function with given fields: ctx, breakdown, trx
+func (_m *PayCalculator) Settle(ctx context.Context, breakdown *model.PayBreakdown, trx *model.Transaction) error {
+	ret := _m.Called(ctx, breakdown, trx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.PayBreakdown, *model.Transaction) error); ok {
+		r0 = rf(ctx, breakdown, trx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPayCalculator interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPayCalculator creates a new instance of PayCalculator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPayCalculator(t mockConstructorTestingTNewPayCalculator) *PayCalculator {
+	mock := &PayCalculator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
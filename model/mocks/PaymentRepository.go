@@ -0,0 +1,241 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PaymentRepository is an autogenerated mock type for the PaymentRepository type
+type PaymentRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, payment
+func (_m *PaymentRepository) Create(ctx context.Context, payment *model.Payment) (*model.Payment, error) {
+	ret := _m.Called(ctx, payment)
+
+	var r0 *model.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Payment) (*model.Payment, error)); ok {
+		return rf(ctx, payment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Payment) *model.Payment); ok {
+		r0 = rf(ctx, payment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Payment) error); ok {
+		r1 = rf(ctx, payment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, userID, id
+func (_m *PaymentRepository) Delete(ctx context.Context, userID int, id int) error {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchByUserID provides a mock function with given fields: ctx, userID
+func (_m *PaymentRepository) FetchByUserID(ctx context.Context, userID int) ([]*model.Payment, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*model.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.Payment, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.Payment); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchPaidIn provides a mock function with given fields: ctx, userID, period
+func (_m *PaymentRepository) FetchPaidIn(ctx context.Context, userID int, period string) ([]*model.Payment, error) {
+	ret := _m.Called(ctx, userID, period)
+
+	var r0 []*model.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) ([]*model.Payment, error)); ok {
+		return rf(ctx, userID, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) []*model.Payment); ok {
+		r0 = rf(ctx, userID, period)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, userID, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchPayable provides a mock function with given fields: ctx, userID, before
+func (_m *PaymentRepository) FetchPayable(ctx context.Context, userID int, before time.Time) ([]*model.Payment, error) {
+	ret := _m.Called(ctx, userID, before)
+
+	var r0 []*model.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) ([]*model.Payment, error)); ok {
+		return rf(ctx, userID, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) []*model.Payment); ok {
+		r0 = rf(ctx, userID, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = rf(ctx, userID, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, userID, id
+func (_m *PaymentRepository) FindByID(ctx context.Context, userID int, id int) (*model.Payment, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Payment, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Payment); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Lock provides a mock function with given fields: ctx, userID, id
+func (_m *PaymentRepository) Lock(ctx context.Context, userID int, id int) (*model.Payment, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Payment, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Payment); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkPaid provides a mock function with given fields: ctx, ids, transactionID
+func (_m *PaymentRepository) MarkPaid(ctx context.Context, ids []int, transactionID int) error {
+	ret := _m.Called(ctx, ids, transactionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int, int) error); ok {
+		r0 = rf(ctx, ids, transactionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateByID provides a mock function with given fields: ctx, id, payment
+func (_m *PaymentRepository) UpdateByID(ctx context.Context, id int, payment *model.Payment) (*model.Payment, error) {
+	ret := _m.Called(ctx, id, payment)
+
+	var r0 *model.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.Payment) (*model.Payment, error)); ok {
+		return rf(ctx, id, payment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.Payment) *model.Payment); ok {
+		r0 = rf(ctx, id, payment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *model.Payment) error); ok {
+		r1 = rf(ctx, id, payment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPaymentRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPaymentRepository creates a new instance of PaymentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPaymentRepository(t mockConstructorTestingTNewPaymentRepository) *PaymentRepository {
+	mock := &PaymentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
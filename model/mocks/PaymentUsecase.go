@@ -0,0 +1,201 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	request "self-payrol/request"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PaymentUsecase is an autogenerated mock type for the PaymentUsecase type
+type PaymentUsecase struct {
+	mock.Mock
+}
+
+// ApprovePayment provides a mock function with given fields: ctx, userID, id
+func (_m *PaymentUsecase) ApprovePayment(ctx context.Context, userID int, id int) (*model.Payment, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Payment, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Payment); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DestroyPayment provides a mock function with given fields: ctx, userID, id
+func (_m *PaymentUsecase) DestroyPayment(ctx context.Context, userID int, id int) error {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DisbursePayment provides a mock function with given fields: ctx, userID, id
+func (_m *PaymentUsecase) DisbursePayment(ctx context.Context, userID int, id int) (*model.Transaction, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Transaction, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Transaction); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchPayment provides a mock function with given fields: ctx, userID
+func (_m *PaymentUsecase) FetchPayment(ctx context.Context, userID int) ([]*model.Payment, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*model.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.Payment, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.Payment); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, userID, id
+func (_m *PaymentUsecase) GetByID(ctx context.Context, userID int, id int) (*model.Payment, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Payment, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Payment); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RejectPayment provides a mock function with given fields: ctx, userID, id
+func (_m *PaymentUsecase) RejectPayment(ctx context.Context, userID int, id int) (*model.Payment, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Payment, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Payment); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorePayment provides a mock function with given fields: ctx, userID, req
+func (_m *PaymentUsecase) StorePayment(ctx context.Context, userID int, req *request.PaymentRequest) (*model.Payment, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 *model.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.PaymentRequest) (*model.Payment, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.PaymentRequest) *model.Payment); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *request.PaymentRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPaymentUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPaymentUsecase creates a new instance of PaymentUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPaymentUsecase(t mockConstructorTestingTNewPaymentUsecase) *PaymentUsecase {
+	mock := &PaymentUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,212 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PayrollRunRepository is an autogenerated mock type for the PayrollRunRepository type
+type PayrollRunRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, run
+func (_m *PayrollRunRepository) Create(ctx context.Context, run *model.PayrollRun) (*model.PayrollRun, error) {
+	ret := _m.Called(ctx, run)
+
+	var r0 *model.PayrollRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.PayrollRun) (*model.PayrollRun, error)); ok {
+		return rf(ctx, run)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.PayrollRun) *model.PayrollRun); ok {
+		r0 = rf(ctx, run)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PayrollRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.PayrollRun) error); ok {
+		r1 = rf(ctx, run)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateEvent provides a mock function with given fields: ctx, event
+func (_m *PayrollRunRepository) CreateEvent(ctx context.Context, event *model.PayrollRunEvent) (*model.PayrollRunEvent, error) {
+	ret := _m.Called(ctx, event)
+
+	var r0 *model.PayrollRunEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.PayrollRunEvent) (*model.PayrollRunEvent, error)); ok {
+		return rf(ctx, event)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.PayrollRunEvent) *model.PayrollRunEvent); ok {
+		r0 = rf(ctx, event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PayrollRunEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.PayrollRunEvent) error); ok {
+		r1 = rf(ctx, event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Fetch provides a mock function with given fields: ctx, limit, offset
+func (_m *PayrollRunRepository) Fetch(ctx context.Context, limit int, offset int) ([]*model.PayrollRun, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	var r0 []*model.PayrollRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*model.PayrollRun, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*model.PayrollRun); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PayrollRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *PayrollRunRepository) FindByID(ctx context.Context, id int) (*model.PayrollRun, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.PayrollRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*model.PayrollRun, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.PayrollRun); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PayrollRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Lock provides a mock function with given fields: ctx, id
+func (_m *PayrollRunRepository) Lock(ctx context.Context, id int) (*model.PayrollRun, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.PayrollRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*model.PayrollRun, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.PayrollRun); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PayrollRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateByID provides a mock function with given fields: ctx, id, run
+func (_m *PayrollRunRepository) UpdateByID(ctx context.Context, id int, run *model.PayrollRun) (*model.PayrollRun, error) {
+	ret := _m.Called(ctx, id, run)
+
+	var r0 *model.PayrollRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.PayrollRun) (*model.PayrollRun, error)); ok {
+		return rf(ctx, id, run)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.PayrollRun) *model.PayrollRun); ok {
+		r0 = rf(ctx, id, run)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PayrollRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *model.PayrollRun) error); ok {
+		r1 = rf(ctx, id, run)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateItem provides a mock function with given fields: ctx, item
+func (_m *PayrollRunRepository) UpdateItem(ctx context.Context, item *model.PayrollRunItem) (*model.PayrollRunItem, error) {
+	ret := _m.Called(ctx, item)
+
+	var r0 *model.PayrollRunItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.PayrollRunItem) (*model.PayrollRunItem, error)); ok {
+		return rf(ctx, item)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.PayrollRunItem) *model.PayrollRunItem); ok {
+		r0 = rf(ctx, item)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PayrollRunItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.PayrollRunItem) error); ok {
+		r1 = rf(ctx, item)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPayrollRunRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPayrollRunRepository creates a new instance of PayrollRunRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPayrollRunRepository(t mockConstructorTestingTNewPayrollRunRepository) *PayrollRunRepository {
+	mock := &PayrollRunRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
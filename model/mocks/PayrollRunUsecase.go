@@ -0,0 +1,265 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	request "self-payrol/request"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PayrollRunUsecase is an autogenerated mock type for the PayrollRunUsecase type
+type PayrollRunUsecase struct {
+	mock.Mock
+}
+
+// Approve provides a mock function with given fields: ctx, id, req
+func (_m *PayrollRunUsecase) Approve(ctx context.Context, id int, req *request.PayrollRunActionRequest) (*model.PayrollRun, error) {
+	ret := _m.Called(ctx, id, req)
+
+	var r0 *model.PayrollRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.PayrollRunActionRequest) (*model.PayrollRun, error)); ok {
+		return rf(ctx, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.PayrollRunActionRequest) *model.PayrollRun); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PayrollRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *request.PayrollRunActionRequest) error); ok {
+		r1 = rf(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BankFile provides a mock function with given fields: ctx, id, format, currency
+func (_m *PayrollRunUsecase) BankFile(ctx context.Context, id int, format string, currency string) (*model.BankFile, error) {
+	ret := _m.Called(ctx, id, format, currency)
+
+	var r0 *model.BankFile
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) (*model.BankFile, error)); ok {
+		return rf(ctx, id, format, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) *model.BankFile); ok {
+		r0 = rf(ctx, id, format, currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.BankFile)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, string) error); ok {
+		r1 = rf(ctx, id, format, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Disburse provides a mock function with given fields: ctx, id, req
+func (_m *PayrollRunUsecase) Disburse(ctx context.Context, id int, req *request.PayrollRunActionRequest) (*model.PayrollRun, error) {
+	ret := _m.Called(ctx, id, req)
+
+	var r0 *model.PayrollRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.PayrollRunActionRequest) (*model.PayrollRun, error)); ok {
+		return rf(ctx, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.PayrollRunActionRequest) *model.PayrollRun); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PayrollRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *request.PayrollRunActionRequest) error); ok {
+		r1 = rf(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Draft provides a mock function with given fields: ctx, trigger, req
+func (_m *PayrollRunUsecase) Draft(ctx context.Context, trigger string, req *request.PayrollRunActionRequest) (*model.PayrollRun, error) {
+	ret := _m.Called(ctx, trigger, req)
+
+	var r0 *model.PayrollRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *request.PayrollRunActionRequest) (*model.PayrollRun, error)); ok {
+		return rf(ctx, trigger, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *request.PayrollRunActionRequest) *model.PayrollRun); ok {
+		r0 = rf(ctx, trigger, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PayrollRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *request.PayrollRunActionRequest) error); ok {
+		r1 = rf(ctx, trigger, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EditItem provides a mock function with given fields: ctx, id, itemID, req
+func (_m *PayrollRunUsecase) EditItem(ctx context.Context, id int, itemID int, req *request.PayrollRunItemRequest) (*model.PayrollRun, error) {
+	ret := _m.Called(ctx, id, itemID, req)
+
+	var r0 *model.PayrollRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *request.PayrollRunItemRequest) (*model.PayrollRun, error)); ok {
+		return rf(ctx, id, itemID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *request.PayrollRunItemRequest) *model.PayrollRun); ok {
+		r0 = rf(ctx, id, itemID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PayrollRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, *request.PayrollRunItemRequest) error); ok {
+		r1 = rf(ctx, id, itemID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchPayrollRuns provides a mock function with given fields: ctx, limit, offset
+func (_m *PayrollRunUsecase) FetchPayrollRuns(ctx context.Context, limit int, offset int) ([]*model.PayrollRun, error) {
+	ret := _m.Called(ctx, limit, offset)
+
+	var r0 []*model.PayrollRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]*model.PayrollRun, error)); ok {
+		return rf(ctx, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []*model.PayrollRun); ok {
+		r0 = rf(ctx, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PayrollRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *PayrollRunUsecase) GetByID(ctx context.Context, id int) (*model.PayrollRun, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *model.PayrollRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*model.PayrollRun, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *model.PayrollRun); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PayrollRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reject provides a mock function with given fields: ctx, id, req
+func (_m *PayrollRunUsecase) Reject(ctx context.Context, id int, req *request.PayrollRunActionRequest) (*model.PayrollRun, error) {
+	ret := _m.Called(ctx, id, req)
+
+	var r0 *model.PayrollRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.PayrollRunActionRequest) (*model.PayrollRun, error)); ok {
+		return rf(ctx, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.PayrollRunActionRequest) *model.PayrollRun); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PayrollRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *request.PayrollRunActionRequest) error); ok {
+		r1 = rf(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Submit provides a mock function with given fields: ctx, id, req
+func (_m *PayrollRunUsecase) Submit(ctx context.Context, id int, req *request.PayrollRunActionRequest) (*model.PayrollRun, error) {
+	ret := _m.Called(ctx, id, req)
+
+	var r0 *model.PayrollRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.PayrollRunActionRequest) (*model.PayrollRun, error)); ok {
+		return rf(ctx, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.PayrollRunActionRequest) *model.PayrollRun); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PayrollRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *request.PayrollRunActionRequest) error); ok {
+		r1 = rf(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPayrollRunUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPayrollRunUsecase creates a new instance of PayrollRunUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPayrollRunUsecase(t mockConstructorTestingTNewPayrollRunUsecase) *PayrollRunUsecase {
+	mock := &PayrollRunUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
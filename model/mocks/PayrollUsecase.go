@@ -0,0 +1,56 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PayrollUsecase is an autogenerated mock type for the PayrollUsecase type
+type PayrollUsecase struct {
+	mock.Mock
+}
+
+// Preview provides a mock function with given fields: ctx, period
+func (_m *PayrollUsecase) Preview(ctx context.Context, period string) (*model.PayrollPreview, error) {
+	ret := _m.Called(ctx, period)
+
+	var r0 *model.PayrollPreview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*model.PayrollPreview, error)); ok {
+		return rf(ctx, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.PayrollPreview); ok {
+		r0 = rf(ctx, period)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PayrollPreview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPayrollUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPayrollUsecase creates a new instance of PayrollUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPayrollUsecase(t mockConstructorTestingTNewPayrollUsecase) *PayrollUsecase {
+	mock := &PayrollUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,55 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "self-payrol/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PayslipRenderer is an autogenerated mock type for the PayslipRenderer type
+type PayslipRenderer struct {
+	mock.Mock
+}
+
+// Render provides a mock function with given fields: payslip
+func (_m *PayslipRenderer) Render(payslip *model.Payslip) ([]byte, error) {
+	ret := _m.Called(payslip)
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*model.Payslip) ([]byte, error)); ok {
+		return rf(payslip)
+	}
+	if rf, ok := ret.Get(0).(func(*model.Payslip) []byte); ok {
+		r0 = rf(payslip)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.Payslip) error); ok {
+		r1 = rf(payslip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPayslipRenderer interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPayslipRenderer creates a new instance of PayslipRenderer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPayslipRenderer(t mockConstructorTestingTNewPayslipRenderer) *PayslipRenderer {
+	mock := &PayslipRenderer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,108 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PayslipRepository is an autogenerated mock type for the PayslipRepository type
+type PayslipRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, payslip
+func (_m *PayslipRepository) Create(ctx context.Context, payslip *model.Payslip) (*model.Payslip, error) {
+	ret := _m.Called(ctx, payslip)
+
+	var r0 *model.Payslip
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Payslip) (*model.Payslip, error)); ok {
+		return rf(ctx, payslip)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Payslip) *model.Payslip); ok {
+		r0 = rf(ctx, payslip)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Payslip)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Payslip) error); ok {
+		r1 = rf(ctx, payslip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchByUserID provides a mock function with given fields: ctx, userID
+func (_m *PayslipRepository) FetchByUserID(ctx context.Context, userID int) ([]*model.Payslip, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*model.Payslip
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.Payslip, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.Payslip); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Payslip)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindLatest provides a mock function with given fields: ctx, userID, period
+func (_m *PayslipRepository) FindLatest(ctx context.Context, userID int, period string) (*model.Payslip, error) {
+	ret := _m.Called(ctx, userID, period)
+
+	var r0 *model.Payslip
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (*model.Payslip, error)); ok {
+		return rf(ctx, userID, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) *model.Payslip); ok {
+		r0 = rf(ctx, userID, period)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Payslip)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, userID, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPayslipRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPayslipRepository creates a new instance of PayslipRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPayslipRepository(t mockConstructorTestingTNewPayslipRepository) *PayslipRepository {
+	mock := &PayslipRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PayslipUsecase is an autogenerated mock type for the PayslipUsecase type
+type PayslipUsecase struct {
+	mock.Mock
+}
+
+// FetchPayslips provides a mock function with given fields: ctx, userID
+func (_m *PayslipUsecase) FetchPayslips(ctx context.Context, userID int) ([]*model.Payslip, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*model.Payslip
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.Payslip, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.Payslip); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Payslip)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RenderPayslip provides a mock function with given fields: ctx, userID, period
+func (_m *PayslipUsecase) RenderPayslip(ctx context.Context, userID int, period string) (*model.Payslip, []byte, error) {
+	ret := _m.Called(ctx, userID, period)
+
+	var r0 *model.Payslip
+	var r1 []byte
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (*model.Payslip, []byte, error)); ok {
+		return rf(ctx, userID, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) *model.Payslip); ok {
+		r0 = rf(ctx, userID, period)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Payslip)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) []byte); ok {
+		r1 = rf(ctx, userID, period)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, int, string) error); ok {
+		r2 = rf(ctx, userID, period)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewPayslipUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPayslipUsecase creates a new instance of PayslipUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPayslipUsecase(t mockConstructorTestingTNewPayslipUsecase) *PayslipUsecase {
+	mock := &PayslipUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
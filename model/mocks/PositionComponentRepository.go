@@ -0,0 +1,148 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PositionComponentRepository is an autogenerated mock type for the PositionComponentRepository type
+type PositionComponentRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, component
+func (_m *PositionComponentRepository) Create(ctx context.Context, component *model.PositionComponent) (*model.PositionComponent, error) {
+	ret := _m.Called(ctx, component)
+
+	var r0 *model.PositionComponent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.PositionComponent) (*model.PositionComponent, error)); ok {
+		return rf(ctx, component)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.PositionComponent) *model.PositionComponent); ok {
+		r0 = rf(ctx, component)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PositionComponent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.PositionComponent) error); ok {
+		r1 = rf(ctx, component)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, positionID, id
+func (_m *PositionComponentRepository) Delete(ctx context.Context, positionID int, id int) error {
+	ret := _m.Called(ctx, positionID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, positionID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchByPositionID provides a mock function with given fields: ctx, positionID
+func (_m *PositionComponentRepository) FetchByPositionID(ctx context.Context, positionID int) ([]*model.PositionComponent, error) {
+	ret := _m.Called(ctx, positionID)
+
+	var r0 []*model.PositionComponent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.PositionComponent, error)); ok {
+		return rf(ctx, positionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.PositionComponent); ok {
+		r0 = rf(ctx, positionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PositionComponent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, positionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, positionID, id
+func (_m *PositionComponentRepository) FindByID(ctx context.Context, positionID int, id int) (*model.PositionComponent, error) {
+	ret := _m.Called(ctx, positionID, id)
+
+	var r0 *model.PositionComponent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.PositionComponent, error)); ok {
+		return rf(ctx, positionID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.PositionComponent); ok {
+		r0 = rf(ctx, positionID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PositionComponent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, positionID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateByID provides a mock function with given fields: ctx, id, component
+func (_m *PositionComponentRepository) UpdateByID(ctx context.Context, id int, component *model.PositionComponent) (*model.PositionComponent, error) {
+	ret := _m.Called(ctx, id, component)
+
+	var r0 *model.PositionComponent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.PositionComponent) (*model.PositionComponent, error)); ok {
+		return rf(ctx, id, component)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.PositionComponent) *model.PositionComponent); ok {
+		r0 = rf(ctx, id, component)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PositionComponent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *model.PositionComponent) error); ok {
+		r1 = rf(ctx, id, component)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPositionComponentRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPositionComponentRepository creates a new instance of PositionComponentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPositionComponentRepository(t mockConstructorTestingTNewPositionComponentRepository) *PositionComponentRepository {
+	mock := &PositionComponentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
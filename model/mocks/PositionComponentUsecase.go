@@ -0,0 +1,149 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	request "self-payrol/request"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PositionComponentUsecase is an autogenerated mock type for the PositionComponentUsecase type
+type PositionComponentUsecase struct {
+	mock.Mock
+}
+
+// DestroyComponent provides a mock function with given fields: ctx, positionID, id
+func (_m *PositionComponentUsecase) DestroyComponent(ctx context.Context, positionID int, id int) error {
+	ret := _m.Called(ctx, positionID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, positionID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditComponent provides a mock function with given fields: ctx, positionID, id, req
+func (_m *PositionComponentUsecase) EditComponent(ctx context.Context, positionID int, id int, req *request.PositionComponentRequest) (*model.PositionComponent, error) {
+	ret := _m.Called(ctx, positionID, id, req)
+
+	var r0 *model.PositionComponent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *request.PositionComponentRequest) (*model.PositionComponent, error)); ok {
+		return rf(ctx, positionID, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *request.PositionComponentRequest) *model.PositionComponent); ok {
+		r0 = rf(ctx, positionID, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PositionComponent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, *request.PositionComponentRequest) error); ok {
+		r1 = rf(ctx, positionID, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchComponent provides a mock function with given fields: ctx, positionID
+func (_m *PositionComponentUsecase) FetchComponent(ctx context.Context, positionID int) ([]*model.PositionComponent, error) {
+	ret := _m.Called(ctx, positionID)
+
+	var r0 []*model.PositionComponent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.PositionComponent, error)); ok {
+		return rf(ctx, positionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.PositionComponent); ok {
+		r0 = rf(ctx, positionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PositionComponent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, positionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, positionID, id
+func (_m *PositionComponentUsecase) GetByID(ctx context.Context, positionID int, id int) (*model.PositionComponent, error) {
+	ret := _m.Called(ctx, positionID, id)
+
+	var r0 *model.PositionComponent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.PositionComponent, error)); ok {
+		return rf(ctx, positionID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.PositionComponent); ok {
+		r0 = rf(ctx, positionID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PositionComponent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, positionID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StoreComponent provides a mock function with given fields: ctx, positionID, req
+func (_m *PositionComponentUsecase) StoreComponent(ctx context.Context, positionID int, req *request.PositionComponentRequest) (*model.PositionComponent, error) {
+	ret := _m.Called(ctx, positionID, req)
+
+	var r0 *model.PositionComponent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.PositionComponentRequest) (*model.PositionComponent, error)); ok {
+		return rf(ctx, positionID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.PositionComponentRequest) *model.PositionComponent); ok {
+		r0 = rf(ctx, positionID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PositionComponent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *request.PositionComponentRequest) error); ok {
+		r1 = rf(ctx, positionID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPositionComponentUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPositionComponentUsecase creates a new instance of PositionComponentUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPositionComponentUsecase(t mockConstructorTestingTNewPositionComponentUsecase) *PositionComponentUsecase {
+	mock := &PositionComponentUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,82 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PositionSalaryRepository is an autogenerated mock type for the PositionSalaryRepository type
+type PositionSalaryRepository struct {
+	mock.Mock
+}
+
+// FetchByPositionID provides a mock function with given fields: ctx, positionID
+func (_m *PositionSalaryRepository) FetchByPositionID(ctx context.Context, positionID int) ([]*model.PositionSalary, error) {
+	ret := _m.Called(ctx, positionID)
+
+	var r0 []*model.PositionSalary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.PositionSalary, error)); ok {
+		return rf(ctx, positionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.PositionSalary); ok {
+		r0 = rf(ctx, positionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PositionSalary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, positionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Upsert provides a mock function with given fields: ctx, salary
+func (_m *PositionSalaryRepository) Upsert(ctx context.Context, salary *model.PositionSalary) (*model.PositionSalary, error) {
+	ret := _m.Called(ctx, salary)
+
+	var r0 *model.PositionSalary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.PositionSalary) (*model.PositionSalary, error)); ok {
+		return rf(ctx, salary)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.PositionSalary) *model.PositionSalary); ok {
+		r0 = rf(ctx, salary)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PositionSalary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.PositionSalary) error); ok {
+		r1 = rf(ctx, salary)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewPositionSalaryRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPositionSalaryRepository creates a new instance of PositionSalaryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPositionSalaryRepository(t mockConstructorTestingTNewPositionSalaryRepository) *PositionSalaryRepository {
+	mock := &PositionSalaryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
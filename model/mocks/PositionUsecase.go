@@ -5,10 +5,9 @@ package mocks
 import (
 	context "context"
 	model "self-payrol/model"
+	request "self-payrol/request"
 
 	mock "github.com/stretchr/testify/mock"
-
-	request "self-payrol/request"
 )
 
 // PositionUsecase is an autogenerated mock type for the PositionUsecase type
@@ -82,6 +81,32 @@ func (_m *PositionUsecase) FetchPosition(ctx context.Context, limit int, offset
 	return r0, r1
 }
 
+// FetchSalaryHistory provides a mock function with given fields: ctx, id
+func (_m *PositionUsecase) FetchSalaryHistory(ctx context.Context, id int) ([]*model.PositionSalary, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []*model.PositionSalary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.PositionSalary, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.PositionSalary); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PositionSalary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByID provides a mock function with given fields: ctx, id
 func (_m *PositionUsecase) GetByID(ctx context.Context, id int) (*model.Position, error) {
 	ret := _m.Called(ctx, id)
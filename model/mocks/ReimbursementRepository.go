@@ -0,0 +1,267 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReimbursementRepository is an autogenerated mock type for the ReimbursementRepository type
+type ReimbursementRepository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, claim
+func (_m *ReimbursementRepository) Create(ctx context.Context, claim *model.Reimbursement) (*model.Reimbursement, error) {
+	ret := _m.Called(ctx, claim)
+
+	var r0 *model.Reimbursement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Reimbursement) (*model.Reimbursement, error)); ok {
+		return rf(ctx, claim)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Reimbursement) *model.Reimbursement); ok {
+		r0 = rf(ctx, claim)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Reimbursement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.Reimbursement) error); ok {
+		r1 = rf(ctx, claim)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, userID, id
+func (_m *ReimbursementRepository) Delete(ctx context.Context, userID int, id int) error {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchByUserID provides a mock function with given fields: ctx, userID
+func (_m *ReimbursementRepository) FetchByUserID(ctx context.Context, userID int) ([]*model.Reimbursement, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*model.Reimbursement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.Reimbursement, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.Reimbursement); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Reimbursement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchPaidIn provides a mock function with given fields: ctx, userID, period
+func (_m *ReimbursementRepository) FetchPaidIn(ctx context.Context, userID int, period string) ([]*model.Reimbursement, error) {
+	ret := _m.Called(ctx, userID, period)
+
+	var r0 []*model.Reimbursement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) ([]*model.Reimbursement, error)); ok {
+		return rf(ctx, userID, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) []*model.Reimbursement); ok {
+		r0 = rf(ctx, userID, period)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Reimbursement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, userID, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchPayable provides a mock function with given fields: ctx, userID, before
+func (_m *ReimbursementRepository) FetchPayable(ctx context.Context, userID int, before time.Time) ([]*model.Reimbursement, error) {
+	ret := _m.Called(ctx, userID, before)
+
+	var r0 []*model.Reimbursement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) ([]*model.Reimbursement, error)); ok {
+		return rf(ctx, userID, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Time) []*model.Reimbursement); ok {
+		r0 = rf(ctx, userID, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Reimbursement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = rf(ctx, userID, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, userID, id
+func (_m *ReimbursementRepository) FindByID(ctx context.Context, userID int, id int) (*model.Reimbursement, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Reimbursement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Reimbursement, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Reimbursement); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Reimbursement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindReceipt provides a mock function with given fields: ctx, userID, id
+func (_m *ReimbursementRepository) FindReceipt(ctx context.Context, userID int, id int) (*model.Reimbursement, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Reimbursement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Reimbursement, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Reimbursement); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Reimbursement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Lock provides a mock function with given fields: ctx, userID, id
+func (_m *ReimbursementRepository) Lock(ctx context.Context, userID int, id int) (*model.Reimbursement, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Reimbursement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Reimbursement, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Reimbursement); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Reimbursement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkPaid provides a mock function with given fields: ctx, ids, transactionID
+func (_m *ReimbursementRepository) MarkPaid(ctx context.Context, ids []int, transactionID int) error {
+	ret := _m.Called(ctx, ids, transactionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int, int) error); ok {
+		r0 = rf(ctx, ids, transactionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateByID provides a mock function with given fields: ctx, id, claim
+func (_m *ReimbursementRepository) UpdateByID(ctx context.Context, id int, claim *model.Reimbursement) (*model.Reimbursement, error) {
+	ret := _m.Called(ctx, id, claim)
+
+	var r0 *model.Reimbursement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.Reimbursement) (*model.Reimbursement, error)); ok {
+		return rf(ctx, id, claim)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.Reimbursement) *model.Reimbursement); ok {
+		r0 = rf(ctx, id, claim)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Reimbursement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *model.Reimbursement) error); ok {
+		r1 = rf(ctx, id, claim)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewReimbursementRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewReimbursementRepository creates a new instance of ReimbursementRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewReimbursementRepository(t mockConstructorTestingTNewReimbursementRepository) *ReimbursementRepository {
+	mock := &ReimbursementRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
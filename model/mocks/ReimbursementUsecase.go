@@ -0,0 +1,201 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+	request "self-payrol/request"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReimbursementUsecase is an autogenerated mock type for the ReimbursementUsecase type
+type ReimbursementUsecase struct {
+	mock.Mock
+}
+
+// ApproveReimbursement provides a mock function with given fields: ctx, userID, id, req
+func (_m *ReimbursementUsecase) ApproveReimbursement(ctx context.Context, userID int, id int, req *request.ReimbursementReviewRequest) (*model.Reimbursement, error) {
+	ret := _m.Called(ctx, userID, id, req)
+
+	var r0 *model.Reimbursement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *request.ReimbursementReviewRequest) (*model.Reimbursement, error)); ok {
+		return rf(ctx, userID, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, *request.ReimbursementReviewRequest) *model.Reimbursement); ok {
+		r0 = rf(ctx, userID, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Reimbursement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, *request.ReimbursementReviewRequest) error); ok {
+		r1 = rf(ctx, userID, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DestroyReimbursement provides a mock function with given fields: ctx, userID, id
+func (_m *ReimbursementUsecase) DestroyReimbursement(ctx context.Context, userID int, id int) error {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchReimbursement provides a mock function with given fields: ctx, userID
+func (_m *ReimbursementUsecase) FetchReimbursement(ctx context.Context, userID int) ([]*model.Reimbursement, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*model.Reimbursement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.Reimbursement, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.Reimbursement); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Reimbursement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, userID, id
+func (_m *ReimbursementUsecase) GetByID(ctx context.Context, userID int, id int) (*model.Reimbursement, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Reimbursement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Reimbursement, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Reimbursement); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Reimbursement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceipt provides a mock function with given fields: ctx, userID, id
+func (_m *ReimbursementUsecase) GetReceipt(ctx context.Context, userID int, id int) (*model.Reimbursement, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Reimbursement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Reimbursement, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Reimbursement); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Reimbursement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RejectReimbursement provides a mock function with given fields: ctx, userID, id
+func (_m *ReimbursementUsecase) RejectReimbursement(ctx context.Context, userID int, id int) (*model.Reimbursement, error) {
+	ret := _m.Called(ctx, userID, id)
+
+	var r0 *model.Reimbursement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*model.Reimbursement, error)); ok {
+		return rf(ctx, userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *model.Reimbursement); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Reimbursement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StoreReimbursement provides a mock function with given fields: ctx, userID, req
+func (_m *ReimbursementUsecase) StoreReimbursement(ctx context.Context, userID int, req *request.ReimbursementRequest) (*model.Reimbursement, error) {
+	ret := _m.Called(ctx, userID, req)
+
+	var r0 *model.Reimbursement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.ReimbursementRequest) (*model.Reimbursement, error)); ok {
+		return rf(ctx, userID, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.ReimbursementRequest) *model.Reimbursement); ok {
+		r0 = rf(ctx, userID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Reimbursement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *request.ReimbursementRequest) error); ok {
+		r1 = rf(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewReimbursementUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewReimbursementUsecase creates a new instance of ReimbursementUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewReimbursementUsecase(t mockConstructorTestingTNewReimbursementUsecase) *ReimbursementUsecase {
+	mock := &ReimbursementUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,56 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	model "self-payrol/model"
+	money "self-payrol/money"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TaxCalculator is an autogenerated mock type for the TaxCalculator type
+type TaxCalculator struct {
+	mock.Mock
+}
+
+// Withholding provides a mock function with given fields: period, status, gross, deductible
+func (_m *TaxCalculator) Withholding(period model.PayrollPeriod, status string, gross money.Money, deductible money.Money) (*model.TaxCalculation, error) {
+	ret := _m.Called(period, status, gross, deductible)
+
+	var r0 *model.TaxCalculation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model.PayrollPeriod, string, money.Money, money.Money) (*model.TaxCalculation, error)); ok {
+		return rf(period, status, gross, deductible)
+	}
+	if rf, ok := ret.Get(0).(func(model.PayrollPeriod, string, money.Money, money.Money) *model.TaxCalculation); ok {
+		r0 = rf(period, status, gross, deductible)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TaxCalculation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(model.PayrollPeriod, string, money.Money, money.Money) error); ok {
+		r1 = rf(period, status, gross, deductible)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewTaxCalculator interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTaxCalculator creates a new instance of TaxCalculator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTaxCalculator(t mockConstructorTestingTNewTaxCalculator) *TaxCalculator {
+	mock := &TaxCalculator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
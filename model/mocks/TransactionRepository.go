@@ -40,6 +40,32 @@ func (_m *TransactionRepository) Fetch(ctx context.Context, limit int, offset in
 	return r0, r1
 }
 
+// FetchByUserID provides a mock function with given fields: ctx, userID, limit, offset
+func (_m *TransactionRepository) FetchByUserID(ctx context.Context, userID int, limit int, offset int) ([]*model.Transaction, error) {
+	ret := _m.Called(ctx, userID, limit, offset)
+
+	var r0 []*model.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) ([]*model.Transaction, error)); ok {
+		return rf(ctx, userID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) []*model.Transaction); ok {
+		r0 = rf(ctx, userID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, userID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewTransactionRepository interface {
 	mock.TestingT
 	Cleanup(func())
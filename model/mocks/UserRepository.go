@@ -5,6 +5,7 @@ package mocks
 import (
 	context "context"
 	model "self-payrol/model"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -80,6 +81,32 @@ func (_m *UserRepository) Fetch(ctx context.Context, limit int, offset int) ([]*
 	return r0, r1
 }
 
+// FetchPayable provides a mock function with given fields: ctx, since
+func (_m *UserRepository) FetchPayable(ctx context.Context, since time.Time) ([]*model.User, error) {
+	ret := _m.Called(ctx, since)
+
+	var r0 []*model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*model.User, error)); ok {
+		return rf(ctx, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*model.User); ok {
+		r0 = rf(ctx, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByID provides a mock function with given fields: ctx, id
 func (_m *UserRepository) FindByID(ctx context.Context, id int) (*model.User, error) {
 	ret := _m.Called(ctx, id)
@@ -0,0 +1,108 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UserSalaryRepository is an autogenerated mock type for the UserSalaryRepository type
+type UserSalaryRepository struct {
+	mock.Mock
+}
+
+// FetchByPositionID provides a mock function with given fields: ctx, positionID
+func (_m *UserSalaryRepository) FetchByPositionID(ctx context.Context, positionID int) ([]*model.UserSalary, error) {
+	ret := _m.Called(ctx, positionID)
+
+	var r0 []*model.UserSalary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.UserSalary, error)); ok {
+		return rf(ctx, positionID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.UserSalary); ok {
+		r0 = rf(ctx, positionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserSalary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, positionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchByUserID provides a mock function with given fields: ctx, userID
+func (_m *UserSalaryRepository) FetchByUserID(ctx context.Context, userID int) ([]*model.UserSalary, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []*model.UserSalary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.UserSalary, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.UserSalary); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserSalary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Upsert provides a mock function with given fields: ctx, salary
+func (_m *UserSalaryRepository) Upsert(ctx context.Context, salary *model.UserSalary) (*model.UserSalary, error) {
+	ret := _m.Called(ctx, salary)
+
+	var r0 *model.UserSalary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UserSalary) (*model.UserSalary, error)); ok {
+		return rf(ctx, salary)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UserSalary) *model.UserSalary); ok {
+		r0 = rf(ctx, salary)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserSalary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *model.UserSalary) error); ok {
+		r1 = rf(ctx, salary)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewUserSalaryRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUserSalaryRepository creates a new instance of UserSalaryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUserSalaryRepository(t mockConstructorTestingTNewUserSalaryRepository) *UserSalaryRepository {
+	mock := &UserSalaryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
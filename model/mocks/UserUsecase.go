@@ -5,10 +5,9 @@ package mocks
 import (
 	context "context"
 	model "self-payrol/model"
+	request "self-payrol/request"
 
 	mock "github.com/stretchr/testify/mock"
-
-	request "self-payrol/request"
 )
 
 // UserUsecase is an autogenerated mock type for the UserUsecase type
@@ -56,6 +55,58 @@ func (_m *UserUsecase) EditUser(ctx context.Context, id int, req *request.UserRe
 	return r0, r1
 }
 
+// FetchSalaryHistory provides a mock function with given fields: ctx, id
+func (_m *UserUsecase) FetchSalaryHistory(ctx context.Context, id int) ([]*model.UserSalary, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []*model.UserSalary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*model.UserSalary, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*model.UserSalary); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserSalary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchTransactions provides a mock function with given fields: ctx, id, limit, offset
+func (_m *UserUsecase) FetchTransactions(ctx context.Context, id int, limit int, offset int) ([]*model.Transaction, error) {
+	ret := _m.Called(ctx, id, limit, offset)
+
+	var r0 []*model.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) ([]*model.Transaction, error)); ok {
+		return rf(ctx, id, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) []*model.Transaction); ok {
+		r0 = rf(ctx, id, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = rf(ctx, id, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FetchUser provides a mock function with given fields: ctx, limit, offset
 func (_m *UserUsecase) FetchUser(ctx context.Context, limit int, offset int) ([]*model.User, error) {
 	ret := _m.Called(ctx, limit, offset)
@@ -108,6 +159,32 @@ func (_m *UserUsecase) GetByID(ctx context.Context, id int) (*model.User, error)
 	return r0, r1
 }
 
+// SetSalary provides a mock function with given fields: ctx, id, req
+func (_m *UserUsecase) SetSalary(ctx context.Context, id int, req *request.UserSalaryRequest) (*model.UserSalary, error) {
+	ret := _m.Called(ctx, id, req)
+
+	var r0 *model.UserSalary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.UserSalaryRequest) (*model.UserSalary, error)); ok {
+		return rf(ctx, id, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *request.UserSalaryRequest) *model.UserSalary); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UserSalary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *request.UserSalaryRequest) error); ok {
+		r1 = rf(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // StoreUser provides a mock function with given fields: ctx, req
 func (_m *UserUsecase) StoreUser(ctx context.Context, req *request.UserRequest) (*model.User, error) {
 	ret := _m.Called(ctx, req)
@@ -134,18 +211,56 @@ func (_m *UserUsecase) StoreUser(ctx context.Context, req *request.UserRequest)
 	return r0, r1
 }
 
+// TaxPreview provides a mock function with given fields: ctx, id, period
+func (_m *UserUsecase) TaxPreview(ctx context.Context, id int, period string) (*model.PayBreakdown, error) {
+	ret := _m.Called(ctx, id, period)
+
+	var r0 *model.PayBreakdown
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (*model.PayBreakdown, error)); ok {
+		return rf(ctx, id, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) *model.PayBreakdown); ok {
+		r0 = rf(ctx, id, period)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PayBreakdown)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, id, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // WithdrawSalary provides a mock function with given fields: ctx, req
-func (_m *UserUsecase) WithdrawSalary(ctx context.Context, req *request.WithdrawRequest) error {
+func (_m *UserUsecase) WithdrawSalary(ctx context.Context, req *request.WithdrawRequest) (*model.WithdrawalSummary, error) {
 	ret := _m.Called(ctx, req)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *request.WithdrawRequest) error); ok {
+	var r0 *model.WithdrawalSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *request.WithdrawRequest) (*model.WithdrawalSummary, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *request.WithdrawRequest) *model.WithdrawalSummary); ok {
 		r0 = rf(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.WithdrawalSummary)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, *request.WithdrawRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 type mockConstructorTestingTNewUserUsecase interface {
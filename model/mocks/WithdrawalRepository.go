@@ -0,0 +1,108 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "self-payrol/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// WithdrawalRepository is an autogenerated mock type for the WithdrawalRepository type
+type WithdrawalRepository struct {
+	mock.Mock
+}
+
+// FetchByPeriod provides a mock function with given fields: ctx, period
+func (_m *WithdrawalRepository) FetchByPeriod(ctx context.Context, period string) ([]*model.Withdrawal, error) {
+	ret := _m.Called(ctx, period)
+
+	var r0 []*model.Withdrawal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*model.Withdrawal, error)); ok {
+		return rf(ctx, period)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.Withdrawal); ok {
+		r0 = rf(ctx, period)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Withdrawal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Lock provides a mock function with given fields: ctx, userID, period, currency
+func (_m *WithdrawalRepository) Lock(ctx context.Context, userID int, period string, currency string) (*model.Withdrawal, error) {
+	ret := _m.Called(ctx, userID, period, currency)
+
+	var r0 *model.Withdrawal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) (*model.Withdrawal, error)); ok {
+		return rf(ctx, userID, period, currency)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, string) *model.Withdrawal); ok {
+		r0 = rf(ctx, userID, period, currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Withdrawal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, string) error); ok {
+		r1 = rf(ctx, userID, period, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateByID provides a mock function with given fields: ctx, id, withdrawal
+func (_m *WithdrawalRepository) UpdateByID(ctx context.Context, id int, withdrawal *model.Withdrawal) (*model.Withdrawal, error) {
+	ret := _m.Called(ctx, id, withdrawal)
+
+	var r0 *model.Withdrawal
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.Withdrawal) (*model.Withdrawal, error)); ok {
+		return rf(ctx, id, withdrawal)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, *model.Withdrawal) *model.Withdrawal); ok {
+		r0 = rf(ctx, id, withdrawal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Withdrawal)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, *model.Withdrawal) error); ok {
+		r1 = rf(ctx, id, withdrawal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewWithdrawalRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewWithdrawalRepository creates a new instance of WithdrawalRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewWithdrawalRepository(t mockConstructorTestingTNewWithdrawalRepository) *WithdrawalRepository {
+	mock := &WithdrawalRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"context"
+	"self-payrol/money"
+)
+
+type (
+	Attachment struct {
+		Filename    string
+		ContentType string
+		Content     []byte
+	}
+
+	Email struct {
+		To          []string
+		Subject     string
+		Body        string
+		Attachments []Attachment
+	}
+
+	Mailer interface {
+		Send(ctx context.Context, email *Email) error
+	}
+
+	// Notifier tells people about money that has moved. It is called after
+	// the database transaction commits and never fails the caller, delivery
+	// problems are logged instead.
+	Notifier interface {
+		SalaryWithdrawn(ctx context.Context, user *User, payslip *Payslip)
+		BalanceToppedUp(ctx context.Context, company *Company, amount money.Money)
+	}
+)
@@ -0,0 +1,136 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"self-payrol/money"
+	"self-payrol/request"
+	"time"
+)
+
+const (
+	OvertimeStatusPending  = "pending"
+	OvertimeStatusApproved = "approved"
+	OvertimeStatusRejected = "rejected"
+
+	PayCodeOvertime = "overtime"
+
+	// OvertimeHoursPerMonth divides the monthly salary into the hourly rate
+	// overtime is paid at.
+	OvertimeHoursPerMonth = 173
+)
+
+var (
+	ErrOvertimeNotPending     = errors.New("overtime has already been reviewed")
+	ErrOvertimePaid           = errors.New("overtime has already been paid")
+	ErrOvertimeExceedsLimit   = errors.New("overtime exceeds the daily limit")
+	ErrOvertimeInvalidMinutes = errors.New("overtime must be at least one minute")
+)
+
+type (
+	// Overtime is one day of overtime of an employee. It is priced when it
+	// is recorded and paid by the first withdrawal that settles a period
+	// after it has been approved.
+	Overtime struct {
+		ID      int       `json:"id"`
+		UserID  int       `json:"user_id" gorm:"index"`
+		User    *User     `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+		Date    time.Time `json:"date" gorm:"type:date"`
+		Minutes int       `json:"minutes"`
+		// RestDay prices the hours at the rest day and public holiday
+		// multipliers, weekends are always rest days.
+		RestDay           bool         `json:"rest_day"`
+		HourlyRate        money.Money  `json:"hourly_rate" gorm:"embedded;embeddedPrefix:hourly_rate_"`
+		Amount            money.Money  `json:"amount" gorm:"embedded"`
+		Status            string       `json:"status" gorm:"default:pending"`
+		Note              string       `json:"note"`
+		ReviewedAt        *time.Time   `json:"reviewed_at"`
+		PaidTransactionID *int         `json:"paid_transaction_id"`
+		PaidTransaction   *Transaction `json:"-" gorm:"constraint:OnDelete:SET NULL;"`
+		CreatedAt         time.Time    `json:"created_at"`
+		UpdatedAt         time.Time    `json:"updated_at"`
+	}
+
+	OvertimeRepository interface {
+		Create(ctx context.Context, overtime *Overtime) (*Overtime, error)
+		FindByID(ctx context.Context, userID, id int) (*Overtime, error)
+		UpdateByID(ctx context.Context, id int, overtime *Overtime) (*Overtime, error)
+		Delete(ctx context.Context, userID, id int) error
+		FetchByUserID(ctx context.Context, userID int) ([]*Overtime, error)
+		// FetchPayable returns approved, unpaid overtime dated before before.
+		FetchPayable(ctx context.Context, userID int, before time.Time) ([]*Overtime, error)
+		// FetchPaidIn returns the overtime paid by the user's salary
+		// withdrawals of period.
+		FetchPaidIn(ctx context.Context, userID int, period string) ([]*Overtime, error)
+		MarkPaid(ctx context.Context, ids []int, transactionID int) error
+	}
+
+	OvertimeUsecase interface {
+		GetByID(ctx context.Context, userID, id int) (*Overtime, error)
+		FetchOvertime(ctx context.Context, userID int) ([]*Overtime, error)
+		StoreOvertime(ctx context.Context, userID int, req *request.OvertimeRequest) (*Overtime, error)
+		ApproveOvertime(ctx context.Context, userID, id int) (*Overtime, error)
+		RejectOvertime(ctx context.Context, userID, id int) (*Overtime, error)
+		DestroyOvertime(ctx context.Context, userID, id int) error
+	}
+)
+
+type overtimeTier struct {
+	minutes int // 0 means the rest of the hours
+	rate    int // multiplier in tenths
+}
+
+var (
+	// on a working day the first hour is paid 1.5x and the rest 2x, at
+	// most 4 hours a day
+	workdayOvertime = []overtimeTier{{60, 15}, {0, 20}}
+	// on a rest day or public holiday of a 5-day week the first 8 hours are
+	// paid 2x, the 9th 3x and the 10th to 12th 4x
+	restDayOvertime = []overtimeTier{{8 * 60, 20}, {60, 30}, {0, 40}}
+
+	workdayOvertimeLimit = 4 * 60
+	restDayOvertimeLimit = 12 * 60
+)
+
+// OvertimePay prices minutes of overtime for an employee on a monthly
+// salary, returning the hourly rate and the amount to pay.
+func OvertimePay(salary money.Money, minutes int, restDay bool) (money.Money, money.Money, error) {
+	tiers, limit := workdayOvertime, workdayOvertimeLimit
+	if restDay {
+		tiers, limit = restDayOvertime, restDayOvertimeLimit
+	}
+
+	if minutes <= 0 {
+		return money.Money{}, money.Money{}, ErrOvertimeInvalidMinutes
+	}
+	if minutes > limit {
+		return money.Money{}, money.Money{}, ErrOvertimeExceedsLimit
+	}
+
+	weighted, left := 0, minutes
+	for _, tier := range tiers {
+		n := left
+		if tier.minutes > 0 && n > tier.minutes {
+			n = tier.minutes
+		}
+		weighted += n * tier.rate
+		left -= n
+	}
+
+	hourlyRate, err := salary.Prorate(1, OvertimeHoursPerMonth)
+	if err != nil {
+		return money.Money{}, money.Money{}, err
+	}
+
+	amount, err := salary.Prorate(int64(weighted), OvertimeHoursPerMonth*60*10)
+	if err != nil {
+		return money.Money{}, money.Money{}, err
+	}
+
+	return hourlyRate, amount, nil
+}
+
+// IsRestDay reports whether date falls on a weekend.
+func IsRestDay(date time.Time) bool {
+	return date.Weekday() == time.Saturday || date.Weekday() == time.Sunday
+}
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"context"
+	"self-payrol/money"
+)
+
+const (
+	PayLineEarning   = "earning"
+	PayLineDeduction = "deduction"
+	// PayLineEmployer lines are paid by the company on the employee's
+	// behalf and are left out of Net.
+	PayLineEmployer = "employer"
+
+	PayCodeBaseSalary      = "base_salary"
+	PayCodeAllowance       = "allowance"
+	PayCodeDeduction       = "deduction"
+	PayCodeAdvance         = "advance"
+	PayCodeAdvanceRecovery = "advance_recovery"
+	// PayCodeBPJS prefixes the program, e.g. bpjs_jht.
+	PayCodeBPJS = "bpjs_"
+)
+
+type (
+	// PayLine is one item of a pay breakdown. SourceType and SourceID point
+	// at the record the line was derived from, if any.
+	PayLine struct {
+		Code       string      `json:"code"`
+		Name       string      `json:"name"`
+		Kind       string      `json:"kind"`
+		Amount     money.Money `json:"amount"`
+		Taxable    bool        `json:"taxable"`
+		SourceType string      `json:"source_type,omitempty"`
+		SourceID   *int        `json:"source_id,omitempty"`
+		// Settled lines were paid by an earlier withdrawal of the period,
+		// they are kept so that settling it again adds up.
+		Settled bool `json:"settled,omitempty"`
+		// SourceAmount is what a loan installment comes to in the currency
+		// of the loan, when that is not the pay currency.
+		SourceAmount money.Money `json:"-"`
+	}
+
+	// PayBreakdown is what an employee earns in one payroll period before
+	// anything has been withdrawn, in the currency they are paid in.
+	PayBreakdown struct {
+		UserID     int         `json:"user_id"`
+		Period     string      `json:"period"`
+		Lines      []PayLine   `json:"lines"`
+		Gross      money.Money `json:"gross"`
+		Deductions money.Money `json:"deductions"`
+		Net        money.Money `json:"net"`
+		// EmployerCost is what the company pays on top of Net, it is not
+		// part of the employee's pay.
+		EmployerCost  money.Money         `json:"employer_cost"`
+		Contributions []*BPJSContribution `json:"contributions,omitempty"`
+		// Tax explains the PPh 21 line, it is worked out on monthly figures
+		// even when the period is half a month, and in the currency of the
+		// balance.
+		Tax *TaxCalculation `json:"tax,omitempty"`
+		// Proration is set when the employee joined or left during the
+		// period and their salary and components were cut down.
+		Proration *Proration `json:"proration,omitempty"`
+	}
+
+	PayCalculator interface {
+		Calculate(ctx context.Context, user *User, period PayrollPeriod) (*PayBreakdown, error)
+		// Settle records what a breakdown leaves behind once it is paid out
+		// in full by trx, such as the contributions owed to BPJS.
+		Settle(ctx context.Context, breakdown *PayBreakdown, trx *Transaction) error
+	}
+)
+
+// NewPayBreakdown starts the breakdown of a period with its totals at zero
+// in currency.
+func NewPayBreakdown(userID int, period, currency string) *PayBreakdown {
+	zero := money.New(0, currency)
+	return &PayBreakdown{UserID: userID, Period: period, Gross: zero, Deductions: zero, Net: zero, EmployerCost: zero}
+}
+
+// Add appends line and keeps the totals in step. A line in another
+// currency than the totals is refused.
+func (b *PayBreakdown) Add(line PayLine) error {
+	gross, deductions, employerCost := b.Gross, b.Deductions, b.EmployerCost
+
+	var err error
+	switch line.Kind {
+	case PayLineEarning:
+		gross, err = gross.Add(line.Amount)
+	case PayLineDeduction:
+		deductions, err = deductions.Add(line.Amount)
+	case PayLineEmployer:
+		employerCost, err = employerCost.Add(line.Amount)
+	}
+	if err != nil {
+		return err
+	}
+
+	net, err := gross.Sub(deductions)
+	if err != nil {
+		return err
+	}
+
+	b.Lines = append(b.Lines, line)
+	b.Gross, b.Deductions, b.EmployerCost, b.Net = gross, deductions, employerCost, net
+	return nil
+}
+
+// TaxableGross sums the taxable earnings and the taxable benefits paid by
+// the employer.
+func (b *PayBreakdown) TaxableGross() (money.Money, error) {
+	total := money.New(0, b.Gross.Currency)
+	for _, line := range b.Lines {
+		if line.Kind != PayLineDeduction && line.Taxable {
+			var err error
+			if total, err = total.Add(line.Amount); err != nil {
+				return money.Money{}, err
+			}
+		}
+	}
+
+	return total, nil
+}
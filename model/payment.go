@@ -0,0 +1,82 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"self-payrol/money"
+	"self-payrol/request"
+	"time"
+)
+
+const (
+	PaymentStatusPending  = "pending"
+	PaymentStatusApproved = "approved"
+	PaymentStatusRejected = "rejected"
+	PaymentStatusPaid     = "paid"
+
+	// PaymentWithSalary payments are added to the withdrawal that settles
+	// their due period, PaymentSeparate ones are disbursed on their own.
+	PaymentWithSalary = "salary"
+	PaymentSeparate   = "separate"
+
+	PayCodePayment = "payment"
+)
+
+var (
+	ErrPaymentNotPending     = errors.New("payment has already been reviewed")
+	ErrPaymentNotApproved    = errors.New("payment is not approved")
+	ErrPaymentPaid           = errors.New("payment has already been paid")
+	ErrPaymentPaidWithSalary = errors.New("payment is paid with salary")
+)
+
+type (
+	// Payment is a one-off amount such as a bonus or incentive, paid on top
+	// of salary once approved.
+	Payment struct {
+		ID     int         `json:"id"`
+		UserID int         `json:"user_id" gorm:"index"`
+		User   *User       `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+		Amount money.Money `json:"amount" gorm:"embedded"`
+		Reason string      `json:"reason"`
+		// Period is the payroll period the payment is due in, DueFrom its
+		// start so payable payments can be found by date.
+		Period            string       `json:"period"`
+		DueFrom           time.Time    `json:"due_from" gorm:"index"`
+		Taxable           bool         `json:"taxable"`
+		Disbursement      string       `json:"disbursement" gorm:"default:salary"`
+		Status            string       `json:"status" gorm:"default:pending"`
+		ReviewedAt        *time.Time   `json:"reviewed_at"`
+		PaidTransactionID *int         `json:"paid_transaction_id"`
+		PaidTransaction   *Transaction `json:"-" gorm:"constraint:OnDelete:SET NULL;"`
+		CreatedAt         time.Time    `json:"created_at"`
+		UpdatedAt         time.Time    `json:"updated_at"`
+	}
+
+	PaymentRepository interface {
+		Create(ctx context.Context, payment *Payment) (*Payment, error)
+		FindByID(ctx context.Context, userID, id int) (*Payment, error)
+		// Lock returns the payment and holds a row lock until the
+		// transaction ends.
+		Lock(ctx context.Context, userID, id int) (*Payment, error)
+		UpdateByID(ctx context.Context, id int, payment *Payment) (*Payment, error)
+		Delete(ctx context.Context, userID, id int) error
+		FetchByUserID(ctx context.Context, userID int) ([]*Payment, error)
+		// FetchPayable returns approved, unpaid payments to be paid with
+		// salary that are due before before.
+		FetchPayable(ctx context.Context, userID int, before time.Time) ([]*Payment, error)
+		// FetchPaidIn returns the payments paid by the user's salary
+		// withdrawals of period.
+		FetchPaidIn(ctx context.Context, userID int, period string) ([]*Payment, error)
+		MarkPaid(ctx context.Context, ids []int, transactionID int) error
+	}
+
+	PaymentUsecase interface {
+		GetByID(ctx context.Context, userID, id int) (*Payment, error)
+		FetchPayment(ctx context.Context, userID int) ([]*Payment, error)
+		StorePayment(ctx context.Context, userID int, req *request.PaymentRequest) (*Payment, error)
+		ApprovePayment(ctx context.Context, userID, id int) (*Payment, error)
+		RejectPayment(ctx context.Context, userID, id int) (*Payment, error)
+		DisbursePayment(ctx context.Context, userID, id int) (*Transaction, error)
+		DestroyPayment(ctx context.Context, userID, id int) error
+	}
+)
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"context"
+	"self-payrol/money"
+)
+
+type (
+	// PayrollPreview compares what a period costs the company against its
+	// balance. Liability is what the balance has to cover: the net pay of
+	// payable employees not withdrawn yet plus the employer BPJS
+	// contributions of the period, which are paid out of the balance too.
+	// Shortfall is the top-up needed to cover it. Employees whose position
+	// was removed are counted under an "Unassigned" position with ID 0.
+	PayrollPreview struct {
+		Period       string               `json:"period"`
+		Headcount    int                  `json:"headcount"`
+		Gross        money.Money          `json:"gross"`
+		Net          money.Money          `json:"net"`
+		EmployerCost money.Money          `json:"employer_cost"`
+		Withdrawn    money.Money          `json:"withdrawn"`
+		Liability    money.Money          `json:"liability"`
+		Balance      money.Money          `json:"balance"`
+		Shortfall    money.Money          `json:"shortfall"`
+		Positions    []*PositionLiability `json:"positions"`
+	}
+
+	// PositionLiability is the share of one position in a PayrollPreview.
+	PositionLiability struct {
+		PositionID   int         `json:"position_id"`
+		Name         string      `json:"name"`
+		Headcount    int         `json:"headcount"`
+		Gross        money.Money `json:"gross"`
+		Net          money.Money `json:"net"`
+		EmployerCost money.Money `json:"employer_cost"`
+		Withdrawn    money.Money `json:"withdrawn"`
+		Liability    money.Money `json:"liability"`
+	}
+
+	PayrollUsecase interface {
+		// Preview works out the liability of a period, the current one
+		// when period is empty, without paying or recording anything.
+		Preview(ctx context.Context, period string) (*PayrollPreview, error)
+	}
+)
+
+// UnassignedPosition names the share of employees without a position.
+const UnassignedPosition = "Unassigned"
+
+// Add counts the pay of one employee towards the position.
+func (l *PositionLiability) Add(breakdown *PayBreakdown, withdrawn money.Money) error {
+	gross, err := l.Gross.Add(breakdown.Gross)
+	if err != nil {
+		return err
+	}
+	net, err := l.Net.Add(breakdown.Net)
+	if err != nil {
+		return err
+	}
+	employerCost, err := l.EmployerCost.Add(breakdown.EmployerCost)
+	if err != nil {
+		return err
+	}
+	total, err := l.Withdrawn.Add(withdrawn)
+	if err != nil {
+		return err
+	}
+	owed, err := breakdown.Net.Sub(withdrawn)
+	if err != nil {
+		return err
+	}
+
+	liability, err := l.Liability.Add(breakdown.EmployerCost)
+	if err != nil {
+		return err
+	}
+	if owed.Amount > 0 {
+		if liability, err = liability.Add(owed); err != nil {
+			return err
+		}
+	}
+
+	l.Headcount++
+	l.Gross, l.Net, l.EmployerCost, l.Withdrawn, l.Liability = gross, net, employerCost, total, liability
+	return nil
+}
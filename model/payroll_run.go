@@ -0,0 +1,165 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"self-payrol/money"
+	"self-payrol/request"
+	"time"
+)
+
+const (
+	PayrollRunScheduled = "scheduled"
+	PayrollRunManual    = "manual"
+
+	// PayrollRunScheduler is the actor of the drafts made on schedule.
+	PayrollRunScheduler = "scheduler"
+
+	PayrollRunDraft     = "draft"
+	PayrollRunSubmitted = "submitted"
+	PayrollRunApproved  = "approved"
+	PayrollRunDisbursed = "disbursed"
+	PayrollRunRejected  = "rejected"
+
+	PayrollItemPending  = "pending"
+	PayrollItemExcluded = "excluded"
+	PayrollItemSkipped  = "skipped"
+	PayrollItemPaid     = "paid"
+)
+
+var (
+	ErrPayrollRunNotDraft          = errors.New("payroll run can only be edited as a draft")
+	ErrInvalidPayrollRunTransition = errors.New("payroll run cannot move to that status")
+	ErrPayrollRunSelfApproval      = errors.New("payroll run must be approved by an admin who did not prepare it")
+	ErrPayrollItemNotPayable       = errors.New("employee is not paid by this payroll run")
+	ErrPayrollItemExceedsSalary    = errors.New("amount exceeds the salary left to pay")
+)
+
+// payrollRunTransitions lists where each status of a run can move to.
+// Disbursed and rejected runs are final.
+var payrollRunTransitions = map[string][]string{
+	PayrollRunDraft:     {PayrollRunSubmitted, PayrollRunRejected},
+	PayrollRunSubmitted: {PayrollRunApproved, PayrollRunRejected},
+	PayrollRunApproved:  {PayrollRunDisbursed, PayrollRunRejected},
+}
+
+type (
+	// PayrollRun pays the salary of one period to every active employee at
+	// once. It is drafted with the calculated pay of each employee, which
+	// can be edited until the draft is submitted for review. Once approved
+	// by another admin the whole run is disbursed in a single transaction
+	// through the same withdrawal as WithdrawSalary.
+	PayrollRun struct {
+		ID      int    `json:"id"`
+		Period  string `json:"period" gorm:"index"`
+		Trigger string `json:"trigger"`
+		Status  string `json:"status" gorm:"default:draft"`
+		// CreatedBy and SubmittedBy prepared the run, neither of them can
+		// approve it.
+		CreatedBy   string `json:"created_by"`
+		SubmittedBy string `json:"submitted_by"`
+		ApprovedBy  string `json:"approved_by"`
+		// Total is what the pending items take out of the balance, at the
+		// rates in effect when they were last counted for employees paid in
+		// another currency, or what was debited once the run is disbursed.
+		Total       money.Money        `json:"total" gorm:"embedded;embeddedPrefix:total_"`
+		Paid        int                `json:"paid"`
+		Skipped     int                `json:"skipped"`
+		DisbursedAt *time.Time         `json:"disbursed_at"`
+		Items       []*PayrollRunItem  `json:"items,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+		Events      []*PayrollRunEvent `json:"events,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+		CreatedAt   time.Time          `json:"created_at"`
+		UpdatedAt   time.Time          `json:"updated_at"`
+	}
+
+	// PayrollRunItem is what a run pays one employee. Salary is the net pay
+	// left to pay when the run was drafted, Amount what the run will pay of
+	// it. Reason explains an excluded or skipped employee.
+	PayrollRunItem struct {
+		ID            int         `json:"id"`
+		PayrollRunID  int         `json:"payroll_run_id" gorm:"index"`
+		UserID        int         `json:"user_id"`
+		User          *User       `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+		Salary        money.Money `json:"salary" gorm:"embedded;embeddedPrefix:salary_"`
+		Amount        money.Money `json:"amount" gorm:"embedded"`
+		Status        string      `json:"status"`
+		TransactionID *int        `json:"transaction_id"`
+		Payslip       string      `json:"payslip,omitempty"`
+		Reason        string      `json:"reason,omitempty"`
+		// Conversion is what the payment of an employee paid in another
+		// currency than the company balance was debited.
+		Conversion *Conversion `json:"conversion,omitempty" gorm:"serializer:json"`
+		// BankCode, BankAccountNumber and BankAccountName are the account
+		// of the employee when the item was paid, bank files are made from
+		// them so later changes to the employee do not rewrite the run.
+		BankCode          string `json:"bank_code,omitempty"`
+		BankAccountNumber string `json:"bank_account_number,omitempty"`
+		BankAccountName   string `json:"bank_account_name,omitempty"`
+	}
+
+	// PayrollRunEvent records who moved a run to another status and when.
+	PayrollRunEvent struct {
+		ID           int       `json:"id"`
+		PayrollRunID int       `json:"payroll_run_id" gorm:"index"`
+		From         string    `json:"from"`
+		To           string    `json:"to"`
+		Actor        string    `json:"actor"`
+		Note         string    `json:"note"`
+		CreatedAt    time.Time `json:"created_at"`
+	}
+
+	PayrollRunRepository interface {
+		// Create stores the run with its items.
+		Create(ctx context.Context, run *PayrollRun) (*PayrollRun, error)
+		UpdateByID(ctx context.Context, id int, run *PayrollRun) (*PayrollRun, error)
+		UpdateItem(ctx context.Context, item *PayrollRunItem) (*PayrollRunItem, error)
+		CreateEvent(ctx context.Context, event *PayrollRunEvent) (*PayrollRunEvent, error)
+		// FindByID returns the run with its items and events.
+		FindByID(ctx context.Context, id int) (*PayrollRun, error)
+		// Lock returns the run with its items and holds a row lock on the
+		// run until the transaction ends.
+		Lock(ctx context.Context, id int) (*PayrollRun, error)
+		// Fetch returns the runs newest first, without items and events.
+		Fetch(ctx context.Context, limit, offset int) ([]*PayrollRun, error)
+	}
+
+	PayrollRunUsecase interface {
+		// Draft calculates the current period for every active employee.
+		// Employees who have withdrawn any of it already are left to
+		// withdraw the rest themselves.
+		Draft(ctx context.Context, trigger string, req *request.PayrollRunActionRequest) (*PayrollRun, error)
+		GetByID(ctx context.Context, id int) (*PayrollRun, error)
+		FetchPayrollRuns(ctx context.Context, limit, offset int) ([]*PayrollRun, error)
+		// EditItem changes what a draft pays an employee, zero leaves them
+		// out of the run.
+		EditItem(ctx context.Context, id, itemID int, req *request.PayrollRunItemRequest) (*PayrollRun, error)
+		Submit(ctx context.Context, id int, req *request.PayrollRunActionRequest) (*PayrollRun, error)
+		Approve(ctx context.Context, id int, req *request.PayrollRunActionRequest) (*PayrollRun, error)
+		Reject(ctx context.Context, id int, req *request.PayrollRunActionRequest) (*PayrollRun, error)
+		// Disburse pays every pending item of an approved run, all of them
+		// or none.
+		Disburse(ctx context.Context, id int, req *request.PayrollRunActionRequest) (*PayrollRun, error)
+		// BankFile lays the payouts in currency of a disbursed run out as a
+		// bank bulk transfer file, an empty currency is the one of the
+		// company balance.
+		BankFile(ctx context.Context, id int, format, currency string) (*BankFile, error)
+	}
+)
+
+// CanMovePayrollRun reports whether a run can go from one status to the
+// other.
+func CanMovePayrollRun(from, to string) bool {
+	for _, next := range payrollRunTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// PreparedBy reports whether actor drafted or submitted the run. Actors are
+// the names sent with each request, not authenticated identities, so this
+// only keeps an admin from approving their own run by mistake.
+func (r *PayrollRun) PreparedBy(actor string) bool {
+	return actor == r.CreatedBy || actor == r.SubmittedBy
+}
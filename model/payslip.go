@@ -0,0 +1,68 @@
+package model
+
+import (
+	"context"
+	"self-payrol/money"
+	"time"
+)
+
+type (
+	// Payslip is issued for every salary withdrawal. Data is a snapshot of
+	// everything printed on it, so the PDF renders the same however the
+	// employee, position or company change afterwards.
+	Payslip struct {
+		ID            int         `json:"id"`
+		UserID        int         `json:"user_id" gorm:"index:idx_payslips_user_period"`
+		Period        string      `json:"period" gorm:"index:idx_payslips_user_period"`
+		TransactionID int         `json:"transaction_id" gorm:"uniqueIndex"`
+		Number        string      `json:"number"`
+		Data          PayslipData `json:"data" gorm:"serializer:json"`
+		CreatedAt     time.Time   `json:"created_at"`
+	}
+
+	PayslipData struct {
+		CompanyName    string      `json:"company_name"`
+		CompanyAddress string      `json:"company_address"`
+		EmployeeID     int         `json:"employee_id"`
+		EmployeeName   string      `json:"employee_name"`
+		EmployeeEmail  string      `json:"employee_email"`
+		PositionName   string      `json:"position_name"`
+		Period         string      `json:"period"`
+		PeriodStart    time.Time   `json:"period_start"`
+		PeriodEnd      time.Time   `json:"period_end"`
+		IssuedAt       time.Time   `json:"issued_at"`
+		Lines          []PayLine   `json:"lines"`
+		Gross          money.Money `json:"gross"`
+		Deductions     money.Money `json:"deductions"`
+		Net            money.Money `json:"net"`
+		EmployerCost   money.Money `json:"employer_cost"`
+		// Amount is what this withdrawal paid, Withdrawn and Remaining are
+		// the period totals after it, all in the currency the employee is
+		// paid in. Payslips issued before amounts kept their currency read
+		// back as rupiah.
+		Amount    money.Money `json:"amount"`
+		Withdrawn money.Money `json:"withdrawn"`
+		Remaining money.Money `json:"remaining"`
+		// Conversion is what the company balance was debited when the
+		// employee is paid in another currency.
+		Conversion *Conversion `json:"conversion,omitempty"`
+	}
+
+	PayslipRepository interface {
+		Create(ctx context.Context, payslip *Payslip) (*Payslip, error)
+		// FindLatest returns the payslip of the last withdrawal of the period.
+		FindLatest(ctx context.Context, userID int, period string) (*Payslip, error)
+		FetchByUserID(ctx context.Context, userID int) ([]*Payslip, error)
+	}
+
+	// PayslipRenderer turns a payslip into a PDF. The same payslip always
+	// renders to the same bytes.
+	PayslipRenderer interface {
+		Render(payslip *Payslip) ([]byte, error)
+	}
+
+	PayslipUsecase interface {
+		FetchPayslips(ctx context.Context, userID int) ([]*Payslip, error)
+		RenderPayslip(ctx context.Context, userID int, period string) (*Payslip, []byte, error)
+	}
+)
@@ -0,0 +1,206 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"self-payrol/money"
+	"strings"
+	"time"
+)
+
+const (
+	PayrollCycleMonthly     = "monthly"
+	PayrollCycleSemiMonthly = "semimonthly"
+
+	ProrationWorkingDays  = "working_days"
+	ProrationCalendarDays = "calendar_days"
+)
+
+var ErrInvalidPayrollPeriod = errors.New("payroll period not valid")
+
+type (
+	// PayrollPeriod is a half-open date range [Start, End) identified by Code,
+	// e.g. "2026-10" for a monthly cycle or "2026-10-2" for the second half of
+	// October on a semimonthly cycle.
+	PayrollPeriod struct {
+		Code  string    `json:"code"`
+		Start time.Time `json:"start"`
+		End   time.Time `json:"end"`
+	}
+
+	// Proration is the part of a period an employee was employed for, Days
+	// out of PeriodDays counted with Method.
+	Proration struct {
+		Method     string `json:"method"`
+		Days       int    `json:"days"`
+		PeriodDays int    `json:"period_days"`
+	}
+)
+
+// PayrollPeriodOf returns the period of the given cycle that contains t.
+// An unknown or empty cycle falls back to monthly.
+func PayrollPeriodOf(cycle string, t time.Time) PayrollPeriod {
+	monthStart := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	monthCode := monthStart.Format("2006-01")
+
+	if cycle == PayrollCycleSemiMonthly {
+		mid := monthStart.AddDate(0, 0, 15)
+		if t.Before(mid) {
+			return PayrollPeriod{Code: monthCode + "-1", Start: monthStart, End: mid}
+		}
+		return PayrollPeriod{Code: monthCode + "-2", Start: mid, End: monthStart.AddDate(0, 1, 0)}
+	}
+
+	return PayrollPeriod{Code: monthCode, Start: monthStart, End: monthStart.AddDate(0, 1, 0)}
+}
+
+// ParsePayrollPeriod turns a period code back into its date range.
+func ParsePayrollPeriod(code string, loc *time.Location) (PayrollPeriod, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	if len(code) == len("2006-01") {
+		t, err := time.ParseInLocation("2006-01", code, loc)
+		if err != nil {
+			return PayrollPeriod{}, ErrInvalidPayrollPeriod
+		}
+		return PayrollPeriodOf(PayrollCycleMonthly, t), nil
+	}
+
+	var year, month, half int
+	if _, err := fmt.Sscanf(code, "%04d-%02d-%d", &year, &month, &half); err != nil || month < 1 || month > 12 {
+		return PayrollPeriod{}, ErrInvalidPayrollPeriod
+	}
+
+	switch half {
+	case 1:
+		return PayrollPeriodOf(PayrollCycleSemiMonthly, time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)), nil
+	case 2:
+		return PayrollPeriodOf(PayrollCycleSemiMonthly, time.Date(year, time.Month(month), 16, 0, 0, 0, 0, loc)), nil
+	}
+
+	return PayrollPeriod{}, ErrInvalidPayrollPeriod
+}
+
+// Contains reports whether t falls inside the period.
+func (p PayrollPeriod) Contains(t time.Time) bool {
+	return !t.Before(p.Start) && t.Before(p.End)
+}
+
+// WorkingDays counts the Monday to Friday days in the period.
+func (p PayrollPeriod) WorkingDays() int {
+	return countWorkingDays(p.Start, p.End)
+}
+
+// WorkingDaysElapsed counts the working days from the start of the period up
+// to and including the day of t, capped at the period end.
+func (p PayrollPeriod) WorkingDaysElapsed(t time.Time) int {
+	until := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, p.Start.Location()).AddDate(0, 0, 1)
+	if until.After(p.End) {
+		until = p.End
+	}
+
+	return countWorkingDays(p.Start, until)
+}
+
+func countWorkingDays(from, to time.Time) int {
+	days := 0
+	for d := from; d.Before(to); d = d.AddDate(0, 0, 1) {
+		if d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			days++
+		}
+	}
+
+	return days
+}
+
+// Proration works out the part of the period between the employment start
+// and end dates, both inclusive and either optional, counting working days
+// unless method is ProrationCalendarDays. It is nil when the employee was
+// employed the whole period.
+func (p PayrollPeriod) Proration(method string, start, end *time.Time) *Proration {
+	from, to := p.Employed(start, end)
+	if from.Equal(p.Start) && to.Equal(p.End) {
+		return nil
+	}
+
+	count := countWorkingDays
+	if method == ProrationCalendarDays {
+		count = countDays
+	} else {
+		method = ProrationWorkingDays
+	}
+
+	proration := &Proration{Method: method, PeriodDays: count(p.Start, p.End)}
+	if from.Before(to) {
+		proration.Days = count(from, to)
+	}
+
+	return proration
+}
+
+// Employed narrows the period down to the days between the employment
+// start and end dates, both inclusive and either optional. The range is
+// empty when the employment does not reach into the period.
+func (p PayrollPeriod) Employed(start, end *time.Time) (time.Time, time.Time) {
+	from, to := p.Start, p.End
+	if start != nil {
+		if day := p.dayOf(*start); day.After(from) {
+			from = day
+		}
+	}
+	if end != nil {
+		if day := p.dayOf(*end).AddDate(0, 0, 1); day.Before(to) {
+			to = day
+		}
+	}
+
+	return from, to
+}
+
+// dayOf moves the date of t into the location of the period, dates read
+// back from the database come in UTC.
+func (p PayrollPeriod) dayOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, p.Start.Location())
+}
+
+func countDays(from, to time.Time) int {
+	days := 0
+	for d := from; d.Before(to); d = d.AddDate(0, 0, 1) {
+		days++
+	}
+
+	return days
+}
+
+// Apply cuts a full-period amount down to the days employed, a nil
+// proration leaves it as it is.
+func (r *Proration) Apply(amount money.Money) (money.Money, error) {
+	if r == nil || r.PeriodDays == 0 {
+		return amount, nil
+	}
+
+	return amount.Prorate(int64(r.Days), int64(r.PeriodDays))
+}
+
+// Halved reports a semimonthly period, which is paid a share of monthly
+// amounts.
+func (p PayrollPeriod) Halved() bool {
+	return len(p.Code) == len("2006-01-2")
+}
+
+// Share returns the part of a monthly amount that is paid in this period.
+// The two halves of a semimonthly month always add up to amount.
+func (p PayrollPeriod) Share(amount money.Money) money.Money {
+	if !p.Halved() {
+		return amount
+	}
+
+	if strings.HasSuffix(p.Code, "-1") {
+		amount.Amount /= 2
+		return amount
+	}
+	amount.Amount -= amount.Amount / 2
+	return amount
+}
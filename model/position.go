@@ -2,17 +2,35 @@ package model
 
 import (
 	"context"
+	"errors"
+	"self-payrol/money"
 	"self-payrol/request"
 	"time"
+
+	"gorm.io/gorm"
 )
 
+// ErrSalaryCurrency is returned for a salary that is not in the currency
+// the position, or the employee, is paid in.
+var ErrSalaryCurrency = errors.New("salary is not in the currency the employees are paid in")
+
 type (
 	Position struct {
-		ID        int       `json:"id"`
-		Name      string    `json:"name"`
-		Salary    int       `json:"salary"`
-		CreatedAt time.Time `json:"created_at"`
-		UpdatedAt time.Time `json:"updated_at"`
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+		// Salary is the salary in effect when the position was last edited,
+		// pay is worked out from the salary history instead, in the
+		// currency of the company balance.
+		Salary money.Money `json:"salary" gorm:"embedded;embeddedPrefix:salary_"`
+		// MinSalary and MaxSalary are the optional salary band employees'
+		// negotiated pay has to stay within, in the currency of Salary.
+		MinSalary *money.Money `json:"min_salary" gorm:"embedded;embeddedPrefix:min_salary_"`
+		MaxSalary *money.Money `json:"max_salary" gorm:"embedded;embeddedPrefix:max_salary_"`
+		// PayCurrency is the currency employees of the position are paid
+		// in, empty for the currency of the company balance.
+		PayCurrency string    `json:"pay_currency" gorm:"size:3"`
+		CreatedAt   time.Time `json:"created_at"`
+		UpdatedAt   time.Time `json:"updated_at"`
 	}
 
 	PositionRepository interface {
@@ -29,5 +47,31 @@ type (
 		DestroyPosition(ctx context.Context, id int) error
 		EditPosition(ctx context.Context, id int, req *request.PositionRequest) (*Position, error)
 		StorePosition(ctx context.Context, req *request.PositionRequest) (*Position, error)
+		FetchSalaryHistory(ctx context.Context, id int) ([]*PositionSalary, error)
 	}
 )
+
+// AfterFind reads a missing salary band back as nil, gorm fills it in from
+// its null columns.
+func (p *Position) AfterFind(*gorm.DB) error {
+	if p.MinSalary != nil && p.MinSalary.IsZero() {
+		p.MinSalary = nil
+	}
+	if p.MaxSalary != nil && p.MaxSalary.IsZero() {
+		p.MaxSalary = nil
+	}
+	return nil
+}
+
+// InBand reports whether salary fits the salary band of the position, a
+// position without one takes any salary. A salary in another currency than
+// the band does not fit it.
+func (p *Position) InBand(salary money.Money) bool {
+	if p.MinSalary != nil && (!salary.SameCurrency(*p.MinSalary) || salary.Amount < p.MinSalary.Amount) {
+		return false
+	}
+	if p.MaxSalary != nil && (!salary.SameCurrency(*p.MaxSalary) || salary.Amount > p.MaxSalary.Amount) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"context"
+	"self-payrol/money"
+	"self-payrol/request"
+	"time"
+)
+
+const (
+	PositionComponentAllowance = "allowance"
+	PositionComponentDeduction = "deduction"
+)
+
+type (
+	// PositionComponent is a recurring allowance or deduction paid to every
+	// employee of a position on top of the base salary.
+	PositionComponent struct {
+		ID         int         `json:"id"`
+		PositionID int         `json:"position_id" gorm:"index"`
+		Position   *Position   `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+		Name       string      `json:"name"`
+		Type       string      `json:"type"`
+		Amount     money.Money `json:"amount" gorm:"embedded"`
+		Taxable    bool        `json:"taxable"`
+		CreatedAt  time.Time   `json:"created_at"`
+		UpdatedAt  time.Time   `json:"updated_at"`
+	}
+
+	PositionComponentRepository interface {
+		Create(ctx context.Context, component *PositionComponent) (*PositionComponent, error)
+		UpdateByID(ctx context.Context, id int, component *PositionComponent) (*PositionComponent, error)
+		FindByID(ctx context.Context, positionID, id int) (*PositionComponent, error)
+		Delete(ctx context.Context, positionID, id int) error
+		FetchByPositionID(ctx context.Context, positionID int) ([]*PositionComponent, error)
+	}
+
+	PositionComponentUsecase interface {
+		GetByID(ctx context.Context, positionID, id int) (*PositionComponent, error)
+		FetchComponent(ctx context.Context, positionID int) ([]*PositionComponent, error)
+		DestroyComponent(ctx context.Context, positionID, id int) error
+		EditComponent(ctx context.Context, positionID, id int, req *request.PositionComponentRequest) (*PositionComponent, error)
+		StoreComponent(ctx context.Context, positionID int, req *request.PositionComponentRequest) (*PositionComponent, error)
+	}
+)
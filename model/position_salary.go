@@ -0,0 +1,47 @@
+package model
+
+import (
+	"context"
+	"self-payrol/money"
+	"time"
+)
+
+type (
+	// PositionSalary is the salary of a position from EffectiveFrom until
+	// the next row takes over.
+	PositionSalary struct {
+		ID            int         `json:"id"`
+		PositionID    int         `json:"position_id" gorm:"uniqueIndex:idx_position_salaries_position_effective"`
+		Position      *Position   `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+		Salary        money.Money `json:"salary" gorm:"embedded;embeddedPrefix:salary_"`
+		EffectiveFrom time.Time   `json:"effective_from" gorm:"type:date;uniqueIndex:idx_position_salaries_position_effective"`
+		CreatedAt     time.Time   `json:"created_at"`
+		UpdatedAt     time.Time   `json:"updated_at"`
+	}
+
+	PositionSalaryRepository interface {
+		// Upsert records salary from the row's EffectiveFrom, replacing a
+		// row already effective on that date.
+		Upsert(ctx context.Context, salary *PositionSalary) (*PositionSalary, error)
+		// FetchByPositionID returns the history newest first.
+		FetchByPositionID(ctx context.Context, positionID int) ([]*PositionSalary, error)
+	}
+)
+
+// SalaryOn picks the salary in effect on t from a history sorted newest
+// first. Before the first row the earliest known salary applies, and with
+// no history at all fallback does.
+func SalaryOn(history []*PositionSalary, t time.Time, fallback money.Money) money.Money {
+	if len(history) == 0 {
+		return fallback
+	}
+
+	day := t.Format("2006-01-02")
+	for _, row := range history {
+		if row.EffectiveFrom.Format("2006-01-02") <= day {
+			return row.Salary
+		}
+	}
+
+	return history[len(history)-1].Salary
+}
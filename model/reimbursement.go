@@ -0,0 +1,86 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"self-payrol/money"
+	"self-payrol/request"
+	"time"
+)
+
+const (
+	ReimbursementStatusPending  = "pending"
+	ReimbursementStatusApproved = "approved"
+	ReimbursementStatusRejected = "rejected"
+	ReimbursementStatusPaid     = "paid"
+
+	// ReimbursementWithSalary claims are added to the next salary
+	// withdrawal, ReimbursementImmediate ones are paid when approved.
+	ReimbursementWithSalary = "salary"
+	ReimbursementImmediate  = "immediate"
+
+	PayCodeReimbursement = "reimbursement"
+)
+
+var (
+	ErrReimbursementNotPending = errors.New("reimbursement claim has already been reviewed")
+	ErrReimbursementPaid       = errors.New("reimbursement claim has already been paid")
+)
+
+type (
+	// Reimbursement is an expense an employee paid out of pocket and claims
+	// back with a receipt. It is not income, so it is never taxed.
+	Reimbursement struct {
+		ID          int         `json:"id"`
+		UserID      int         `json:"user_id" gorm:"index"`
+		User        *User       `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+		Amount      money.Money `json:"amount" gorm:"embedded"`
+		Category    string      `json:"category"`
+		Description string      `json:"description"`
+		// Receipt is the uploaded file, it is only loaded to be downloaded.
+		ReceiptName string `json:"receipt_name"`
+		ReceiptType string `json:"receipt_type"`
+		Receipt     []byte `json:"-"`
+		// Disbursement is chosen when the claim is approved.
+		Disbursement      string       `json:"disbursement,omitempty"`
+		Status            string       `json:"status" gorm:"default:pending"`
+		ReviewedAt        *time.Time   `json:"reviewed_at"`
+		PaidTransactionID *int         `json:"paid_transaction_id"`
+		PaidTransaction   *Transaction `json:"-" gorm:"constraint:OnDelete:SET NULL;"`
+		CreatedAt         time.Time    `json:"created_at"`
+		UpdatedAt         time.Time    `json:"updated_at"`
+	}
+
+	ReimbursementRepository interface {
+		Create(ctx context.Context, claim *Reimbursement) (*Reimbursement, error)
+		// FindByID returns the claim without its receipt.
+		FindByID(ctx context.Context, userID, id int) (*Reimbursement, error)
+		// FindReceipt returns the claim with its receipt.
+		FindReceipt(ctx context.Context, userID, id int) (*Reimbursement, error)
+		// Lock returns the claim and holds a row lock until the transaction
+		// ends.
+		Lock(ctx context.Context, userID, id int) (*Reimbursement, error)
+		UpdateByID(ctx context.Context, id int, claim *Reimbursement) (*Reimbursement, error)
+		Delete(ctx context.Context, userID, id int) error
+		FetchByUserID(ctx context.Context, userID int) ([]*Reimbursement, error)
+		// FetchPayable returns approved, unpaid claims to be paid with
+		// salary that were approved before before.
+		FetchPayable(ctx context.Context, userID int, before time.Time) ([]*Reimbursement, error)
+		// FetchPaidIn returns the claims paid by the user's salary
+		// withdrawals of period.
+		FetchPaidIn(ctx context.Context, userID int, period string) ([]*Reimbursement, error)
+		MarkPaid(ctx context.Context, ids []int, transactionID int) error
+	}
+
+	ReimbursementUsecase interface {
+		GetByID(ctx context.Context, userID, id int) (*Reimbursement, error)
+		GetReceipt(ctx context.Context, userID, id int) (*Reimbursement, error)
+		FetchReimbursement(ctx context.Context, userID int) ([]*Reimbursement, error)
+		StoreReimbursement(ctx context.Context, userID int, req *request.ReimbursementRequest) (*Reimbursement, error)
+		// ApproveReimbursement pays the claim straight away out of the
+		// company balance when it is to be paid immediately.
+		ApproveReimbursement(ctx context.Context, userID, id int, req *request.ReimbursementReviewRequest) (*Reimbursement, error)
+		RejectReimbursement(ctx context.Context, userID, id int) (*Reimbursement, error)
+		DestroyReimbursement(ctx context.Context, userID, id int) error
+	}
+)
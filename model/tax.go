@@ -0,0 +1,36 @@
+package model
+
+import "self-payrol/money"
+
+const (
+	// DefaultTaxStatus is used for employees without a PTKP status on file.
+	DefaultTaxStatus = "TK/0"
+
+	PayCodePPh21 = "pph21"
+)
+
+type (
+	// TaxCalculation shows how a monthly PPh 21 withholding was reached so
+	// HR can check it against their own numbers.
+	TaxCalculation struct {
+		Version   string      `json:"version"`
+		Method    string      `json:"method"`
+		TaxStatus string      `json:"tax_status"`
+		Gross     money.Money `json:"gross"`
+		// Deductible are the employee pension contributions taken off gross.
+		Deductible money.Money `json:"deductible"`
+		// The annual workings below are only set by the progressive method.
+		OccupationalCost *money.Money `json:"occupational_cost,omitempty"`
+		AnnualNet        *money.Money `json:"annual_net,omitempty"`
+		PTKP             *money.Money `json:"ptkp,omitempty"`
+		PKP              *money.Money `json:"pkp,omitempty"`
+		AnnualTax        *money.Money `json:"annual_tax,omitempty"`
+		Category         string       `json:"category,omitempty"`
+		RateBps          int          `json:"rate_bps,omitempty"`
+		Withholding      money.Money  `json:"withholding"`
+	}
+
+	TaxCalculator interface {
+		Withholding(period PayrollPeriod, status string, gross, deductible money.Money) (*TaxCalculation, error)
+	}
+)
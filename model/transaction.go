@@ -2,26 +2,69 @@ package model
 
 import (
 	"context"
+	"self-payrol/money"
 	"time"
 )
 
 const (
 	TransactionTypeDebit   = "debit"
 	TransactionsTypeCredit = "credit"
+
+	// Categories tell what moved the money.
+	TransactionCategorySalary        = "salary"
+	TransactionCategoryTopUp         = "top_up"
+	TransactionCategoryPayment       = "payment"
+	TransactionCategoryLoan          = "loan"
+	TransactionCategoryLoanRepayment = "loan_repayment"
+	TransactionCategoryReimbursement = "reimbursement"
 )
 
 type (
 	Transaction struct {
-		ID        int       `json:"id"`
-		Amount    int       `json:"amount"`
-		Note      string    `json:"note"`
-		Type      string    `json:"type"`
-		CreatedAt time.Time `json:"created_at"`
-		UpdatedAt time.Time `json:"updated_at"`
+		ID     int         `json:"id"`
+		Amount money.Money `json:"amount" gorm:"embedded"`
+		Note   string      `json:"note"`
+		Type   string      `json:"type"`
+		// Category is one of the TransactionCategory constants, rows
+		// written before categories existed have none.
+		Category string `json:"category" gorm:"index"`
+		// UserID, PositionID and Period are set on salary withdrawals only,
+		// top-ups leave them empty.
+		UserID     *int      `json:"user_id" gorm:"index"`
+		User       *User     `json:"-" gorm:"constraint:OnDelete:SET NULL;"`
+		PositionID *int      `json:"position_id"`
+		Position   *Position `json:"-" gorm:"constraint:OnDelete:SET NULL;"`
+		Period     *string   `json:"period" gorm:"index"`
+		// Proration records how the salary was cut for an employee who
+		// joined or left during the period.
+		Proration *Proration `json:"proration,omitempty" gorm:"serializer:json"`
+		// Conversion is set on salary withdrawals of employees paid in
+		// another currency than the company balance.
+		Conversion *Conversion `json:"conversion,omitempty" gorm:"serializer:json"`
+		// Lines break a withdrawal down into what was earned and deducted,
+		// their earnings minus deductions add up to Amount, or to the
+		// Conversion Paid in the pay currency.
+		Lines     []TransactionLine `json:"lines,omitempty" gorm:"constraint:OnDelete:CASCADE;"`
+		CreatedAt time.Time         `json:"created_at"`
+		UpdatedAt time.Time         `json:"updated_at"`
+	}
+
+	TransactionLine struct {
+		ID            int         `json:"id"`
+		TransactionID int         `json:"transaction_id" gorm:"index"`
+		Code          string      `json:"code"`
+		Name          string      `json:"name"`
+		Kind          string      `json:"kind"`
+		Amount        money.Money `json:"amount" gorm:"embedded"`
+		Taxable       bool        `json:"taxable"`
+		SourceType    string      `json:"source_type,omitempty"`
+		SourceID      *int        `json:"source_id,omitempty"`
+		CreatedAt     time.Time   `json:"created_at"`
 	}
 
 	TransactionRepository interface {
 		Fetch(ctx context.Context, limit, offset int) ([]*Transaction, error)
+		FetchByUserID(ctx context.Context, userID, limit, offset int) ([]*Transaction, error)
 	}
 
 	TransactionUsecase interface {
@@ -0,0 +1,12 @@
+package model
+
+import "context"
+
+type (
+	// TxManager runs fn inside a single database transaction. Repositories
+	// called with the ctx handed to fn take part in that transaction, and a
+	// non-nil error from fn rolls everything back.
+	TxManager interface {
+		WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+	}
+)
@@ -2,22 +2,52 @@ package model
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"self-payrol/money"
 	"self-payrol/request"
 	"time"
+
+	"gorm.io/gorm"
 )
 
+var ErrEmploymentEndsBeforeStart = errors.New("employment end date is before its start date")
+
 type (
 	User struct {
-		ID         int       `json:"id"`
-		SecretID   string    `json:"secret_id"`
-		Name       string    `json:"name"`
-		Email      string    `json:"email"`
-		Phone      string    `json:"phone"`
-		Address    string    `json:"address"`
+		ID       int    `json:"id"`
+		SecretID string `json:"secret_id"`
+		Name     string `json:"name"`
+		Email    string `json:"email"`
+		Phone    string `json:"phone"`
+		Address  string `json:"address"`
+		// TaxStatus is the PTKP status such as "TK/0" or "K/2".
+		TaxStatus  string    `json:"tax_status" gorm:"default:TK/0"`
 		PositionID int       `json:"position_id"`
 		Position   *Position `json:"position"`
-		CreatedAt  time.Time `json:"created_at"`
-		UpdatedAt  time.Time `json:"updated_at"`
+		// StartDate and EndDate bound the employment, pay for a period
+		// they cut into is prorated.
+		StartDate *time.Time `json:"start_date" gorm:"type:date"`
+		EndDate   *time.Time `json:"end_date" gorm:"type:date"`
+		// Status is where the employee is in the employment lifecycle,
+		// only active employees and terminated ones settling their last
+		// period can withdraw.
+		Status string `json:"status" gorm:"default:active"`
+		// PayCurrency is the currency the employee is paid in when it is
+		// not the one of their position.
+		PayCurrency string `json:"pay_currency" gorm:"size:3"`
+		// BankCode, BankAccountNumber and BankAccountName are where salary
+		// transfers keyed from bank files go.
+		BankCode          string `json:"bank_code"`
+		BankAccountNumber string `json:"bank_account_number"`
+		BankAccountName   string `json:"bank_account_name"`
+		// SalaryOverride is the negotiated base salary in effect today, nil
+		// when the employee is paid the position salary.
+		SalaryOverride *money.Money `json:"salary_override" gorm:"-"`
+		CreatedAt      time.Time    `json:"created_at"`
+		UpdatedAt      time.Time    `json:"updated_at"`
+		// DeletedAt keeps removed employees and their history in place.
+		DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 	}
 
 	UserRepository interface {
@@ -26,6 +56,9 @@ type (
 		FindByID(ctx context.Context, id int) (*User, error)
 		Delete(ctx context.Context, id int) error
 		Fetch(ctx context.Context, limit, offset int) ([]*User, error)
+		// FetchPayable returns the employees who can be paid for a period
+		// starting on since, see Payable.
+		FetchPayable(ctx context.Context, since time.Time) ([]*User, error)
 	}
 
 	UserUsecase interface {
@@ -34,6 +67,36 @@ type (
 		DestroyUser(ctx context.Context, id int) error
 		EditUser(ctx context.Context, id int, req *request.UserRequest) (*User, error)
 		StoreUser(ctx context.Context, req *request.UserRequest) (*User, error)
-		WithdrawSalary(ctx context.Context, req *request.WithdrawRequest) error
+		WithdrawSalary(ctx context.Context, req *request.WithdrawRequest) (*WithdrawalSummary, error)
+		FetchTransactions(ctx context.Context, id, limit, offset int) ([]*Transaction, error)
+		TaxPreview(ctx context.Context, id int, period string) (*PayBreakdown, error)
+		SetSalary(ctx context.Context, id int, req *request.UserSalaryRequest) (*UserSalary, error)
+		FetchSalaryHistory(ctx context.Context, id int) ([]*UserSalary, error)
 	}
 )
+
+// PaidIn returns the currency the employee is paid in: their own pay
+// currency, else the one of their position, else balanceCurrency.
+func (u *User) PaidIn(balanceCurrency string) string {
+	if u.PayCurrency != "" {
+		return u.PayCurrency
+	}
+	if u.Position != nil && u.Position.PayCurrency != "" {
+		return u.Position.PayCurrency
+	}
+	return balanceCurrency
+}
+
+// Payable reports why the employee cannot be paid for period, nil when
+// they can. Terminated employees are still paid up to the period holding
+// their last day worked, the others only while active.
+func (u *User) Payable(period PayrollPeriod) error {
+	if u.Status == EmploymentActive {
+		return nil
+	}
+	if u.Status == EmploymentTerminated && u.EndDate != nil && !period.dayOf(*u.EndDate).Before(period.Start) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrEmployeeNotActive, u.Status)
+}
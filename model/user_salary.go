@@ -0,0 +1,60 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"self-payrol/money"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrSalaryOutsideBand = errors.New("salary is outside the salary band of the position")
+
+type (
+	// UserSalary is an employee's negotiated base salary from EffectiveFrom
+	// until the next row takes over. It replaces the position salary, a nil
+	// Salary ends the override and the position salary applies again.
+	UserSalary struct {
+		ID            int          `json:"id"`
+		UserID        int          `json:"user_id" gorm:"uniqueIndex:idx_user_salaries_user_effective"`
+		User          *User        `json:"-" gorm:"constraint:OnDelete:CASCADE;"`
+		Salary        *money.Money `json:"salary" gorm:"embedded;embeddedPrefix:salary_"`
+		EffectiveFrom time.Time    `json:"effective_from" gorm:"type:date;uniqueIndex:idx_user_salaries_user_effective"`
+		CreatedAt     time.Time    `json:"created_at"`
+		UpdatedAt     time.Time    `json:"updated_at"`
+	}
+
+	UserSalaryRepository interface {
+		// Upsert records the override from the row's EffectiveFrom,
+		// replacing a row already effective on that date.
+		Upsert(ctx context.Context, salary *UserSalary) (*UserSalary, error)
+		// FetchByUserID returns the history newest first.
+		FetchByUserID(ctx context.Context, userID int) ([]*UserSalary, error)
+		// FetchByPositionID returns the histories of the employees now
+		// holding the position, each newest first.
+		FetchByPositionID(ctx context.Context, positionID int) ([]*UserSalary, error)
+	}
+)
+
+// AfterFind reads an ended override back as nil, gorm fills the salary in
+// from its null columns.
+func (s *UserSalary) AfterFind(*gorm.DB) error {
+	if s.Salary != nil && s.Salary.IsZero() {
+		s.Salary = nil
+	}
+	return nil
+}
+
+// OverrideOn picks the salary override in effect on t from a history sorted
+// newest first, nil when the position salary applies.
+func OverrideOn(history []*UserSalary, t time.Time) *money.Money {
+	day := t.Format("2006-01-02")
+	for _, row := range history {
+		if row.EffectiveFrom.Format("2006-01-02") <= day {
+			return row.Salary
+		}
+	}
+
+	return nil
+}
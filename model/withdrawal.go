@@ -0,0 +1,56 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"self-payrol/money"
+	"time"
+)
+
+var (
+	ErrSalaryAlreadyWithdrawn       = errors.New("salary already withdrawn for this period")
+	ErrWithdrawAmountExceedsAccrued = errors.New("withdraw amount exceeds salary accrued so far")
+	ErrNoSalaryToWithdraw           = errors.New("no salary to withdraw for this period")
+)
+
+type (
+	// Withdrawal keeps the running total an employee has withdrawn in one
+	// payroll period, there is exactly one row per user and period.
+	Withdrawal struct {
+		ID        int         `json:"id"`
+		UserID    int         `json:"user_id" gorm:"uniqueIndex:idx_withdrawals_user_period"`
+		Period    string      `json:"period" gorm:"uniqueIndex:idx_withdrawals_user_period"`
+		Amount    money.Money `json:"amount" gorm:"embedded"`
+		CreatedAt time.Time   `json:"created_at"`
+		UpdatedAt time.Time   `json:"updated_at"`
+	}
+
+	// WithdrawalSummary is returned to the employee after a withdrawal.
+	WithdrawalSummary struct {
+		Period string      `json:"period"`
+		Amount money.Money `json:"amount"`
+		// Salary is the full net pay of the period, Accrued the part earned
+		// by the working days elapsed so far.
+		Salary    money.Money `json:"salary"`
+		Accrued   money.Money `json:"accrued"`
+		Withdrawn money.Money `json:"withdrawn"`
+		// Remaining is what is left of Salary, Available what is left of
+		// Accrued and can be drawn right now.
+		Remaining money.Money `json:"remaining"`
+		Available money.Money `json:"available"`
+		// Payslip is the number of the payslip issued for this withdrawal.
+		Payslip string `json:"payslip"`
+		// Conversion is what was debited from the balance when the employee
+		// is paid in another currency than the company balance.
+		Conversion *Conversion `json:"conversion,omitempty"`
+	}
+
+	WithdrawalRepository interface {
+		// Lock returns the withdrawal row of the user and period, creating it
+		// at zero in currency when missing, and holds a row lock until the
+		// transaction ends.
+		Lock(ctx context.Context, userID int, period, currency string) (*Withdrawal, error)
+		UpdateByID(ctx context.Context, id int, withdrawal *Withdrawal) (*Withdrawal, error)
+		FetchByPeriod(ctx context.Context, period string) ([]*Withdrawal, error)
+	}
+)
@@ -0,0 +1,321 @@
+// Package money holds amounts of money as integer minor units of a
+// currency, so they add up exactly and never pass through a float.
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is assumed for amounts given without a currency.
+const DefaultCurrency = "IDR"
+
+var (
+	ErrOverflow         = errors.New("amount of money out of range")
+	ErrCurrencyMismatch = errors.New("amounts of money are in different currencies")
+	ErrUnknownCurrency  = errors.New("currency not supported")
+	ErrInvalidAmount    = errors.New("amount of money not valid")
+)
+
+// exponents lists the supported ISO 4217 currencies with the number of
+// decimal places of their minor unit. The rupiah has had no sen in
+// circulation for decades and is kept in whole rupiah.
+var exponents = map[string]int{
+	"IDR": 0,
+	"JPY": 0,
+	"AUD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"MYR": 2,
+	"SGD": 2,
+	"USD": 2,
+}
+
+// Money is Amount minor units of Currency. It is stored in two columns,
+// amount and currency, prefixed with the name of the field embedding it.
+type Money struct {
+	Amount   int64  `gorm:"column:amount"`
+	Currency string `gorm:"column:currency;size:3;default:IDR"`
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// Exponent returns the decimal places of the minor unit of currency.
+func Exponent(currency string) (int, error) {
+	exponent, ok := exponents[currency]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+	return exponent, nil
+}
+
+// Validate reports an unsupported currency.
+func (m Money) Validate() error {
+	_, err := Exponent(m.Currency)
+	return err
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// SameCurrency reports whether m and o can be added up.
+func (m Money) SameCurrency(o Money) bool {
+	return m.Currency == o.Currency
+}
+
+// Add adds o to m. The zero Money has no currency yet and adds up with any
+// other, so a total can start from it.
+func (m Money) Add(o Money) (Money, error) {
+	if m == (Money{}) {
+		m.Currency = o.Currency
+	}
+	if o == (Money{}) {
+		o.Currency = m.Currency
+	}
+	if !m.SameCurrency(o) {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+
+	sum := m.Amount + o.Amount
+	if (o.Amount > 0 && sum < m.Amount) || (o.Amount < 0 && sum > m.Amount) {
+		return Money{}, ErrOverflow
+	}
+
+	return Money{Amount: sum, Currency: m.Currency}, nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	if o.Amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(Money{Amount: -o.Amount, Currency: o.Currency})
+}
+
+func (m Money) Mul(n int64) (Money, error) {
+	return m.Scale(n, 1)
+}
+
+// Scale multiplies m by num/den, rounding half to even so that rounding
+// errors do not drift one way over many amounts.
+func (m Money) Scale(num, den int64) (Money, error) {
+	if den == 0 {
+		return Money{}, ErrInvalidAmount
+	}
+
+	r := new(big.Rat).SetFrac(new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(num)), big.NewInt(den))
+	return m.round(r)
+}
+
+// Prorate multiplies m by num/den, truncating toward zero like integer
+// division, so a share of an amount never comes out above it.
+func (m Money) Prorate(num, den int64) (Money, error) {
+	if den == 0 {
+		return Money{}, ErrInvalidAmount
+	}
+
+	q := new(big.Int).Quo(new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(num)), big.NewInt(den))
+	if !q.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+
+	return Money{Amount: q.Int64(), Currency: m.Currency}, nil
+}
+
+// ScaleRat multiplies m by a rate given as a decimal string such as
+// "15825.5", rounding half to even.
+func (m Money) ScaleRat(rate string) (Money, error) {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok {
+		return Money{}, ErrInvalidAmount
+	}
+
+	return m.round(r.Mul(r, new(big.Rat).SetInt64(m.Amount)))
+}
+
+// Convert converts m into currency at rate, what one major unit of the
+// currency of m is worth in major units of currency, rounding half to
+// even.
+func (m Money) Convert(currency string, rate *big.Rat) (Money, error) {
+	from, err := Exponent(m.Currency)
+	if err != nil {
+		return Money{}, err
+	}
+	to, err := Exponent(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	r := new(big.Rat).Mul(rate, new(big.Rat).SetInt64(m.Amount))
+	shift := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(to-from))), nil))
+	if to > from {
+		r.Mul(r, shift)
+	} else {
+		r.Quo(r, shift)
+	}
+
+	return Money{Currency: currency}.round(r)
+}
+
+// round rounds r half to even into whole minor units of the currency of m.
+func (m Money) round(r *big.Rat) (Money, error) {
+	quo, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+
+	// compare twice the remainder with the denominator to find the half
+	twice := new(big.Int).Abs(rem)
+	twice.Lsh(twice, 1)
+	switch c := twice.Cmp(r.Denom()); {
+	case c > 0, c == 0 && quo.Bit(0) == 1:
+		quo.Add(quo, big.NewInt(int64(r.Sign())))
+	}
+
+	if !quo.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+
+	return Money{Amount: quo.Int64(), Currency: m.Currency}, nil
+}
+
+// String writes m in major units, e.g. "12.50 USD".
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// Decimal writes the amount in major units with the decimal places of the
+// currency, e.g. "12.50". Unknown currencies are written in minor units.
+func (m Money) Decimal() string {
+	exponent, _ := Exponent(m.Currency)
+
+	digits := strconv.FormatInt(m.Amount, 10)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if exponent == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+// Parse reads an amount in major units of currency, such as "12.5" for
+// USD. More decimal places than the currency has are refused rather than
+// rounded away.
+func Parse(amount, currency string) (Money, error) {
+	exponent, err := Exponent(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	whole, fraction, _ := strings.Cut(strings.TrimSpace(amount), ".")
+	if len(fraction) > exponent {
+		return Money{}, fmt.Errorf("%w: %s has %d decimal places", ErrInvalidAmount, currency, exponent)
+	}
+
+	sign := ""
+	if strings.HasPrefix(whole, "-") {
+		sign, whole = "-", whole[1:]
+	}
+	digits := whole + fraction + strings.Repeat("0", exponent-len(fraction))
+	if whole == "" || strings.ContainsAny(digits, "+-") {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+
+	minor, err := strconv.ParseInt(sign+digits, 10, 64)
+	if errors.Is(err, strconv.ErrRange) {
+		return Money{}, ErrOverflow
+	}
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+
+	return Money{Amount: minor, Currency: currency}, nil
+}
+
+// MarshalJSON writes the amount as a decimal string in major units, which
+// JavaScript clients read without going through a lossy float.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{Amount: m.Decimal(), Currency: m.Currency})
+}
+
+// UnmarshalJSON reads {"amount": "12.50", "currency": "USD"}, or a bare
+// amount in the default currency. Amounts can be strings or numbers.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var raw interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+
+	currency := DefaultCurrency
+	if object, ok := raw.(map[string]interface{}); ok {
+		raw = object["amount"]
+		if code, ok := object["currency"].(string); ok && code != "" {
+			currency = strings.ToUpper(code)
+		}
+	}
+
+	var amount string
+	switch v := raw.(type) {
+	case nil:
+		return nil
+	case json.Number:
+		amount = v.String()
+	case string:
+		amount = v
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidAmount, data)
+	}
+
+	parsed, err := Parse(amount, currency)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+// UnmarshalParam reads a form or query value, "12.50 USD" as String writes
+// it or a bare amount in the default currency.
+func (m *Money) UnmarshalParam(param string) error {
+	amount, currency, found := strings.Cut(strings.TrimSpace(param), " ")
+	if !found {
+		currency = DefaultCurrency
+	}
+
+	parsed, err := Parse(amount, strings.ToUpper(strings.TrimSpace(currency)))
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
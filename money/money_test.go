@@ -0,0 +1,250 @@
+package money_test
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"self-payrol/money"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoney_Add(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        money.Money
+		expected    money.Money
+		expectedErr error
+	}{
+		{
+			name:     "Adds amounts of the same currency",
+			a:        money.New(5000000, "IDR"),
+			b:        money.New(-1250000, "IDR"),
+			expected: money.New(3750000, "IDR"),
+		},
+		{
+			name:        "Refuses different currencies",
+			a:           money.New(5000000, "IDR"),
+			b:           money.New(100, "USD"),
+			expectedErr: money.ErrCurrencyMismatch,
+		},
+		{
+			name:     "Starts a total from the zero Money",
+			a:        money.Money{},
+			b:        money.New(100, "USD"),
+			expected: money.New(100, "USD"),
+		},
+		{
+			name:     "Adds the zero Money to any currency",
+			a:        money.New(100, "USD"),
+			b:        money.Money{},
+			expected: money.New(100, "USD"),
+		},
+		{
+			name:        "Refuses a zero amount of another currency",
+			a:           money.New(100, "USD"),
+			b:           money.New(0, "IDR"),
+			expectedErr: money.ErrCurrencyMismatch,
+		},
+		{
+			name:        "Overflows",
+			a:           money.New(math.MaxInt64, "IDR"),
+			b:           money.New(1, "IDR"),
+			expectedErr: money.ErrOverflow,
+		},
+		{
+			name:        "Overflows below zero",
+			a:           money.New(math.MinInt64, "IDR"),
+			b:           money.New(-1, "IDR"),
+			expectedErr: money.ErrOverflow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sum, err := tt.a.Add(tt.b)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, sum)
+		})
+	}
+}
+
+func TestMoney_Sub(t *testing.T) {
+	_, err := money.New(0, "IDR").Sub(money.New(math.MinInt64, "IDR"))
+	assert.ErrorIs(t, err, money.ErrOverflow)
+
+	diff, err := money.New(1000, "USD").Sub(money.New(1250, "USD"))
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(-250, "USD"), diff)
+}
+
+func TestMoney_Scale(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   money.Money
+		num, den int64
+		expected money.Money
+	}{
+		{name: "Exact", amount: money.New(3000000, "IDR"), num: 2, den: 3, expected: money.New(2000000, "IDR")},
+		{name: "Rounds down below the half", amount: money.New(10, "IDR"), num: 1, den: 3, expected: money.New(3, "IDR")},
+		{name: "Rounds up above the half", amount: money.New(20, "IDR"), num: 1, den: 3, expected: money.New(7, "IDR")},
+		{name: "Half rounds to the even neighbour down", amount: money.New(5, "IDR"), num: 1, den: 2, expected: money.New(2, "IDR")},
+		{name: "Half rounds to the even neighbour up", amount: money.New(7, "IDR"), num: 1, den: 2, expected: money.New(4, "IDR")},
+		{name: "Negative half rounds to even", amount: money.New(-7, "IDR"), num: 1, den: 2, expected: money.New(-4, "IDR")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scaled, err := tt.amount.Scale(tt.num, tt.den)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, scaled)
+		})
+	}
+
+	_, err := money.New(math.MaxInt64, "IDR").Mul(2)
+	assert.ErrorIs(t, err, money.ErrOverflow)
+
+	_, err = money.New(1, "IDR").Scale(1, 0)
+	assert.ErrorIs(t, err, money.ErrInvalidAmount)
+}
+
+func TestMoney_Prorate(t *testing.T) {
+	prorated, err := money.New(5000, "IDR").Prorate(12, 31)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(1935, "IDR"), prorated)
+
+	prorated, err = money.New(-5000, "IDR").Prorate(12, 31)
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(-1935, "IDR"), prorated)
+
+	_, err = money.New(math.MaxInt64, "IDR").Prorate(3, 2)
+	assert.ErrorIs(t, err, money.ErrOverflow)
+
+	_, err = money.New(1, "IDR").Prorate(1, 0)
+	assert.ErrorIs(t, err, money.ErrInvalidAmount)
+}
+
+func TestMoney_ScaleRat(t *testing.T) {
+	scaled, err := money.New(125050, "USD").ScaleRat("1.005")
+	assert.NoError(t, err)
+	assert.Equal(t, money.New(125675, "USD"), scaled)
+
+	_, err = money.New(1, "USD").ScaleRat("abc")
+	assert.ErrorIs(t, err, money.ErrInvalidAmount)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		amount      string
+		currency    string
+		expected    money.Money
+		expectedErr error
+	}{
+		{amount: "5001000", currency: "IDR", expected: money.New(5001000, "IDR")},
+		{amount: "12.5", currency: "USD", expected: money.New(1250, "USD")},
+		{amount: "-0.05", currency: "USD", expected: money.New(-5, "USD")},
+		{amount: "12.505", currency: "USD", expectedErr: money.ErrInvalidAmount},
+		{amount: "1000.50", currency: "IDR", expectedErr: money.ErrInvalidAmount},
+		{amount: "12", currency: "XYZ", expectedErr: money.ErrUnknownCurrency},
+		{amount: "1.2.3", currency: "USD", expectedErr: money.ErrInvalidAmount},
+		{amount: ".5", currency: "USD", expectedErr: money.ErrInvalidAmount},
+		{amount: "99999999999999999999", currency: "IDR", expectedErr: money.ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.amount+" "+tt.currency, func(t *testing.T) {
+			m, err := money.Parse(tt.amount, tt.currency)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, m)
+		})
+	}
+}
+
+func TestMoney_Decimal(t *testing.T) {
+	assert.Equal(t, "5001000", money.New(5001000, "IDR").Decimal())
+	assert.Equal(t, "12.50", money.New(1250, "USD").Decimal())
+	assert.Equal(t, "-0.05", money.New(-5, "USD").Decimal())
+	assert.Equal(t, "0.00", money.New(0, "EUR").Decimal())
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(money.New(math.MaxInt64, "IDR"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"9223372036854775807","currency":"IDR"}`, string(data))
+
+	tests := []struct {
+		data        string
+		expected    money.Money
+		expectedErr error
+	}{
+		{data: `{"amount":"12.50","currency":"usd"}`, expected: money.New(1250, "USD")},
+		{data: `{"amount":12.5,"currency":"USD"}`, expected: money.New(1250, "USD")},
+		{data: `{"amount":"5000000"}`, expected: money.New(5000000, "IDR")},
+		{data: `5000000`, expected: money.New(5000000, "IDR")},
+		{data: `"9223372036854775807"`, expected: money.New(math.MaxInt64, "IDR")},
+		{data: `null`},
+		{data: `true`, expectedErr: money.ErrInvalidAmount},
+		{data: `{"amount":"1.005","currency":"USD"}`, expectedErr: money.ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			var m money.Money
+			err := json.Unmarshal([]byte(tt.data), &m)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, m)
+		})
+	}
+}
+
+func TestMoney_UnmarshalParam(t *testing.T) {
+	tests := []struct {
+		param       string
+		expected    money.Money
+		expectedErr error
+	}{
+		{param: "12.50 usd", expected: money.New(1250, "USD")},
+		{param: "5000000", expected: money.New(5000000, "IDR")},
+		{param: "12.5 XYZ", expectedErr: money.ErrUnknownCurrency},
+		{param: "twelve", expectedErr: money.ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.param, func(t *testing.T) {
+			var m money.Money
+			err := m.UnmarshalParam(tt.param)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, m)
+		})
+	}
+}
+
+func TestMoney_Convert(t *testing.T) {
+	rate := func(s string) *big.Rat {
+		r, _ := new(big.Rat).SetString(s)
+		return r
+	}
+
+	tests := []struct {
+		name        string
+		amount      money.Money
+		currency    string
+		rate        *big.Rat
+		expected    money.Money
+		expectedErr error
+	}{
+		{name: "Into more decimal places", amount: money.New(15825500, "IDR"), currency: "USD", rate: new(big.Rat).Inv(rate("15825.5")), expected: money.New(100000, "USD")},
+		{name: "Into fewer decimal places", amount: money.New(125050, "USD"), currency: "IDR", rate: rate("15825.5"), expected: money.New(19789788, "IDR")},
+		{name: "Rounds half to even", amount: money.New(25, "IDR"), currency: "USD", rate: rate("0.005"), expected: money.New(12, "USD")},
+		{name: "Unknown currency", amount: money.New(100, "IDR"), currency: "XYZ", rate: rate("1"), expectedErr: money.ErrUnknownCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted, err := tt.amount.Convert(tt.currency, tt.rate)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, converted)
+		})
+	}
+}
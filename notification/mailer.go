@@ -0,0 +1,171 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"self-payrol/model"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type smtpMailer struct {
+	addr     string
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPMailer sends through the server at addr (host:port). STARTTLS is
+// used when the server offers it, authentication only when username is set,
+// so a local sink such as MailHog works without either.
+func NewSMTPMailer(addr, username, password, from string) model.Mailer {
+	return &smtpMailer{addr: addr, username: username, password: password, from: from}
+}
+
+func (m *smtpMailer) Send(ctx context.Context, email *model.Email) error {
+	msg, err := buildMessage(m.from, email)
+	if err != nil {
+		return err
+	}
+
+	host, _, err := net.SplitHostPort(m.addr)
+	if err != nil {
+		return err
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+
+	if m.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.username, m.password, host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(m.from); err != nil {
+		return err
+	}
+	for _, to := range email.To {
+		if err := client.Rcpt(to); err != nil {
+			return err
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// buildMessage writes email as a MIME message, a quoted-printable text part
+// followed by the attachments in base64.
+func buildMessage(from string, email *model.Email) ([]byte, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(email.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", email.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", mw.Boundary())
+
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(email.Body, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range email.Attachments {
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(attachment.ContentType, map[string]string{"name": attachment.Filename})},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		encoded := base64.StdEncoding.EncodeToString(attachment.Content)
+		for len(encoded) > 76 {
+			fmt.Fprintf(part, "%s\r\n", encoded[:76])
+			encoded = encoded[76:]
+		}
+		fmt.Fprintf(part, "%s\r\n", encoded)
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type logMailer struct{}
+
+// NewLogMailer only logs what would have been sent, for development.
+func NewLogMailer() model.Mailer {
+	return &logMailer{}
+}
+
+func (m *logMailer) Send(ctx context.Context, email *model.Email) error {
+	attachments := make([]string, 0, len(email.Attachments))
+	for _, attachment := range email.Attachments {
+		attachments = append(attachments, fmt.Sprintf("%s (%d bytes)", attachment.Filename, len(attachment.Content)))
+	}
+
+	log.Info().
+		Strs("to", email.To).
+		Str("subject", email.Subject).
+		Strs("attachments", attachments).
+		Msg(email.Body)
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package notification_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"self-payrol/model"
+	"self-payrol/notification"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpSink accepts one message and hands it back, like a local MailHog.
+func smtpSink(t *testing.T) (string, <-chan string) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		tp := textproto.NewConn(conn)
+		tp.PrintfLine("220 sink ready")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+
+			switch cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); cmd {
+			case "EHLO", "HELO":
+				tp.PrintfLine("250 sink")
+			case "MAIL", "RCPT":
+				tp.PrintfLine("250 ok")
+			case "DATA":
+				tp.PrintfLine("354 go ahead")
+				data, err := tp.ReadDotLines()
+				if err != nil {
+					return
+				}
+				received <- strings.Join(data, "\n")
+				tp.PrintfLine("250 queued")
+			case "QUIT":
+				tp.PrintfLine("221 bye")
+				return
+			default:
+				tp.PrintfLine("502 not implemented")
+			}
+		}
+	}()
+
+	return ln.Addr().String(), received
+}
+
+func Test_smtpMailer_Send(t *testing.T) {
+	addr, received := smtpSink(t)
+
+	m := notification.NewSMTPMailer(addr, "", "", "payroll@example.com")
+
+	err := m.Send(context.TODO(), &model.Email{
+		To:      []string{"siti@example.com"},
+		Subject: "Payslip 2026-10",
+		Body:    "Hi Siti,\nyour payslip is attached.\n",
+		Attachments: []model.Attachment{
+			{Filename: "payslip.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.4 test")},
+		},
+	})
+	require.NoError(t, err)
+
+	msg := <-received
+	assert.Contains(t, msg, "From: payroll@example.com")
+	assert.Contains(t, msg, "To: siti@example.com")
+	assert.Contains(t, msg, "Subject: Payslip 2026-10")
+	assert.Contains(t, msg, "Hi Siti,")
+	assert.Contains(t, msg, `Content-Disposition: attachment; filename=payslip.pdf`)
+	assert.Contains(t, msg, base64.StdEncoding.EncodeToString([]byte("%PDF-1.4 test")))
+}
+
+func Test_smtpMailer_Send_Unreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+
+	m := notification.NewSMTPMailer(addr, "", "", "payroll@example.com")
+
+	err = m.Send(context.TODO(), &model.Email{To: []string{"siti@example.com"}, Subject: "test"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,114 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"self-payrol/helper"
+	"self-payrol/model"
+	"self-payrol/money"
+	"strings"
+	"text/template"
+
+	"github.com/rs/zerolog/log"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// every template defines its own subject and body blocks, so each is
+// parsed on its own
+var templates = map[string]*template.Template{
+	"salary_withdrawn":  parseTemplate("salary_withdrawn.tmpl"),
+	"balance_topped_up": parseTemplate("balance_topped_up.tmpl"),
+}
+
+func parseTemplate(name string) *template.Template {
+	return template.Must(template.New(name).
+		Funcs(template.FuncMap{"money": helper.FormatMoney}).
+		ParseFS(templateFS, "templates/"+name))
+}
+
+type notifier struct {
+	mailer   model.Mailer
+	renderer model.PayslipRenderer
+}
+
+// NewNotifier emails employees and the company through mailer, which should
+// be a Queue so a slow mail server does not hold up the request.
+func NewNotifier(mailer model.Mailer, renderer model.PayslipRenderer) model.Notifier {
+	return &notifier{mailer: mailer, renderer: renderer}
+}
+
+func (n *notifier) SalaryWithdrawn(ctx context.Context, user *model.User, payslip *model.Payslip) {
+	if user.Email == "" {
+		return
+	}
+
+	email, err := newEmail("salary_withdrawn", user.Email, map[string]interface{}{
+		"User":    user,
+		"Payslip": payslip,
+	})
+	if err != nil {
+		n.fail(err, user.Email)
+		return
+	}
+
+	pdf, err := n.renderer.Render(payslip)
+	if err != nil {
+		n.fail(err, user.Email)
+		return
+	}
+
+	email.Attachments = []model.Attachment{{
+		Filename:    "payslip-" + strings.ReplaceAll(payslip.Number, "/", "-") + ".pdf",
+		ContentType: "application/pdf",
+		Content:     pdf,
+	}}
+
+	if err := n.mailer.Send(ctx, email); err != nil {
+		n.fail(err, user.Email)
+	}
+}
+
+func (n *notifier) BalanceToppedUp(ctx context.Context, company *model.Company, amount money.Money) {
+	if company.Email == "" {
+		return
+	}
+
+	email, err := newEmail("balance_topped_up", company.Email, map[string]interface{}{
+		"Company": company,
+		"Amount":  amount,
+	})
+	if err != nil {
+		n.fail(err, company.Email)
+		return
+	}
+
+	if err := n.mailer.Send(ctx, email); err != nil {
+		n.fail(err, company.Email)
+	}
+}
+
+func (n *notifier) fail(err error, to string) {
+	log.Error().Err(err).Str("to", to).Msg("failed to queue email")
+}
+
+// newEmail executes the subject and body blocks of the named template.
+func newEmail(name, to string, data interface{}) (*model.Email, error) {
+	tmpl := templates[name]
+
+	var subject, body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := tmpl.ExecuteTemplate(&body, "body", data); err != nil {
+		return nil, err
+	}
+
+	return &model.Email{
+		To:      []string{to},
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    body.String(),
+	}, nil
+}
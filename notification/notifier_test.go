@@ -0,0 +1,103 @@
+package notification_test
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"self-payrol/money"
+	"self-payrol/notification"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_notifier_SalaryWithdrawn(t *testing.T) {
+	payslip := &model.Payslip{
+		Period: "2026-10",
+		Number: "PS/2026-10/1/12",
+		Data: model.PayslipData{
+			CompanyName: "PT Sejahtera",
+			Net:         money.New(9560000, "IDR"),
+			Amount:      money.New(9560000, "IDR"),
+			Withdrawn:   money.New(9560000, "IDR"),
+			Remaining:   money.New(0, "IDR"),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		user      *model.User
+		renderErr error
+		sendErr   error
+		sent      bool
+	}{
+		{
+			name: "Payslip is attached",
+			user: &model.User{ID: 1, Name: "Siti", Email: "siti@example.com"},
+			sent: true,
+		},
+		{
+			name: "Employee without email",
+			user: &model.User{ID: 1, Name: "Siti"},
+		},
+		{
+			name:      "Failed to render the payslip",
+			user:      &model.User{ID: 1, Name: "Siti", Email: "siti@example.com"},
+			renderErr: assert.AnError,
+		},
+		{
+			name:    "Failed to queue the email",
+			user:    &model.User{ID: 1, Name: "Siti", Email: "siti@example.com"},
+			sendErr: notification.ErrQueueFull,
+			sent:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockMailer := new(mocks.Mailer)
+			mockRenderer := new(mocks.PayslipRenderer)
+
+			if tt.user.Email != "" {
+				mockRenderer.On("Render", payslip).Return([]byte("%PDF-1.4"), tt.renderErr)
+			}
+
+			if tt.sent {
+				mockMailer.On("Send", mock.Anything, &model.Email{
+					To:      []string{"siti@example.com"},
+					Subject: "Payslip 2026-10: Rp 9.560.000 paid",
+					Body: "Hi Siti,\n\nRp 9.560.000 of your 2026-10 salary has been paid.\n\n" +
+						"Net pay this period:   Rp 9.560.000\n" +
+						"Withdrawn so far:      Rp 9.560.000\n" +
+						"Remaining:             Rp 0\n\n" +
+						"Your payslip PS/2026-10/1/12 is attached.\n\nPT Sejahtera\n",
+					Attachments: []model.Attachment{
+						{Filename: "payslip-PS-2026-10-1-12.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.4")},
+					},
+				}).Return(tt.sendErr)
+			}
+
+			n := notification.NewNotifier(mockMailer, mockRenderer)
+
+			n.SalaryWithdrawn(context.TODO(), tt.user, payslip)
+
+			mockMailer.AssertExpectations(t)
+			mockRenderer.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_notifier_BalanceToppedUp(t *testing.T) {
+	mockMailer := new(mocks.Mailer)
+	mockMailer.On("Send", mock.Anything, &model.Email{
+		To:      []string{"finance@example.com"},
+		Subject: "PT Sejahtera balance topped up by Rp 1.000.000",
+		Body:    "The balance of PT Sejahtera was topped up by Rp 1.000.000.\n\nNew balance: Rp 21.000.000\n",
+	}).Return(nil)
+
+	n := notification.NewNotifier(mockMailer, new(mocks.PayslipRenderer))
+
+	n.BalanceToppedUp(context.TODO(), &model.Company{Name: "PT Sejahtera", Email: "finance@example.com", Balance: money.New(21000000, "IDR")}, money.New(1000000, "IDR"))
+
+	mockMailer.AssertExpectations(t)
+}
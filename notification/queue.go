@@ -0,0 +1,91 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"self-payrol/model"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var ErrQueueFull = errors.New("mail queue is full")
+
+// Queue is a Mailer that hands emails to a background worker, so sending
+// never holds up a request. Failed sends are retried with a growing delay
+// and logged once the attempts run out.
+type Queue struct {
+	mailer   model.Mailer
+	emails   chan *model.Email
+	attempts int
+	backoff  time.Duration
+	timeout  time.Duration
+	wg       sync.WaitGroup
+}
+
+func NewQueue(mailer model.Mailer, size, attempts int, backoff time.Duration) *Queue {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	q := &Queue{
+		mailer:   mailer,
+		emails:   make(chan *model.Email, size),
+		attempts: attempts,
+		backoff:  backoff,
+		timeout:  30 * time.Second,
+	}
+
+	q.wg.Add(1)
+	go q.run()
+
+	return q
+}
+
+// Send queues email and returns straight away, the context of the caller
+// is not used for delivery.
+func (q *Queue) Send(ctx context.Context, email *model.Email) error {
+	select {
+	case q.emails <- email:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Close stops taking emails and waits for the queued ones to be sent.
+func (q *Queue) Close() {
+	close(q.emails)
+	q.wg.Wait()
+}
+
+func (q *Queue) run() {
+	defer q.wg.Done()
+
+	for email := range q.emails {
+		q.deliver(email)
+	}
+}
+
+func (q *Queue) deliver(email *model.Email) {
+	var err error
+	for attempt := 1; attempt <= q.attempts; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), q.timeout)
+		err = q.mailer.Send(ctx, email)
+		cancel()
+		if err == nil {
+			return
+		}
+
+		if attempt < q.attempts {
+			time.Sleep(q.backoff * time.Duration(attempt))
+		}
+	}
+
+	log.Error().
+		Err(err).
+		Strs("to", email.To).
+		Str("subject", email.Subject).
+		Msg("failed to send email")
+}
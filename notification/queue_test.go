@@ -0,0 +1,44 @@
+package notification_test
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"self-payrol/notification"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_Queue_Send(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		attempts int
+		calls    int
+	}{
+		{name: "Sent on the first attempt", attempts: 3, calls: 1},
+		{name: "Retried after a failure", failures: 1, attempts: 3, calls: 2},
+		{name: "Given up after the last attempt", failures: 5, attempts: 3, calls: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email := &model.Email{To: []string{"siti@example.com"}, Subject: "test"}
+			mockMailer := new(mocks.Mailer)
+
+			if tt.failures > 0 {
+				mockMailer.On("Send", mock.Anything, email).Return(assert.AnError).Times(tt.failures)
+			}
+			mockMailer.On("Send", mock.Anything, email).Return(nil)
+
+			q := notification.NewQueue(mockMailer, 1, tt.attempts, 0)
+
+			// the caller never sees delivery errors
+			assert.NoError(t, q.Send(context.TODO(), email))
+			q.Close()
+
+			mockMailer.AssertNumberOfCalls(t, "Send", tt.calls)
+		})
+	}
+}
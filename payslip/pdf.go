@@ -0,0 +1,151 @@
+package payslip
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	pageWidth  = 595.0 // A4 in points
+	pageHeight = 842.0
+
+	fontRegular = "F1"
+	fontBold    = "F2"
+)
+
+// document is a minimal PDF writer for text and rules in the standard
+// Helvetica fonts. It writes no timestamps or ids of its own, so the same
+// drawing calls always produce the same file.
+type document struct {
+	title   string
+	created time.Time
+	pages   []*bytes.Buffer
+}
+
+func newDocument(title string, created time.Time) *document {
+	return &document{title: title, created: created}
+}
+
+func (d *document) addPage() {
+	d.pages = append(d.pages, new(bytes.Buffer))
+}
+
+func (d *document) page() *bytes.Buffer {
+	if len(d.pages) == 0 {
+		d.addPage()
+	}
+	return d.pages[len(d.pages)-1]
+}
+
+// text draws s with its baseline starting at x, y from the bottom left.
+func (d *document) text(x, y float64, font string, size float64, s string) {
+	fmt.Fprintf(d.page(), "BT /%s %s Tf %s %s Td (%s) Tj ET\n", font, number(size), number(x), number(y), escape(s))
+}
+
+// textRight draws s ending at x. Only amounts are right aligned, so the
+// width table covers the characters they are made of.
+func (d *document) textRight(x, y float64, font string, size float64, s string) {
+	d.text(x-textWidth(s, size), y, font, size, s)
+}
+
+func (d *document) rule(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(d.page(), "%s %s m %s %s l 0.5 w S\n", number(x1), number(y1), number(x2), number(y2))
+}
+
+func (d *document) bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	if len(d.pages) == 0 {
+		d.addPage()
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1 catalog, 2 page tree, 3 and 4 fonts, 5 info, then a page and its
+	// content stream for every page
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+i*2)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title (%s) /Producer (self-payrol) /CreationDate (%s) >>", escape(d.title), pdfDate(d.created)))
+
+	for i, content := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			number(pageWidth), number(pageHeight), fontRegular, fontBold, 7+i*2))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// escape encodes s as the body of a PDF literal string in WinAnsi, runes
+// outside Latin-1 are replaced.
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+func number(f float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", f), "0"), ".")
+}
+
+func pdfDate(t time.Time) string {
+	_, offset := t.Zone()
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	return fmt.Sprintf("D:%s%c%02d'%02d'", t.Format("20060102150405"), sign, offset/3600, offset%3600/60)
+}
+
+// helveticaWidths are the Helvetica advance widths, in thousandths of the
+// font size, of the characters used in amounts.
+var helveticaWidths = map[rune]float64{
+	' ': 278, '.': 278, ',': 278, '-': 333, '(': 333, ')': 333, 'R': 722, 'p': 556,
+}
+
+func textWidth(s string, size float64) float64 {
+	width := 0.0
+	for _, r := range s {
+		w, ok := helveticaWidths[r]
+		if !ok {
+			w = 556 // digits
+		}
+		width += w
+	}
+	return width * size / 1000
+}
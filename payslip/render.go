@@ -0,0 +1,146 @@
+package payslip
+
+import (
+	"self-payrol/helper"
+	"self-payrol/model"
+	"self-payrol/money"
+	"strconv"
+	"strings"
+)
+
+const (
+	marginLeft   = 50.0
+	marginRight  = pageWidth - 50.0
+	marginTop    = pageHeight - 50.0
+	marginBottom = 60.0
+	lineHeight   = 16.0
+)
+
+type renderer struct{}
+
+func NewRenderer() model.PayslipRenderer {
+	return &renderer{}
+}
+
+func (r *renderer) Render(payslip *model.Payslip) ([]byte, error) {
+	data := payslip.Data
+	w := &writer{doc: newDocument("Payslip "+payslip.Number, data.IssuedAt), y: marginTop}
+
+	w.doc.text(marginLeft, w.y, fontBold, 18, "PAYSLIP")
+	w.doc.textRight(marginRight, w.y, fontRegular, 10, payslip.Number)
+	w.y -= 24
+	w.doc.text(marginLeft, w.y, fontBold, 12, data.CompanyName)
+	w.y -= 14
+	for _, line := range strings.Split(data.CompanyAddress, "\n") {
+		w.doc.text(marginLeft, w.y, fontRegular, 10, line)
+		w.y -= 12
+	}
+	w.y -= 6
+	w.doc.rule(marginLeft, w.y, marginRight, w.y)
+	w.y -= 20
+
+	w.field("Employee", data.EmployeeName)
+	w.field("Employee ID", strconv.Itoa(data.EmployeeID))
+	if data.EmployeeEmail != "" {
+		w.field("Email", data.EmployeeEmail)
+	}
+	w.field("Position", data.PositionName)
+	w.field("Period", data.Period+" ("+data.PeriodStart.Format("2 Jan 2006")+" - "+data.PeriodEnd.AddDate(0, 0, -1).Format("2 Jan 2006")+")")
+	w.field("Issued", data.IssuedAt.Format("2 Jan 2006 15:04 -0700"))
+	w.y -= 8
+
+	w.section("Earnings")
+	for _, line := range data.Lines {
+		if line.Kind == model.PayLineEarning {
+			w.row(line.Name, line.Amount)
+		}
+	}
+	w.total("Gross pay", data.Gross)
+
+	w.section("Deductions")
+	for _, line := range data.Lines {
+		if line.Kind == model.PayLineDeduction {
+			w.row(line.Name, line.Amount)
+		}
+	}
+	w.total("Total deductions", data.Deductions)
+	w.total("Net pay", data.Net)
+
+	if data.EmployerCost.Amount > 0 {
+		w.section("Paid by the employer, not part of net pay")
+		for _, line := range data.Lines {
+			if line.Kind == model.PayLineEmployer {
+				w.row(line.Name, line.Amount)
+			}
+		}
+		w.total("Total employer contributions", data.EmployerCost)
+	}
+
+	w.section("Payment")
+	w.row("Paid by this withdrawal", data.Amount)
+	if c := data.Conversion; c != nil {
+		w.rowText("Debited from the company in "+c.Debited.Currency, helper.FormatMoney(c.Debited))
+		w.rowText("Exchange rate of "+c.RateDate.Format("2 Jan 2006"), rate(c.Rate)+" per "+c.Paid.Currency)
+	}
+	w.row("Withdrawn this period", data.Withdrawn)
+	w.total("Remaining this period", data.Remaining)
+
+	return w.doc.bytes(), nil
+}
+
+// writer lays the payslip out top to bottom, starting a new page when the
+// current one is full.
+type writer struct {
+	doc *document
+	y   float64
+}
+
+func (w *writer) advance(height float64) {
+	if w.y-height < marginBottom {
+		w.doc.addPage()
+		w.y = marginTop
+		return
+	}
+	w.y -= height
+}
+
+func (w *writer) field(label, value string) {
+	w.doc.text(marginLeft, w.y, fontBold, 10, label)
+	w.doc.text(marginLeft+90, w.y, fontRegular, 10, value)
+	w.advance(lineHeight)
+}
+
+func (w *writer) section(title string) {
+	w.advance(8)
+	w.doc.text(marginLeft, w.y, fontBold, 11, title)
+	w.advance(4)
+	w.doc.rule(marginLeft, w.y, marginRight, w.y)
+	w.advance(lineHeight)
+}
+
+func (w *writer) row(label string, amount money.Money) {
+	w.doc.text(marginLeft+10, w.y, fontRegular, 10, label)
+	w.doc.textRight(marginRight, w.y, fontRegular, 10, helper.FormatMoney(amount))
+	w.advance(lineHeight)
+}
+
+func (w *writer) rowText(label, value string) {
+	w.doc.text(marginLeft+10, w.y, fontRegular, 10, label)
+	w.doc.textRight(marginRight, w.y, fontRegular, 10, value)
+	w.advance(lineHeight)
+}
+
+func (w *writer) total(label string, amount money.Money) {
+	w.doc.text(marginLeft, w.y, fontBold, 10, label)
+	w.doc.textRight(marginRight, w.y, fontBold, 10, helper.FormatMoney(amount))
+	w.advance(lineHeight)
+}
+
+// rate drops the padding zeros of a rate read back from the database, such
+// as 15825.5000000000.
+func rate(r string) string {
+	if !strings.Contains(r, ".") {
+		return r
+	}
+	return strings.TrimSuffix(strings.TrimRight(r, "0"), ".")
+}
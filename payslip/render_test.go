@@ -0,0 +1,142 @@
+package payslip_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"self-payrol/model"
+	"self-payrol/money"
+	"self-payrol/payslip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPayslip() *model.Payslip {
+	jakarta := time.FixedZone("WIB", 7*3600)
+	period := model.PayrollPeriodOf(model.PayrollCycleMonthly, time.Date(2026, time.October, 18, 0, 0, 0, 0, jakarta))
+
+	return &model.Payslip{
+		ID:            1,
+		UserID:        1,
+		Period:        period.Code,
+		TransactionID: 12,
+		Number:        "PS/2026-10/1/12",
+		Data: model.PayslipData{
+			CompanyName:    "PT Sejahtera (Selamanya)",
+			CompanyAddress: "Jln. Malioboro 1\nYogyakarta",
+			EmployeeID:     1,
+			EmployeeName:   "Siti Aminah",
+			EmployeeEmail:  "siti@example.com",
+			PositionName:   "CEO",
+			Period:         period.Code,
+			PeriodStart:    period.Start,
+			PeriodEnd:      period.End,
+			IssuedAt:       time.Date(2026, time.October, 31, 17, 0, 0, 0, jakarta),
+			Lines: []model.PayLine{
+				{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(10000000, "IDR"), Taxable: true},
+				{Code: "bpjs_jht", Name: "BPJS JHT employee contribution", Kind: model.PayLineDeduction, Amount: money.New(200000, "IDR")},
+				{Code: "bpjs_jht", Name: "BPJS JHT employer contribution", Kind: model.PayLineEmployer, Amount: money.New(370000, "IDR")},
+				{Code: model.PayCodePPh21, Name: "PPh 21 withholding", Kind: model.PayLineDeduction, Amount: money.New(240000, "IDR")},
+			},
+			Gross:        money.New(10000000, "IDR"),
+			Deductions:   money.New(440000, "IDR"),
+			Net:          money.New(9560000, "IDR"),
+			EmployerCost: money.New(370000, "IDR"),
+			Amount:       money.New(9560000, "IDR"),
+			Withdrawn:    money.New(9560000, "IDR"),
+			Remaining:    money.New(0, "IDR"),
+		},
+	}
+}
+
+func Test_renderer_Render(t *testing.T) {
+	r := payslip.NewRenderer()
+
+	pdf, err := r.Render(testPayslip())
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	assert.Contains(t, string(pdf), "(PT Sejahtera \\(Selamanya\\)) Tj")
+	assert.Contains(t, string(pdf), "(Rp 9.560.000) Tj")
+	assert.Contains(t, string(pdf), "/Count 1")
+	assert.Contains(t, string(pdf), "/CreationDate (D:20261031170000+07'00')")
+}
+
+func Test_renderer_Render_Identical(t *testing.T) {
+	r := payslip.NewRenderer()
+
+	first, err := r.Render(testPayslip())
+	require.NoError(t, err)
+
+	// a payslip read back from the database renders to the same bytes
+	stored, err := json.Marshal(testPayslip())
+	require.NoError(t, err)
+	loaded := new(model.Payslip)
+	require.NoError(t, json.Unmarshal(stored, loaded))
+
+	second, err := r.Render(loaded)
+	require.NoError(t, err)
+
+	assert.True(t, bytes.Equal(first, second), "payslip rendered differently after a round trip")
+}
+
+func Test_renderer_Render_Pages(t *testing.T) {
+	p := testPayslip()
+	for i := 0; i < 60; i++ {
+		p.Data.Lines = append(p.Data.Lines, model.PayLine{Code: model.PayCodeAllowance, Name: "Allowance", Kind: model.PayLineEarning, Amount: money.New(1000, "IDR")})
+	}
+
+	pdf, err := payslip.NewRenderer().Render(p)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(pdf), "/Count 2")
+}
+
+func Test_renderer_Render_Conversion(t *testing.T) {
+	p := testPayslip()
+	p.Data.Conversion = &model.Conversion{
+		Paid:     money.New(60408, "USD"),
+		Debited:  money.New(9559967, "IDR"),
+		Rate:     "15825.5000000000",
+		RateDate: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	pdf, err := payslip.NewRenderer().Render(p)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(pdf), "(Debited from the company in IDR) Tj")
+	assert.Contains(t, string(pdf), "(Rp 9.559.967) Tj")
+	assert.Contains(t, string(pdf), "(15825.5 per USD) Tj")
+}
+
+func Test_renderer_Render_Currency(t *testing.T) {
+	p := testPayslip()
+	p.Data.Lines = []model.PayLine{{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(125050, "USD"), Taxable: true}}
+	p.Data.Gross = money.New(125050, "USD")
+	p.Data.Deductions = money.New(0, "USD")
+	p.Data.Net = money.New(125050, "USD")
+	p.Data.EmployerCost = money.New(0, "USD")
+	p.Data.Amount = money.New(125050, "USD")
+	p.Data.Withdrawn = money.New(125050, "USD")
+	p.Data.Remaining = money.New(0, "USD")
+
+	pdf, err := payslip.NewRenderer().Render(p)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(pdf), "(USD 1.250,50) Tj")
+	assert.NotContains(t, string(pdf), "(Rp ")
+}
+
+func Test_renderer_Render_Rupiah(t *testing.T) {
+	// payslips issued before amounts kept their currency hold bare rupiah
+	loaded := new(model.Payslip)
+	require.NoError(t, json.Unmarshal([]byte(`{"number":"PS/2026-10/1/12","data":{"lines":[],"gross":9560000,"net":9560000,"amount":9560000,"withdrawn":9560000,"remaining":0}}`), loaded))
+
+	pdf, err := payslip.NewRenderer().Render(loaded)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(pdf), "(Rp 9.560.000) Tj")
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"self-payrol/config"
+	"self-payrol/model"
+	"time"
+)
+
+type attendanceRepository struct {
+	Cfg config.Config
+}
+
+func NewAttendanceRepository(cfg config.Config) model.AttendanceRepository {
+	return &attendanceRepository{Cfg: cfg}
+}
+
+func (a *attendanceRepository) Create(ctx context.Context, attendance *model.Attendance) (*model.Attendance, error) {
+	if err := getDB(ctx, a.Cfg).Create(attendance).Error; err != nil {
+		return nil, err
+	}
+	return attendance, nil
+}
+
+func (a *attendanceRepository) FindByDate(ctx context.Context, userID int, date time.Time) (*model.Attendance, error) {
+	attendance := new(model.Attendance)
+
+	if err := getDB(ctx, a.Cfg).
+		Where("user_id = ? AND date = ?", userID, date.Format("2006-01-02")).
+		First(attendance).Error; err != nil {
+		return nil, err
+	}
+	return attendance, nil
+}
+
+func (a *attendanceRepository) UpdateByID(ctx context.Context, id int, attendance *model.Attendance) (*model.Attendance, error) {
+	if err := getDB(ctx, a.Cfg).
+		Model(&model.Attendance{ID: id}).
+		Updates(attendance).Error; err != nil {
+		return nil, err
+	}
+
+	if err := getDB(ctx, a.Cfg).First(attendance, id).Error; err != nil {
+		return nil, err
+	}
+
+	return attendance, nil
+}
+
+func (a *attendanceRepository) FetchByUserID(ctx context.Context, userID int, from, to time.Time) ([]*model.Attendance, error) {
+	var data []*model.Attendance
+
+	if err := getDB(ctx, a.Cfg).
+		Where("user_id = ? AND date >= ? AND date < ?", userID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("date desc").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"self-payrol/config"
+	"self-payrol/model"
+
+	"gorm.io/gorm/clause"
+)
+
+type bpjsRateRepository struct {
+	Cfg config.Config
+}
+
+func NewBPJSRateRepository(cfg config.Config) model.BPJSRateRepository {
+	return &bpjsRateRepository{Cfg: cfg}
+}
+
+func (b *bpjsRateRepository) FetchByCompanyID(ctx context.Context, companyID int) ([]*model.BPJSRate, error) {
+	var data []*model.BPJSRate
+
+	if err := getDB(ctx, b.Cfg).
+		Where("company_id = ?", companyID).
+		Order("id").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (b *bpjsRateRepository) Upsert(ctx context.Context, rates []*model.BPJSRate) ([]*model.BPJSRate, error) {
+	if err := getDB(ctx, b.Cfg).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "company_id"}, {Name: "program"}},
+			DoUpdates: clause.AssignmentColumns([]string{"employee_rate_bps", "employer_rate_bps", "wage_cap_amount", "wage_cap_currency", "updated_at"}),
+		}).
+		Create(&rates).Error; err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+type bpjsContributionRepository struct {
+	Cfg config.Config
+}
+
+func NewBPJSContributionRepository(cfg config.Config) model.BPJSContributionRepository {
+	return &bpjsContributionRepository{Cfg: cfg}
+}
+
+func (b *bpjsContributionRepository) CreateMany(ctx context.Context, contributions []*model.BPJSContribution) error {
+	if len(contributions) == 0 {
+		return nil
+	}
+
+	// a period settled again, e.g. for late overtime, is not owed twice
+	if err := getDB(ctx, b.Cfg).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&contributions).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (b *bpjsContributionRepository) FetchByMonth(ctx context.Context, month string) ([]*model.BPJSContribution, error) {
+	var data []*model.BPJSContribution
+
+	if err := getDB(ctx, b.Cfg).
+		Preload("User").
+		Where("period = ? OR period LIKE ?", month, month+"-%").
+		Order("user_id, period, id").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (b *bpjsContributionRepository) LockUnpaidByMonth(ctx context.Context, month string) ([]*model.BPJSContribution, error) {
+	var data []*model.BPJSContribution
+
+	if err := getDB(ctx, b.Cfg).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("(period = ? OR period LIKE ?) AND paid_transaction_id IS NULL", month, month+"-%").
+		Order("id").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (b *bpjsContributionRepository) MarkPaid(ctx context.Context, ids []int, transactionID int) error {
+	if err := getDB(ctx, b.Cfg).
+		Model(&model.BPJSContribution{}).
+		Where("id IN ? AND paid_transaction_id IS NULL", ids).
+		Update("paid_transaction_id", transactionID).Error; err != nil {
+		return err
+	}
+	return nil
+}
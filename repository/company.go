@@ -3,10 +3,13 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"self-payrol/config"
 	"self-payrol/model"
+	"self-payrol/money"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type companyRepository struct {
@@ -20,7 +23,7 @@ func NewCompanyRepository(cfg config.Config) model.CompanyRepository {
 func (c *companyRepository) Get(ctx context.Context) (*model.Company, error) {
 	company := new(model.Company)
 
-	if err := c.Cfg.Database().WithContext(ctx).First(company).Error; err != nil {
+	if err := getDB(ctx, c.Cfg).First(company).Error; err != nil {
 		return nil, err
 	}
 
@@ -31,10 +34,26 @@ func (c *companyRepository) CreateOrUpdate(ctx context.Context, company *model.C
 
 	companyModel := new(model.Company)
 
-	if err := c.Cfg.Database().WithContext(ctx).Debug().
+	if err := getDB(ctx, c.Cfg).Debug().
 		First(&companyModel).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			if err := c.Cfg.Database().WithContext(ctx).Create(&company).Find(companyModel).Error; err != nil {
+			// the opening balance is recorded like a top-up
+			err := getDB(ctx, c.Cfg).Transaction(func(tx *gorm.DB) error {
+				if err := tx.Create(&company).Find(companyModel).Error; err != nil {
+					return err
+				}
+				if company.Balance.IsZero() {
+					return nil
+				}
+
+				return tx.Create(&model.Transaction{
+					Amount:   company.Balance,
+					Note:     "Opening balance company",
+					Type:     model.TransactionsTypeCredit,
+					Category: model.TransactionCategoryTopUp,
+				}).Error
+			})
+			if err != nil {
 				return nil, err
 			}
 
@@ -43,62 +62,147 @@ func (c *companyRepository) CreateOrUpdate(ctx context.Context, company *model.C
 		return nil, err
 	}
 
+	// everything already paid and owed is in the balance currency
+	if company.Balance.Currency != "" && company.Balance.Currency != companyModel.Balance.Currency {
+		return nil, fmt.Errorf("%w: it is in %s", model.ErrBalanceCurrencyChanged, companyModel.Balance.Currency)
+	}
+
 	// TODO(Rakamin): tuliskan baris code untuk update data company
-	if err := c.Cfg.Database().WithContext(ctx).Debug().
-		Model(&companyModel).
-		Where("id = ?", company.ID).
-		Updates(company).
-		Find(company).Error; err != nil {
+	// the balance only moves through AddBalance, DebitBalance and
+	// CreditBalance, which lock it and record the transaction
+	if err := getDB(ctx, c.Cfg).Debug().
+		Model(companyModel).
+		Omit("balance_amount", "balance_currency").
+		Updates(company).Error; err != nil {
+		return nil, err
+	}
+
+	if err := getDB(ctx, c.Cfg).First(companyModel, companyModel.ID).Error; err != nil {
 		return nil, err
 	}
 	//EOL
 	return companyModel, nil
 }
 
-func (c *companyRepository) DebitBalance(ctx context.Context, amount int, note string) error {
-	company, err := c.Get(ctx)
+func (c *companyRepository) DebitBalance(ctx context.Context, trx *model.Transaction) (*model.Transaction, error) {
+	err := getDB(ctx, c.Cfg).Transaction(func(tx *gorm.DB) error {
+		company, err := lockCompany(tx)
+		if err != nil {
+			return err
+		}
+
+		// TODO(Rakamin): tuliskan baris code untuk mengurangi balance
+		balance, err := company.Balance.Sub(trx.Amount)
+		if err != nil {
+			return err
+		}
+		if balance.IsNegative() {
+			return model.ErrInsufficientBalance
+		}
+
+		if err := tx.Model(company).
+			Update("balance_amount", balance.Amount).Error; err != nil {
+			return err
+		}
+		//EOL
+
+		trx.Type = model.TransactionTypeDebit
+		if err := tx.Create(trx).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
 	if err != nil {
-		return errors.New("company data not found")
+		return nil, err
 	}
 
-	// TODO(Rakamin): tuliskan baris code untuk mengurangi balance
-	company.Balance -= amount
-	//EOL
-	if err := c.Cfg.Database().WithContext(ctx).Model(company).Updates(company).Find(company).Error; err != nil {
-		return err
+	return trx, nil
+}
 
-	}
+func (c *companyRepository) CreditBalance(ctx context.Context, trx *model.Transaction) (*model.Transaction, error) {
+	err := getDB(ctx, c.Cfg).Transaction(func(tx *gorm.DB) error {
+		company, err := lockCompany(tx)
+		if err != nil {
+			return err
+		}
+
+		balance, err := company.Balance.Add(trx.Amount)
+		if err != nil {
+			return err
+		}
 
-	if err := c.Cfg.Database().WithContext(ctx).Create(&model.Transaction{
-		Amount: amount,
-		Note:   note,
-		Type:   model.TransactionTypeDebit,
-	}).Error; err != nil {
-		return err
+		if err := tx.Model(company).
+			Update("balance_amount", balance.Amount).Error; err != nil {
+			return err
+		}
+
+		trx.Type = model.TransactionsTypeCredit
+		return tx.Create(trx).Error
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return trx, nil
 }
 
-func (c *companyRepository) AddBalance(ctx context.Context, balance int) (*model.Company, error) {
-	company, err := c.Get(ctx)
+func (c *companyRepository) AddBalance(ctx context.Context, amount money.Money) (*model.Company, error) {
+	company := new(model.Company)
+
+	err := getDB(ctx, c.Cfg).Transaction(func(tx *gorm.DB) error {
+		locked, err := lockCompany(tx)
+		if err != nil {
+			return err
+		}
+
+		// TODO(Rakamin): tuliskan baris code untuk topup balance
+		balance, err := locked.Balance.Add(amount)
+		if err != nil {
+			return err
+		}
+		if balance.IsNegative() {
+			return model.ErrInsufficientBalance
+		}
+
+		if err := tx.Model(locked).
+			Update("balance_amount", balance.Amount).Error; err != nil {
+			return err
+		}
+		//EOL
+
+		if err := tx.First(company, locked.ID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&model.Transaction{
+			Amount:   amount,
+			Note:     "Topup balance company",
+			Type:     model.TransactionsTypeCredit,
+			Category: model.TransactionCategoryTopUp,
+		}).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
 	if err != nil {
-		return nil, errors.New("company data not found")
+		return nil, err
 	}
 
-	// TODO(Rakamin): tuliskan baris code untuk topup balance
-	company.Balance += balance
-	//EOL
-	if err := c.Cfg.Database().WithContext(ctx).Model(company).Updates(company).Find(company).Error; err != nil {
-		return nil, err
+	return company, nil
+}
 
-	}
+// lockCompany reads the company row with SELECT ... FOR UPDATE so concurrent
+// balance changes queue up behind the current transaction.
+func lockCompany(tx *gorm.DB) (*model.Company, error) {
+	company := new(model.Company)
 
-	if err := c.Cfg.Database().WithContext(ctx).Create(&model.Transaction{
-		Amount: balance,
-		Note:   "Topup balance company",
-		Type:   model.TransactionsTypeCredit,
-	}).Error; err != nil {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(company).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("company data not found")
+		}
 		return nil, err
 	}
 
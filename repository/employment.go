@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"self-payrol/config"
+	"self-payrol/model"
+)
+
+type employmentEventRepository struct {
+	Cfg config.Config
+}
+
+func NewEmploymentEventRepository(cfg config.Config) model.EmploymentEventRepository {
+	return &employmentEventRepository{Cfg: cfg}
+}
+
+func (e *employmentEventRepository) Create(ctx context.Context, event *model.EmploymentEvent) (*model.EmploymentEvent, error) {
+	if err := getDB(ctx, e.Cfg).Create(event).Error; err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (e *employmentEventRepository) FetchByUserID(ctx context.Context, userID int) ([]*model.EmploymentEvent, error) {
+	var data []*model.EmploymentEvent
+
+	if err := getDB(ctx, e.Cfg).
+		Where("user_id = ?", userID).
+		Order("created_at desc, id desc").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"self-payrol/config"
+	"self-payrol/model"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+type exchangeRateRepository struct {
+	Cfg config.Config
+}
+
+func NewExchangeRateRepository(cfg config.Config) model.ExchangeRateRepository {
+	return &exchangeRateRepository{Cfg: cfg}
+}
+
+func (e *exchangeRateRepository) Upsert(ctx context.Context, rate *model.ExchangeRate) (*model.ExchangeRate, error) {
+	if err := getDB(ctx, e.Cfg).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "currency"}, {Name: "quote_currency"}, {Name: "effective_from"}},
+			DoUpdates: clause.AssignmentColumns([]string{"rate", "updated_at"}),
+		}).
+		Create(rate).Error; err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+func (e *exchangeRateRepository) Fetch(ctx context.Context, currency string, limit, offset int) ([]*model.ExchangeRate, error) {
+	var data []*model.ExchangeRate
+
+	query := getDB(ctx, e.Cfg)
+	if currency != "" {
+		query = query.Where("currency = ?", currency)
+	}
+
+	if err := query.
+		Order("effective_from desc").Order("currency").
+		Limit(limit).Offset(offset).
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (e *exchangeRateRepository) FindEffective(ctx context.Context, currency, quoteCurrency string, t time.Time) (*model.ExchangeRate, error) {
+	rate := new(model.ExchangeRate)
+
+	if err := getDB(ctx, e.Cfg).
+		Where("currency = ? AND quote_currency = ? AND effective_from <= ?", currency, quoteCurrency, t.Format("2006-01-02")).
+		Order("effective_from desc").
+		First(rate).Error; err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
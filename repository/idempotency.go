@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"self-payrol/config"
+	"self-payrol/model"
+
+	"gorm.io/gorm/clause"
+)
+
+type idempotencyRepository struct {
+	Cfg config.Config
+}
+
+func NewIdempotencyRepository(cfg config.Config) model.IdempotencyRepository {
+	return &idempotencyRepository{Cfg: cfg}
+}
+
+func (i *idempotencyRepository) Create(ctx context.Context, key *model.IdempotencyKey) (*model.IdempotencyKey, error) {
+	res := getDB(ctx, i.Cfg).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(key)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return nil, model.ErrIdempotencyKeyExists
+	}
+
+	return key, nil
+}
+
+func (i *idempotencyRepository) FindByKey(ctx context.Context, key string) (*model.IdempotencyKey, error) {
+	idempotencyKey := new(model.IdempotencyKey)
+
+	if err := getDB(ctx, i.Cfg).
+		Where("key = ?", key).
+		First(idempotencyKey).Error; err != nil {
+		return nil, err
+	}
+	return idempotencyKey, nil
+}
+
+func (i *idempotencyRepository) UpdateByKey(ctx context.Context, key string, idempotencyKey *model.IdempotencyKey) error {
+	if err := getDB(ctx, i.Cfg).
+		Model(&model.IdempotencyKey{}).
+		Where("key = ?", key).
+		Updates(idempotencyKey).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i *idempotencyRepository) DeleteByKey(ctx context.Context, key string) error {
+	if err := getDB(ctx, i.Cfg).
+		Where("key = ?", key).
+		Delete(&model.IdempotencyKey{}).Error; err != nil {
+		return err
+	}
+	return nil
+}
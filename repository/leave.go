@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"self-payrol/config"
+	"self-payrol/model"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+type leaveRepository struct {
+	Cfg config.Config
+}
+
+func NewLeaveRepository(cfg config.Config) model.LeaveRepository {
+	return &leaveRepository{Cfg: cfg}
+}
+
+func (l *leaveRepository) Create(ctx context.Context, leave *model.Leave) (*model.Leave, error) {
+	if err := getDB(ctx, l.Cfg).Create(leave).Error; err != nil {
+		return nil, err
+	}
+	return leave, nil
+}
+
+func (l *leaveRepository) FindByID(ctx context.Context, userID, id int) (*model.Leave, error) {
+	leave := new(model.Leave)
+
+	if err := getDB(ctx, l.Cfg).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(leave).Error; err != nil {
+		return nil, err
+	}
+	return leave, nil
+}
+
+func (l *leaveRepository) Lock(ctx context.Context, userID, id int) (*model.Leave, error) {
+	leave := new(model.Leave)
+
+	if err := getDB(ctx, l.Cfg).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(leave).Error; err != nil {
+		return nil, err
+	}
+	return leave, nil
+}
+
+func (l *leaveRepository) UpdateByID(ctx context.Context, id int, leave *model.Leave) (*model.Leave, error) {
+	if err := getDB(ctx, l.Cfg).
+		Model(&model.Leave{ID: id}).
+		Updates(leave).Error; err != nil {
+		return nil, err
+	}
+
+	if err := getDB(ctx, l.Cfg).First(leave, id).Error; err != nil {
+		return nil, err
+	}
+
+	return leave, nil
+}
+
+func (l *leaveRepository) FetchByUserID(ctx context.Context, userID int) ([]*model.Leave, error) {
+	var data []*model.Leave
+
+	if err := getDB(ctx, l.Cfg).
+		Where("user_id = ?", userID).
+		Order("start_date desc, id desc").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (l *leaveRepository) FetchBetween(ctx context.Context, userID int, from, to time.Time, statuses ...string) ([]*model.Leave, error) {
+	var data []*model.Leave
+
+	if err := getDB(ctx, l.Cfg).
+		Where("user_id = ? AND status IN ? AND start_date < ? AND end_date >= ?",
+			userID, statuses, to.Format("2006-01-02"), from.Format("2006-01-02")).
+		Order("start_date, id").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
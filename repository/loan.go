@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"self-payrol/config"
+	"self-payrol/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type loanRepository struct {
+	Cfg config.Config
+}
+
+func NewLoanRepository(cfg config.Config) model.LoanRepository {
+	return &loanRepository{Cfg: cfg}
+}
+
+func (l *loanRepository) Create(ctx context.Context, loan *model.Loan) (*model.Loan, error) {
+	if err := getDB(ctx, l.Cfg).Create(loan).Error; err != nil {
+		return nil, err
+	}
+	return loan, nil
+}
+
+func (l *loanRepository) FindByID(ctx context.Context, userID, id int) (*model.Loan, error) {
+	loan := new(model.Loan)
+
+	if err := getDB(ctx, l.Cfg).
+		Preload("Repayments", orderByID).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(loan).Error; err != nil {
+		return nil, err
+	}
+	return loan, nil
+}
+
+func (l *loanRepository) Lock(ctx context.Context, id int) (*model.Loan, error) {
+	loan := new(model.Loan)
+
+	if err := getDB(ctx, l.Cfg).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(loan, id).Error; err != nil {
+		return nil, err
+	}
+	return loan, nil
+}
+
+func (l *loanRepository) UpdateByID(ctx context.Context, id int, loan *model.Loan) (*model.Loan, error) {
+	// outstanding reaches zero on the last installment
+	if err := getDB(ctx, l.Cfg).
+		Model(&model.Loan{ID: id}).
+		Select("outstanding_amount", "outstanding_currency", "status", "transaction_id", "repaid_at").
+		Updates(loan).Error; err != nil {
+		return nil, err
+	}
+
+	updated := new(model.Loan)
+	if err := getDB(ctx, l.Cfg).First(updated, id).Error; err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (l *loanRepository) FetchByUserID(ctx context.Context, userID int) ([]*model.Loan, error) {
+	var data []*model.Loan
+
+	if err := getDB(ctx, l.Cfg).
+		Where("user_id = ?", userID).
+		Order("id desc").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (l *loanRepository) FetchDue(ctx context.Context, userID int, period model.PayrollPeriod) ([]*model.Loan, error) {
+	var data []*model.Loan
+
+	db := getDB(ctx, l.Cfg)
+	repaid := db.Session(&gorm.Session{NewDB: true}).
+		Model(&model.LoanRepayment{}).
+		Select("loan_id").
+		Where("period = ?", period.Code)
+
+	if err := db.
+		Where("user_id = ? AND status = ? AND first_due < ?", userID, model.LoanStatusActive, period.End).
+		Where("id NOT IN (?)", repaid).
+		Order("id").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (l *loanRepository) FetchInstallmentsIn(ctx context.Context, userID int, period string) ([]*model.LoanRepayment, error) {
+	var data []*model.LoanRepayment
+
+	db := getDB(ctx, l.Cfg)
+	loans := db.Session(&gorm.Session{NewDB: true}).
+		Model(&model.Loan{}).
+		Select("id").
+		Where("user_id = ?", userID)
+
+	if err := db.
+		Where("kind = ? AND period = ? AND loan_id IN (?)", model.LoanRepaymentInstallment, period, loans).
+		Order("loan_id, id").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (l *loanRepository) CreateRepayment(ctx context.Context, repayment *model.LoanRepayment) (*model.LoanRepayment, error) {
+	if err := getDB(ctx, l.Cfg).Create(repayment).Error; err != nil {
+		return nil, err
+	}
+	return repayment, nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"self-payrol/config"
+	"self-payrol/model"
+	"time"
+)
+
+type overtimeRepository struct {
+	Cfg config.Config
+}
+
+func NewOvertimeRepository(cfg config.Config) model.OvertimeRepository {
+	return &overtimeRepository{Cfg: cfg}
+}
+
+func (o *overtimeRepository) Create(ctx context.Context, overtime *model.Overtime) (*model.Overtime, error) {
+	if err := getDB(ctx, o.Cfg).Create(overtime).Error; err != nil {
+		return nil, err
+	}
+	return overtime, nil
+}
+
+func (o *overtimeRepository) FindByID(ctx context.Context, userID, id int) (*model.Overtime, error) {
+	overtime := new(model.Overtime)
+
+	if err := getDB(ctx, o.Cfg).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(overtime).Error; err != nil {
+		return nil, err
+	}
+	return overtime, nil
+}
+
+func (o *overtimeRepository) UpdateByID(ctx context.Context, id int, overtime *model.Overtime) (*model.Overtime, error) {
+	if err := getDB(ctx, o.Cfg).
+		Model(&model.Overtime{ID: id}).
+		Updates(overtime).Error; err != nil {
+		return nil, err
+	}
+
+	if err := getDB(ctx, o.Cfg).First(overtime, id).Error; err != nil {
+		return nil, err
+	}
+
+	return overtime, nil
+}
+
+func (o *overtimeRepository) Delete(ctx context.Context, userID, id int) error {
+	if err := getDB(ctx, o.Cfg).
+		Where("user_id = ?", userID).
+		Delete(&model.Overtime{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *overtimeRepository) FetchByUserID(ctx context.Context, userID int) ([]*model.Overtime, error) {
+	var data []*model.Overtime
+
+	if err := getDB(ctx, o.Cfg).
+		Where("user_id = ?", userID).
+		Order("date desc, id desc").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (o *overtimeRepository) FetchPayable(ctx context.Context, userID int, before time.Time) ([]*model.Overtime, error) {
+	var data []*model.Overtime
+
+	if err := getDB(ctx, o.Cfg).
+		Where("user_id = ? AND status = ? AND paid_transaction_id IS NULL AND date < ?", userID, model.OvertimeStatusApproved, before).
+		Order("date, id").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (o *overtimeRepository) FetchPaidIn(ctx context.Context, userID int, period string) ([]*model.Overtime, error) {
+	var data []*model.Overtime
+
+	db := getDB(ctx, o.Cfg)
+	if err := db.
+		Where("user_id = ? AND paid_transaction_id IN (?)", userID, salaryPaidIn(db, userID, period)).
+		Order("date, id").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (o *overtimeRepository) MarkPaid(ctx context.Context, ids []int, transactionID int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := getDB(ctx, o.Cfg).
+		Model(&model.Overtime{}).
+		Where("id IN ? AND paid_transaction_id IS NULL", ids).
+		Update("paid_transaction_id", transactionID).Error; err != nil {
+		return err
+	}
+	return nil
+}
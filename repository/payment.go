@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"self-payrol/config"
+	"self-payrol/model"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+type paymentRepository struct {
+	Cfg config.Config
+}
+
+func NewPaymentRepository(cfg config.Config) model.PaymentRepository {
+	return &paymentRepository{Cfg: cfg}
+}
+
+func (p *paymentRepository) Create(ctx context.Context, payment *model.Payment) (*model.Payment, error) {
+	if err := getDB(ctx, p.Cfg).Create(payment).Error; err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+func (p *paymentRepository) FindByID(ctx context.Context, userID, id int) (*model.Payment, error) {
+	payment := new(model.Payment)
+
+	if err := getDB(ctx, p.Cfg).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(payment).Error; err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+func (p *paymentRepository) Lock(ctx context.Context, userID, id int) (*model.Payment, error) {
+	payment := new(model.Payment)
+
+	if err := getDB(ctx, p.Cfg).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(payment).Error; err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
+
+func (p *paymentRepository) UpdateByID(ctx context.Context, id int, payment *model.Payment) (*model.Payment, error) {
+	if err := getDB(ctx, p.Cfg).
+		Model(&model.Payment{ID: id}).
+		Updates(payment).Error; err != nil {
+		return nil, err
+	}
+
+	if err := getDB(ctx, p.Cfg).First(payment, id).Error; err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+func (p *paymentRepository) Delete(ctx context.Context, userID, id int) error {
+	if err := getDB(ctx, p.Cfg).
+		Where("user_id = ?", userID).
+		Delete(&model.Payment{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *paymentRepository) FetchByUserID(ctx context.Context, userID int) ([]*model.Payment, error) {
+	var data []*model.Payment
+
+	if err := getDB(ctx, p.Cfg).
+		Where("user_id = ?", userID).
+		Order("due_from desc, id desc").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (p *paymentRepository) FetchPayable(ctx context.Context, userID int, before time.Time) ([]*model.Payment, error) {
+	var data []*model.Payment
+
+	if err := getDB(ctx, p.Cfg).
+		Where("user_id = ? AND status = ? AND disbursement = ? AND paid_transaction_id IS NULL AND due_from < ?",
+			userID, model.PaymentStatusApproved, model.PaymentWithSalary, before).
+		Order("due_from, id").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (p *paymentRepository) FetchPaidIn(ctx context.Context, userID int, period string) ([]*model.Payment, error) {
+	var data []*model.Payment
+
+	db := getDB(ctx, p.Cfg)
+	if err := db.
+		Where("user_id = ? AND paid_transaction_id IN (?)", userID, salaryPaidIn(db, userID, period)).
+		Order("due_from, id").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (p *paymentRepository) MarkPaid(ctx context.Context, ids []int, transactionID int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := getDB(ctx, p.Cfg).
+		Model(&model.Payment{}).
+		Where("id IN ? AND paid_transaction_id IS NULL", ids).
+		Updates(map[string]interface{}{
+			"status":              model.PaymentStatusPaid,
+			"paid_transaction_id": transactionID,
+		}).Error; err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"self-payrol/config"
+	"self-payrol/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type payrollRunRepository struct {
+	Cfg config.Config
+}
+
+func NewPayrollRunRepository(cfg config.Config) model.PayrollRunRepository {
+	return &payrollRunRepository{Cfg: cfg}
+}
+
+func (p *payrollRunRepository) Create(ctx context.Context, run *model.PayrollRun) (*model.PayrollRun, error) {
+	if err := getDB(ctx, p.Cfg).Create(run).Error; err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+func (p *payrollRunRepository) UpdateByID(ctx context.Context, id int, run *model.PayrollRun) (*model.PayrollRun, error) {
+	// totals can drop to zero, so the columns are written whatever their value
+	if err := getDB(ctx, p.Cfg).
+		Model(&model.PayrollRun{ID: id}).
+		Select("status", "submitted_by", "approved_by", "total_amount", "total_currency", "paid", "skipped", "disbursed_at").
+		Updates(run).Error; err != nil {
+		return nil, err
+	}
+
+	updated := new(model.PayrollRun)
+	if err := getDB(ctx, p.Cfg).First(updated, id).Error; err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+func (p *payrollRunRepository) UpdateItem(ctx context.Context, item *model.PayrollRunItem) (*model.PayrollRunItem, error) {
+	if err := getDB(ctx, p.Cfg).
+		Model(&model.PayrollRunItem{ID: item.ID}).
+		Select("amount", "currency", "status", "transaction_id", "payslip", "reason", "conversion").
+		Updates(item).Error; err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (p *payrollRunRepository) CreateEvent(ctx context.Context, event *model.PayrollRunEvent) (*model.PayrollRunEvent, error) {
+	if err := getDB(ctx, p.Cfg).Create(event).Error; err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (p *payrollRunRepository) FindByID(ctx context.Context, id int) (*model.PayrollRun, error) {
+	run := new(model.PayrollRun)
+
+	if err := getDB(ctx, p.Cfg).
+		Preload("Items", orderByID).
+		Preload("Events", orderByID).
+		First(run, id).Error; err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+func (p *payrollRunRepository) Lock(ctx context.Context, id int) (*model.PayrollRun, error) {
+	run := new(model.PayrollRun)
+
+	if err := getDB(ctx, p.Cfg).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Items", orderByID).
+		First(run, id).Error; err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+func (p *payrollRunRepository) Fetch(ctx context.Context, limit, offset int) ([]*model.PayrollRun, error) {
+	var data []*model.PayrollRun
+
+	if err := getDB(ctx, p.Cfg).
+		Order("id desc").
+		Limit(limit).Offset(offset).
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func orderByID(db *gorm.DB) *gorm.DB {
+	return db.Order("id")
+}
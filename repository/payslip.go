@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"self-payrol/config"
+	"self-payrol/model"
+)
+
+type payslipRepository struct {
+	Cfg config.Config
+}
+
+func NewPayslipRepository(cfg config.Config) model.PayslipRepository {
+	return &payslipRepository{Cfg: cfg}
+}
+
+func (p *payslipRepository) Create(ctx context.Context, payslip *model.Payslip) (*model.Payslip, error) {
+	if err := getDB(ctx, p.Cfg).Create(payslip).Error; err != nil {
+		return nil, err
+	}
+
+	return payslip, nil
+}
+
+func (p *payslipRepository) FindLatest(ctx context.Context, userID int, period string) (*model.Payslip, error) {
+	payslip := new(model.Payslip)
+
+	if err := getDB(ctx, p.Cfg).
+		Where("user_id = ? AND period = ?", userID, period).
+		Order("id desc").
+		First(payslip).Error; err != nil {
+		return nil, err
+	}
+
+	return payslip, nil
+}
+
+func (p *payslipRepository) FetchByUserID(ctx context.Context, userID int) ([]*model.Payslip, error) {
+	var data []*model.Payslip
+
+	if err := getDB(ctx, p.Cfg).
+		Where("user_id = ?", userID).
+		Order("id desc").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
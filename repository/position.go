@@ -17,8 +17,7 @@ func NewPositionRepository(cfg config.Config) model.PositionRepository {
 func (p *positionRepository) FindByID(ctx context.Context, id int) (*model.Position, error) {
 	position := new(model.Position)
 
-	if err := p.Cfg.Database().
-		WithContext(ctx).
+	if err := getDB(ctx, p.Cfg).
 		Where("id = ?", id).
 		First(position).Error; err != nil {
 		return nil, err
@@ -27,15 +26,18 @@ func (p *positionRepository) FindByID(ctx context.Context, id int) (*model.Posit
 }
 
 func (p *positionRepository) Create(ctx context.Context, position *model.Position) (*model.Position, error) {
-	if err := p.Cfg.Database().WithContext(ctx).Create(&position).Error; err != nil {
+	if err := getDB(ctx, p.Cfg).Create(&position).Error; err != nil {
 		return nil, err
 	}
 	return position, nil
 }
 
 func (p *positionRepository) UpdateByID(ctx context.Context, id int, position *model.Position) (*model.Position, error) {
-	if err := p.Cfg.Database().WithContext(ctx).
-		Model(&model.Position{ID: id}).Updates(position).Find(position).Error; err != nil {
+	// the salary band is written explicitly so it can be cleared
+	if err := getDB(ctx, p.Cfg).
+		Model(&model.Position{ID: id}).
+		Select("name", "salary_amount", "salary_currency", "min_salary_amount", "min_salary_currency", "max_salary_amount", "max_salary_currency", "pay_currency").
+		Updates(position).Find(position).Error; err != nil {
 		return nil, err
 	}
 
@@ -50,7 +52,7 @@ func (p *positionRepository) Delete(ctx context.Context, id int) error {
 		return err
 	}
 
-	if err := p.Cfg.Database().WithContext(ctx).
+	if err := getDB(ctx, p.Cfg).
 		Delete(&model.Position{}, id).Error; err != nil {
 		return err
 	}
@@ -63,7 +65,7 @@ func (p *positionRepository) Fetch(ctx context.Context, limit, offset int) ([]*m
 	// TODO(Rakamin): Buat fungsi untuk mendapatkan data position berdasarkan parameter
 	var data []*model.Position
 
-	if err := p.Cfg.Database().WithContext(ctx).
+	if err := getDB(ctx, p.Cfg).
 		Limit(limit).
 		Offset(offset).
 		Find(&data).Error; err != nil {
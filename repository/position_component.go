@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"self-payrol/config"
+	"self-payrol/model"
+)
+
+type positionComponentRepository struct {
+	Cfg config.Config
+}
+
+func NewPositionComponentRepository(cfg config.Config) model.PositionComponentRepository {
+	return &positionComponentRepository{Cfg: cfg}
+}
+
+func (p *positionComponentRepository) FindByID(ctx context.Context, positionID, id int) (*model.PositionComponent, error) {
+	component := new(model.PositionComponent)
+
+	if err := getDB(ctx, p.Cfg).
+		Where("id = ? AND position_id = ?", id, positionID).
+		First(component).Error; err != nil {
+		return nil, err
+	}
+	return component, nil
+}
+
+func (p *positionComponentRepository) Create(ctx context.Context, component *model.PositionComponent) (*model.PositionComponent, error) {
+	if err := getDB(ctx, p.Cfg).Create(&component).Error; err != nil {
+		return nil, err
+	}
+	return component, nil
+}
+
+func (p *positionComponentRepository) UpdateByID(ctx context.Context, id int, component *model.PositionComponent) (*model.PositionComponent, error) {
+	// taxable is written explicitly because Updates skips false
+	if err := getDB(ctx, p.Cfg).
+		Model(&model.PositionComponent{ID: id}).
+		Select("name", "type", "amount", "currency", "taxable").
+		Updates(component).Error; err != nil {
+		return nil, err
+	}
+
+	if err := getDB(ctx, p.Cfg).First(component, id).Error; err != nil {
+		return nil, err
+	}
+
+	return component, nil
+}
+
+func (p *positionComponentRepository) Delete(ctx context.Context, positionID, id int) error {
+	_, err := p.FindByID(ctx, positionID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := getDB(ctx, p.Cfg).
+		Delete(&model.PositionComponent{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *positionComponentRepository) FetchByPositionID(ctx context.Context, positionID int) ([]*model.PositionComponent, error) {
+	var data []*model.PositionComponent
+
+	if err := getDB(ctx, p.Cfg).
+		Where("position_id = ?", positionID).
+		Order("id").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
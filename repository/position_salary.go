@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"self-payrol/config"
+	"self-payrol/model"
+
+	"gorm.io/gorm/clause"
+)
+
+type positionSalaryRepository struct {
+	Cfg config.Config
+}
+
+func NewPositionSalaryRepository(cfg config.Config) model.PositionSalaryRepository {
+	return &positionSalaryRepository{Cfg: cfg}
+}
+
+func (p *positionSalaryRepository) Upsert(ctx context.Context, salary *model.PositionSalary) (*model.PositionSalary, error) {
+	if err := getDB(ctx, p.Cfg).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "position_id"}, {Name: "effective_from"}},
+			DoUpdates: clause.AssignmentColumns([]string{"salary_amount", "salary_currency", "updated_at"}),
+		}).
+		Create(salary).Error; err != nil {
+		return nil, err
+	}
+	return salary, nil
+}
+
+func (p *positionSalaryRepository) FetchByPositionID(ctx context.Context, positionID int) ([]*model.PositionSalary, error) {
+	var data []*model.PositionSalary
+
+	if err := getDB(ctx, p.Cfg).
+		Where("position_id = ?", positionID).
+		Order("effective_from desc").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"self-payrol/config"
+	"self-payrol/model"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+type reimbursementRepository struct {
+	Cfg config.Config
+}
+
+func NewReimbursementRepository(cfg config.Config) model.ReimbursementRepository {
+	return &reimbursementRepository{Cfg: cfg}
+}
+
+func (r *reimbursementRepository) Create(ctx context.Context, claim *model.Reimbursement) (*model.Reimbursement, error) {
+	if err := getDB(ctx, r.Cfg).Create(claim).Error; err != nil {
+		return nil, err
+	}
+
+	claim.Receipt = nil
+	return claim, nil
+}
+
+func (r *reimbursementRepository) FindByID(ctx context.Context, userID, id int) (*model.Reimbursement, error) {
+	claim := new(model.Reimbursement)
+
+	if err := getDB(ctx, r.Cfg).
+		Omit("receipt").
+		Where("id = ? AND user_id = ?", id, userID).
+		First(claim).Error; err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+func (r *reimbursementRepository) FindReceipt(ctx context.Context, userID, id int) (*model.Reimbursement, error) {
+	claim := new(model.Reimbursement)
+
+	if err := getDB(ctx, r.Cfg).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(claim).Error; err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+func (r *reimbursementRepository) Lock(ctx context.Context, userID, id int) (*model.Reimbursement, error) {
+	claim := new(model.Reimbursement)
+
+	if err := getDB(ctx, r.Cfg).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Omit("receipt").
+		Where("id = ? AND user_id = ?", id, userID).
+		First(claim).Error; err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+func (r *reimbursementRepository) UpdateByID(ctx context.Context, id int, claim *model.Reimbursement) (*model.Reimbursement, error) {
+	if err := getDB(ctx, r.Cfg).
+		Model(&model.Reimbursement{ID: id}).
+		Updates(claim).Error; err != nil {
+		return nil, err
+	}
+
+	if err := getDB(ctx, r.Cfg).Omit("receipt").First(claim, id).Error; err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+func (r *reimbursementRepository) Delete(ctx context.Context, userID, id int) error {
+	if err := getDB(ctx, r.Cfg).
+		Where("user_id = ?", userID).
+		Delete(&model.Reimbursement{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *reimbursementRepository) FetchByUserID(ctx context.Context, userID int) ([]*model.Reimbursement, error) {
+	var data []*model.Reimbursement
+
+	if err := getDB(ctx, r.Cfg).
+		Omit("receipt").
+		Where("user_id = ?", userID).
+		Order("id desc").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (r *reimbursementRepository) FetchPayable(ctx context.Context, userID int, before time.Time) ([]*model.Reimbursement, error) {
+	var data []*model.Reimbursement
+
+	if err := getDB(ctx, r.Cfg).
+		Omit("receipt").
+		Where("user_id = ? AND status = ? AND disbursement = ? AND paid_transaction_id IS NULL AND reviewed_at < ?",
+			userID, model.ReimbursementStatusApproved, model.ReimbursementWithSalary, before).
+		Order("reviewed_at, id").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (r *reimbursementRepository) FetchPaidIn(ctx context.Context, userID int, period string) ([]*model.Reimbursement, error) {
+	var data []*model.Reimbursement
+
+	db := getDB(ctx, r.Cfg)
+	if err := db.
+		Omit("receipt").
+		Where("user_id = ? AND paid_transaction_id IN (?)", userID, salaryPaidIn(db, userID, period)).
+		Order("reviewed_at, id").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (r *reimbursementRepository) MarkPaid(ctx context.Context, ids []int, transactionID int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := getDB(ctx, r.Cfg).
+		Model(&model.Reimbursement{}).
+		Where("id IN ? AND paid_transaction_id IS NULL", ids).
+		Updates(map[string]interface{}{
+			"status":              model.ReimbursementStatusPaid,
+			"paid_transaction_id": transactionID,
+		}).Error; err != nil {
+		return err
+	}
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"self-payrol/config"
 	"self-payrol/model"
+
+	"gorm.io/gorm"
 )
 
 type transactionRepository struct {
@@ -17,10 +19,33 @@ func NewTransactionRepository(cfg config.Config) model.TransactionRepository {
 func (t *transactionRepository) Fetch(ctx context.Context, limit, offset int) ([]*model.Transaction, error) {
 	var data []*model.Transaction
 
-	if err := t.Cfg.Database().WithContext(ctx).
+	if err := getDB(ctx, t.Cfg).
+		Limit(limit).Offset(offset).Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (t *transactionRepository) FetchByUserID(ctx context.Context, userID, limit, offset int) ([]*model.Transaction, error) {
+	var data []*model.Transaction
+
+	if err := getDB(ctx, t.Cfg).
+		Where("user_id = ?", userID).
+		Preload("Lines").
+		Order("created_at desc").
 		Limit(limit).Offset(offset).Find(&data).Error; err != nil {
 		return nil, err
 	}
 
 	return data, nil
 }
+
+// salaryPaidIn selects the ids of the salary withdrawals of the user in
+// period, to find what they paid.
+func salaryPaidIn(db *gorm.DB, userID int, period string) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).
+		Model(&model.Transaction{}).
+		Select("id").
+		Where("user_id = ? AND period = ? AND category = ?", userID, period, model.TransactionCategorySalary)
+}
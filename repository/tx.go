@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"self-payrol/config"
+	"self-payrol/model"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+type txManager struct {
+	Cfg config.Config
+}
+
+func NewTxManager(cfg config.Config) model.TxManager {
+	return &txManager{Cfg: cfg}
+}
+
+func (t *txManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return getDB(ctx, t.Cfg).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// getDB returns the transaction carried by ctx when there is one, otherwise
+// a plain session on the shared connection pool.
+func getDB(ctx context.Context, cfg config.Config) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return cfg.Database().WithContext(ctx)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"self-payrol/config"
 	"self-payrol/model"
+	"time"
 )
 
 type userRepository struct {
@@ -19,7 +20,7 @@ func (p *userRepository) FindByID(ctx context.Context, id int) (*model.User, err
 	// TODO(Rakamin): buat fungsi untuk mencari user berdasarkan ID pada parameter
 	user := new(model.User)
 
-	if err := p.Cfg.Database().WithContext(ctx).
+	if err := getDB(ctx, p.Cfg).
 		Where("id = ?", id).
 		Preload("Position").
 		First(user).Error; err != nil {
@@ -31,7 +32,7 @@ func (p *userRepository) FindByID(ctx context.Context, id int) (*model.User, err
 
 func (p *userRepository) Create(ctx context.Context, user *model.User) (*model.User, error) {
 	// TODO(Rakamin): buat fungsi untuk membuat user berdasarkan struct parameter
-	if err := p.Cfg.Database().WithContext(ctx).
+	if err := getDB(ctx, p.Cfg).
 		Create(&user).Error; err != nil {
 		return nil, err
 	}
@@ -41,7 +42,7 @@ func (p *userRepository) Create(ctx context.Context, user *model.User) (*model.U
 
 func (p *userRepository) UpdateByID(ctx context.Context, id int, user *model.User) (*model.User, error) {
 	// TODO(Rakamin): buat fungsi untuk update user berdasarkan struct parameter
-	if err := p.Cfg.Database().WithContext(ctx).
+	if err := getDB(ctx, p.Cfg).
 		Model(&model.User{ID: id}).
 		Updates(user).
 		Find(user).Error; err != nil {
@@ -60,7 +61,7 @@ func (p *userRepository) Delete(ctx context.Context, id int) error {
 		return err
 	}
 
-	res := p.Cfg.Database().WithContext(ctx).
+	res := getDB(ctx, p.Cfg).
 		Delete(&model.User{}, id)
 	if res.Error != nil {
 
@@ -72,10 +73,25 @@ func (p *userRepository) Delete(ctx context.Context, id int) error {
 func (p *userRepository) Fetch(ctx context.Context, limit, offset int) ([]*model.User, error) {
 	var data []*model.User
 
-	if err := p.Cfg.Database().WithContext(ctx).Preload("Position").
+	if err := getDB(ctx, p.Cfg).Preload("Position").
 		Limit(limit).Offset(offset).Find(&data).Error; err != nil {
 		return nil, err
 	}
 
 	return data, nil
 }
+
+// FetchPayable returns every active employee and those terminated on or
+// after since, oldest first.
+func (p *userRepository) FetchPayable(ctx context.Context, since time.Time) ([]*model.User, error) {
+	var data []*model.User
+
+	if err := getDB(ctx, p.Cfg).Preload("Position").
+		Where("status = ? OR (status = ? AND end_date >= ?)", model.EmploymentActive, model.EmploymentTerminated, since.Format("2006-01-02")).
+		Order("id").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"self-payrol/config"
+	"self-payrol/model"
+
+	"gorm.io/gorm/clause"
+)
+
+type userSalaryRepository struct {
+	Cfg config.Config
+}
+
+func NewUserSalaryRepository(cfg config.Config) model.UserSalaryRepository {
+	return &userSalaryRepository{Cfg: cfg}
+}
+
+func (p *userSalaryRepository) Upsert(ctx context.Context, salary *model.UserSalary) (*model.UserSalary, error) {
+	if err := getDB(ctx, p.Cfg).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "effective_from"}},
+			DoUpdates: clause.AssignmentColumns([]string{"salary_amount", "salary_currency", "updated_at"}),
+		}).
+		Create(salary).Error; err != nil {
+		return nil, err
+	}
+	return salary, nil
+}
+
+func (p *userSalaryRepository) FetchByUserID(ctx context.Context, userID int) ([]*model.UserSalary, error) {
+	var data []*model.UserSalary
+
+	if err := getDB(ctx, p.Cfg).
+		Where("user_id = ?", userID).
+		Order("effective_from desc").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (p *userSalaryRepository) FetchByPositionID(ctx context.Context, positionID int) ([]*model.UserSalary, error) {
+	var data []*model.UserSalary
+
+	holders := getDB(ctx, p.Cfg).Model(&model.User{}).Select("id").Where("position_id = ?", positionID)
+	if err := getDB(ctx, p.Cfg).
+		Where("user_id IN (?)", holders).
+		Order("user_id, effective_from desc").
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
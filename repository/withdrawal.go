@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"self-payrol/config"
+	"self-payrol/model"
+	"self-payrol/money"
+
+	"gorm.io/gorm/clause"
+)
+
+type withdrawalRepository struct {
+	Cfg config.Config
+}
+
+func NewWithdrawalRepository(cfg config.Config) model.WithdrawalRepository {
+	return &withdrawalRepository{Cfg: cfg}
+}
+
+func (w *withdrawalRepository) Lock(ctx context.Context, userID int, period, currency string) (*model.Withdrawal, error) {
+	db := getDB(ctx, w.Cfg)
+
+	// the unique index on (user_id, period) keeps this a no-op when the row exists
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.Withdrawal{UserID: userID, Period: period, Amount: money.New(0, currency)}).Error; err != nil {
+		return nil, err
+	}
+
+	withdrawal := new(model.Withdrawal)
+	if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND period = ?", userID, period).
+		First(withdrawal).Error; err != nil {
+		return nil, err
+	}
+
+	return withdrawal, nil
+}
+
+func (w *withdrawalRepository) UpdateByID(ctx context.Context, id int, withdrawal *model.Withdrawal) (*model.Withdrawal, error) {
+	if err := getDB(ctx, w.Cfg).
+		Model(&model.Withdrawal{ID: id}).Updates(withdrawal).Find(withdrawal).Error; err != nil {
+		return nil, err
+	}
+
+	return withdrawal, nil
+}
+
+func (w *withdrawalRepository) FetchByPeriod(ctx context.Context, period string) ([]*model.Withdrawal, error) {
+	var data []*model.Withdrawal
+
+	if err := getDB(ctx, w.Cfg).
+		Where("period = ?", period).
+		Find(&data).Error; err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -0,0 +1,18 @@
+package request
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	ClockRequest struct {
+		Note string `json:"note"`
+	}
+)
+
+func (req ClockRequest) Validate() error {
+	return validation.ValidateStruct(
+		&req,
+		validation.Field(&req.Note, validation.Length(0, 255)),
+	)
+}
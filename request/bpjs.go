@@ -0,0 +1,47 @@
+package request
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"self-payrol/money"
+)
+
+type (
+	BPJSRateRequest struct {
+		Rates []BPJSRateItem `json:"rates"`
+	}
+
+	BPJSRateItem struct {
+		Program         string      `json:"program"`
+		EmployeeRateBps int         `json:"employee_rate_bps"`
+		EmployerRateBps int         `json:"employer_rate_bps"`
+		WageCap         money.Money `json:"wage_cap"`
+	}
+
+	BPJSPayRequest struct {
+		Month string `json:"month"`
+	}
+)
+
+func (req BPJSRateRequest) Validate() error {
+	return validation.ValidateStruct(
+		&req,
+		validation.Field(&req.Rates, validation.Required),
+	)
+}
+
+func (item BPJSRateItem) Validate() error {
+	return validation.ValidateStruct(
+		&item,
+		validation.Field(&item.Program, validation.Required, validation.In("jht", "jp", "jkk", "jkm", "kesehatan")),
+		validation.Field(&item.EmployeeRateBps, validation.Min(0), validation.Max(10000)),
+		validation.Field(&item.EmployerRateBps, validation.Min(0), validation.Max(10000)),
+		validation.Field(&item.WageCap, amountRules{validation.Min(0)}),
+	)
+}
+
+func (req BPJSPayRequest) Validate() error {
+	return validation.ValidateStruct(
+		&req,
+		validation.Field(&req.Month, validation.Required, validation.Length(7, 7)),
+	)
+}
@@ -1,16 +1,35 @@
 package request
 
-import validation "github.com/go-ozzo/ozzo-validation"
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
+	"self-payrol/money"
+)
 
 type (
 	CompanyRequest struct {
-		Name    string `json:"name"`
-		Balance int    `json:"balance"`
-		Address string `json:"address"`
+		Name string `json:"name"`
+		// Balance is the opening balance, it is only read when the company
+		// is created. Top-ups move it after that, and its currency cannot
+		// change.
+		Balance money.Money `json:"balance"`
+		Address string      `json:"address"`
+		Email   string      `json:"email"`
+		// PayrollCycle is optional, an empty value keeps the current cycle,
+		// monthly for a new company. It cannot change while the current
+		// period has withdrawals.
+		PayrollCycle string `json:"payroll_cycle"`
+		// ProrationMethod is optional, an empty value prorates by working
+		// days.
+		ProrationMethod string `json:"proration_method"`
+		// AnnualLeaveDays is optional, zero keeps the current entitlement.
+		AnnualLeaveDays int `json:"annual_leave_days"`
+		// TrackAttendance is optional, leaving it out keeps the setting.
+		TrackAttendance *bool `json:"track_attendance"`
 	}
 
 	TopupCompanyBalance struct {
-		Balance int `json:"balance" validate:"required"`
+		Balance money.Money `json:"balance" validate:"required"`
 	}
 )
 
@@ -19,8 +38,12 @@ func (req CompanyRequest) Validate() error {
 	return validation.ValidateStruct(
 		&req,
 		validation.Field(&req.Name, validation.Required),
-		validation.Field(&req.Balance, validation.Required),
+		validation.Field(&req.Balance, amountRules{validation.Min(0)}),
 		validation.Field(&req.Address, validation.Required),
+		validation.Field(&req.Email, is.Email),
+		validation.Field(&req.PayrollCycle, validation.In("monthly", "semimonthly")),
+		validation.Field(&req.ProrationMethod, validation.In("working_days", "calendar_days")),
+		validation.Field(&req.AnnualLeaveDays, validation.Min(0), validation.Max(366)),
 	)
 }
 
@@ -29,6 +52,6 @@ func (req CompanyRequest) Validate() error {
 func (req TopupCompanyBalance) Validate() error {
 	return validation.ValidateStruct(
 		&req,
-		validation.Field(&req.Balance, validation.Required),
+		validation.Field(&req.Balance, amountRules{validation.Required, validation.Min(1)}),
 	)
 }
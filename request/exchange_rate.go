@@ -0,0 +1,40 @@
+package request
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	ExchangeRateRequest struct {
+		Rates []ExchangeRateItem `json:"rates"`
+	}
+
+	ExchangeRateItem struct {
+		Currency string `json:"currency"`
+		// QuoteCurrency is optional, it defaults to the currency of the
+		// company balance.
+		QuoteCurrency string `json:"quote_currency"`
+		// Rate is what one unit of Currency is worth in QuoteCurrency, as
+		// a decimal string such as "15825.5".
+		Rate string `json:"rate"`
+		// EffectiveFrom is the date, as 2006-01-02, the rate applies from.
+		EffectiveFrom string `json:"effective_from"`
+	}
+)
+
+func (req ExchangeRateRequest) Validate() error {
+	return validation.ValidateStruct(
+		&req,
+		validation.Field(&req.Rates, validation.Required),
+	)
+}
+
+func (item ExchangeRateItem) Validate() error {
+	return validation.ValidateStruct(
+		&item,
+		validation.Field(&item.Currency, validation.Required, currencyRule{}),
+		validation.Field(&item.QuoteCurrency, currencyRule{}, validation.NotIn(item.Currency)),
+		validation.Field(&item.Rate, validation.Required, rateRule{}),
+		validation.Field(&item.EffectiveFrom, validation.Required, validation.Date("2006-01-02")),
+	)
+}
@@ -0,0 +1,25 @@
+package request
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	LeaveRequest struct {
+		Type string `json:"type"`
+		// StartDate and EndDate are the first and last day away, as
+		// 2006-01-02.
+		StartDate string `json:"start_date"`
+		EndDate   string `json:"end_date"`
+		Reason    string `json:"reason"`
+	}
+)
+
+func (req LeaveRequest) Validate() error {
+	return validation.ValidateStruct(
+		&req,
+		validation.Field(&req.Type, validation.Required, validation.In("annual", "sick", "unpaid")),
+		validation.Field(&req.StartDate, validation.Required, validation.Date("2006-01-02")),
+		validation.Field(&req.EndDate, validation.Required, validation.Date("2006-01-02")),
+	)
+}
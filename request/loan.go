@@ -0,0 +1,27 @@
+package request
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"self-payrol/money"
+)
+
+type (
+	LoanRequest struct {
+		Amount money.Money `json:"amount"`
+		Reason string      `json:"reason"`
+		// Installments is how many payroll periods the loan is repaid over.
+		Installments int `json:"installments"`
+		// FirstPeriod is the period code of the first installment, the
+		// current period by default.
+		FirstPeriod string `json:"first_period"`
+	}
+)
+
+func (req LoanRequest) Validate() error {
+	return validation.ValidateStruct(
+		&req,
+		validation.Field(&req.Amount, amountRules{validation.Required, validation.Min(1)}),
+		validation.Field(&req.Reason, validation.Required),
+		validation.Field(&req.Installments, validation.Required, validation.Min(1), validation.Max(120)),
+	)
+}
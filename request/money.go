@@ -0,0 +1,63 @@
+package request
+
+import (
+	"errors"
+	"math/big"
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"self-payrol/money"
+)
+
+// rateFormat fits the numeric(24,10) column rates are kept in.
+var rateFormat = regexp.MustCompile(`^\d{1,14}(\.\d{1,10})?$`)
+
+// amountRules applies its rules to the amount of a money.Money, in minor
+// units of its currency. The currency is checked by money.Money itself. A
+// nil *money.Money is left to NotNil.
+type amountRules []validation.Rule
+
+func (r amountRules) Validate(value interface{}) error {
+	if p, ok := value.(*money.Money); ok {
+		if p == nil {
+			return nil
+		}
+		value = *p
+	}
+
+	m, ok := value.(money.Money)
+	if !ok {
+		return nil
+	}
+	return validation.Validate(m.Amount, r...)
+}
+
+// currencyRule accepts the currencies money supports, or no currency at
+// all for Required to report.
+type currencyRule struct{}
+
+func (currencyRule) Validate(value interface{}) error {
+	currency, _ := value.(string)
+	if currency == "" {
+		return nil
+	}
+	_, err := money.Exponent(currency)
+	return err
+}
+
+// rateRule accepts positive decimal strings such as "15825.5".
+type rateRule struct{}
+
+func (rateRule) Validate(value interface{}) error {
+	rate, _ := value.(string)
+	if rate == "" {
+		return nil
+	}
+	if !rateFormat.MatchString(rate) {
+		return errors.New("must be a decimal number with at most 10 decimal places")
+	}
+	if r, _ := new(big.Rat).SetString(rate); r.Sign() <= 0 {
+		return errors.New("must be greater than 0")
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package request
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+)
+
+type (
+	OvertimeRequest struct {
+		// Date is the day the overtime was worked, as 2006-01-02.
+		Date  string  `json:"date"`
+		Hours float64 `json:"hours"`
+		// RestDay marks a public holiday, weekends are rest days anyway.
+		RestDay bool   `json:"rest_day"`
+		Note    string `json:"note"`
+	}
+)
+
+func (req OvertimeRequest) Validate() error {
+	return validation.ValidateStruct(
+		&req,
+		validation.Field(&req.Date, validation.Required, validation.Date("2006-01-02")),
+		validation.Field(&req.Hours, validation.Required, validation.Min(0.0), validation.Max(12.0)),
+	)
+}
@@ -0,0 +1,29 @@
+package request
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"self-payrol/money"
+)
+
+type (
+	PaymentRequest struct {
+		Amount money.Money `json:"amount"`
+		Reason string      `json:"reason"`
+		// Period is the payroll period code the payment is due in.
+		Period string `json:"period"`
+		// Taxable defaults to true, bonuses are taxable income.
+		Taxable *bool `json:"taxable"`
+		// Disbursement is "salary" (the default) or "separate".
+		Disbursement string `json:"disbursement"`
+	}
+)
+
+func (req PaymentRequest) Validate() error {
+	return validation.ValidateStruct(
+		&req,
+		validation.Field(&req.Amount, amountRules{validation.Required, validation.Min(1)}),
+		validation.Field(&req.Reason, validation.Required),
+		validation.Field(&req.Period, validation.Required),
+		validation.Field(&req.Disbursement, validation.In("salary", "separate")),
+	)
+}
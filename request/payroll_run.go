@@ -0,0 +1,36 @@
+package request
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"self-payrol/money"
+)
+
+type (
+	// PayrollRunActionRequest names the admin acting on a payroll run, the
+	// note is kept with the status change. The service has no login, so
+	// Actor is taken at its word.
+	PayrollRunActionRequest struct {
+		Actor string `json:"actor"`
+		Note  string `json:"note"`
+	}
+
+	// PayrollRunItemRequest sets what a draft pays an employee, zero leaves
+	// them out of the run.
+	PayrollRunItemRequest struct {
+		Actor  string       `json:"actor"`
+		Amount *money.Money `json:"amount"`
+	}
+)
+
+func (req PayrollRunActionRequest) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Actor, validation.Required),
+	)
+}
+
+func (req PayrollRunItemRequest) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Actor, validation.Required),
+		validation.Field(&req.Amount, validation.NotNil, amountRules{validation.Min(0)}),
+	)
+}
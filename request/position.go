@@ -2,19 +2,44 @@ package request
 
 import (
 	validation "github.com/go-ozzo/ozzo-validation"
+	"self-payrol/money"
 )
 
 type (
 	PositionRequest struct {
-		Name   string `json:"name" validate:"required"`
-		Salary int    `json:"salary" validate:"required"`
+		Name   string      `json:"name" validate:"required"`
+		Salary money.Money `json:"salary" validate:"required"`
+		// EffectiveFrom is the date, as 2006-01-02, the salary applies
+		// from. It defaults to today.
+		EffectiveFrom string `json:"effective_from"`
+		// MinSalary and MaxSalary optionally bound the salary of the
+		// position and of its employees' negotiated pay.
+		MinSalary *money.Money `json:"min_salary"`
+		MaxSalary *money.Money `json:"max_salary"`
+		// PayCurrency is optional, employees of the position are paid in
+		// the currency of the company balance without it.
+		PayCurrency string `json:"pay_currency"`
 	}
 )
 
 func (req PositionRequest) Validate() error {
+	salaryRules := []validation.Rule{validation.Required}
+	maxRules := amountRules{validation.Min(1)}
+	if req.MinSalary != nil {
+		salaryRules = append(salaryRules, validation.Min(req.MinSalary.Amount))
+		maxRules = append(maxRules, validation.Min(req.MinSalary.Amount))
+	}
+	if req.MaxSalary != nil {
+		salaryRules = append(salaryRules, validation.Max(req.MaxSalary.Amount))
+	}
+
 	return validation.ValidateStruct(
 		&req,
 		validation.Field(&req.Name, validation.Required),
-		validation.Field(&req.Salary, validation.Required),
+		validation.Field(&req.Salary, amountRules(salaryRules)),
+		validation.Field(&req.EffectiveFrom, validation.Date("2006-01-02")),
+		validation.Field(&req.MinSalary, amountRules{validation.Min(1)}),
+		validation.Field(&req.MaxSalary, maxRules),
+		validation.Field(&req.PayCurrency, currencyRule{}),
 	)
 }
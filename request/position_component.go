@@ -0,0 +1,24 @@
+package request
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"self-payrol/money"
+)
+
+type (
+	PositionComponentRequest struct {
+		Name    string      `json:"name"`
+		Type    string      `json:"type"`
+		Amount  money.Money `json:"amount"`
+		Taxable bool        `json:"taxable"`
+	}
+)
+
+func (req PositionComponentRequest) Validate() error {
+	return validation.ValidateStruct(
+		&req,
+		validation.Field(&req.Name, validation.Required),
+		validation.Field(&req.Type, validation.Required, validation.In("allowance", "deduction")),
+		validation.Field(&req.Amount, amountRules{validation.Required, validation.Min(1)}),
+	)
+}
@@ -0,0 +1,44 @@
+package request
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation"
+	"self-payrol/money"
+)
+
+type (
+	// ReimbursementRequest is sent as a multipart form with the receipt in
+	// the receipt file field.
+	ReimbursementRequest struct {
+		Amount      money.Money `json:"amount" form:"amount"`
+		Category    string      `json:"category" form:"category"`
+		Description string      `json:"description" form:"description"`
+		// ReceiptName, ReceiptType and Receipt are read from the uploaded
+		// file.
+		ReceiptName string `json:"-" form:"-"`
+		ReceiptType string `json:"-" form:"-"`
+		Receipt     []byte `json:"-" form:"-"`
+	}
+
+	ReimbursementReviewRequest struct {
+		// Disbursement is "salary" (the default) or "immediate".
+		Disbursement string `json:"disbursement"`
+	}
+)
+
+func (req ReimbursementRequest) Validate() error {
+	return validation.ValidateStruct(
+		&req,
+		validation.Field(&req.Amount, amountRules{validation.Required, validation.Min(1)}),
+		validation.Field(&req.Category, validation.Required, validation.In("travel", "supplies", "meals", "other")),
+		validation.Field(&req.Description, validation.Required),
+		validation.Field(&req.Receipt, validation.Required),
+		validation.Field(&req.ReceiptType, validation.In("image/jpeg", "image/png", "application/pdf")),
+	)
+}
+
+func (req ReimbursementReviewRequest) Validate() error {
+	return validation.ValidateStruct(
+		&req,
+		validation.Field(&req.Disbursement, validation.In("salary", "immediate")),
+	)
+}
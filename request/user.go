@@ -1,7 +1,17 @@
 package request
 
 import (
+	"regexp"
+
 	validation "github.com/go-ozzo/ozzo-validation"
+	"self-payrol/money"
+)
+
+var (
+	// bankCodeFormat takes the 3 digit clearing codes of Indonesian banks,
+	// or longer sandi kliring and SWIFT style codes.
+	bankCodeFormat    = regexp.MustCompile(`^[0-9A-Z]{3,11}$`)
+	bankAccountFormat = regexp.MustCompile(`^[0-9]{5,34}$`)
 )
 
 type (
@@ -12,12 +22,42 @@ type (
 		Email      string `json:"email"`
 		Phone      string `json:"phone"`
 		Address    string `json:"address"`
+		TaxStatus  string `json:"tax_status"`
 		PositionID int    `json:"position_id"`
+		// StartDate and EndDate are optional employment dates, as
+		// 2006-01-02.
+		StartDate string `json:"start_date"`
+		EndDate   string `json:"end_date"`
+		// PayCurrency is optional, the employee is paid in the pay
+		// currency of their position without it.
+		PayCurrency string `json:"pay_currency"`
+		// BankCode, BankAccountNumber and BankAccountName are optional,
+		// they are needed to put the employee in a bank file.
+		BankCode          string `json:"bank_code"`
+		BankAccountNumber string `json:"bank_account_number"`
+		BankAccountName   string `json:"bank_account_name"`
+	}
+
+	// UserSalaryRequest sets a negotiated base salary for an employee from
+	// EffectiveFrom (as 2006-01-02, today by default). A null salary goes
+	// back to the position salary.
+	UserSalaryRequest struct {
+		Salary        *money.Money `json:"salary"`
+		EffectiveFrom string       `json:"effective_from"`
+	}
+
+	// EmploymentStatusRequest explains a status change. Date is the last
+	// day worked when terminating, as 2006-01-02, today by default.
+	EmploymentStatusRequest struct {
+		Reason string `json:"reason"`
+		Date   string `json:"date"`
 	}
 
 	WithdrawRequest struct {
 		ID       int    `json:"id"`
 		SecretID string `json:"secret_id"`
+		// Amount is optional, zero withdraws everything left of the period.
+		Amount money.Money `json:"amount"`
 	}
 )
 
@@ -25,6 +65,21 @@ func (req WithdrawRequest) Validate() error {
 	return validation.ValidateStruct(&req,
 		validation.Field(&req.ID, validation.Required),
 		validation.Field(&req.SecretID, validation.Required),
+		validation.Field(&req.Amount, amountRules{validation.Min(0)}),
+	)
+}
+
+func (req UserSalaryRequest) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Salary, amountRules{validation.Min(1)}),
+		validation.Field(&req.EffectiveFrom, validation.Date("2006-01-02")),
+	)
+}
+
+func (req EmploymentStatusRequest) Validate() error {
+	return validation.ValidateStruct(&req,
+		validation.Field(&req.Reason, validation.Required),
+		validation.Field(&req.Date, validation.Date("2006-01-02")),
 	)
 }
 
@@ -36,6 +91,13 @@ func (req UserRequest) Validate() error {
 		validation.Field(&req.Email, validation.Required),
 		validation.Field(&req.Phone, validation.Required),
 		validation.Field(&req.Address, validation.Required),
+		validation.Field(&req.TaxStatus, validation.In("TK/0", "TK/1", "TK/2", "TK/3", "K/0", "K/1", "K/2", "K/3")),
 		validation.Field(&req.PositionID, validation.Required),
+		validation.Field(&req.StartDate, validation.Date("2006-01-02")),
+		validation.Field(&req.EndDate, validation.Date("2006-01-02")),
+		validation.Field(&req.PayCurrency, currencyRule{}),
+		validation.Field(&req.BankCode, validation.Match(bankCodeFormat)),
+		validation.Field(&req.BankAccountNumber, validation.Match(bankAccountFormat)),
+		validation.Field(&req.BankAccountName, validation.Length(0, 70)),
 	)
 }
@@ -0,0 +1,200 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSchedule = errors.New("invalid cron expression")
+
+// descriptors are the shorthands accepted in place of the five fields.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	weekdayNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+)
+
+type field struct {
+	min, max int
+	names    map[string]int
+}
+
+var fields = [5]field{
+	{min: 0, max: 59},
+	{min: 0, max: 23},
+	{min: 1, max: 31},
+	{min: 1, max: 12, names: monthNames},
+	// 7 is accepted for Sunday as well
+	{min: 0, max: 7, names: weekdayNames},
+}
+
+// Schedule is a parsed cron expression: minute, hour, day of month, month
+// and day of week.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny remember a "*" day field. As in cron, when both
+	// day fields are restricted a day matching either of them is run.
+	domAny, dowAny bool
+}
+
+// Parse reads a standard five field cron expression such as "0 9 25 * *",
+// or one of the @monthly style shorthands.
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if d, ok := descriptors[strings.ToLower(expr)]; ok {
+		expr = d
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("%w: %q needs %d fields", ErrInvalidSchedule, expr, len(fields))
+	}
+
+	var sets [5]uint64
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidSchedule, expr, err)
+		}
+		sets[i] = set
+	}
+
+	// fold Sunday as 7 onto 0
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+
+	return &Schedule{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		domAny: parts[2] == "*" || parts[2] == "?",
+		dowAny: parts[4] == "*" || parts[4] == "?",
+	}, nil
+}
+
+// parseField turns a comma separated list of values, ranges and steps into
+// a bit set of the values it matches.
+func parseField(expr string, f field) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(expr, ",") {
+		rng, step := item, 1
+		if i := strings.IndexByte(item, '/'); i >= 0 {
+			var err error
+			rng = item[:i]
+			step, err = strconv.Atoi(item[i+1:])
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("bad step in %q", item)
+			}
+		}
+
+		lo, hi := f.min, f.max
+		switch {
+		case rng == "*" || rng == "?":
+		case strings.Contains(rng, "-"):
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if lo, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(bounds[1]); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("bad range %q", rng)
+			}
+		default:
+			v, err := f.value(rng)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			// "5/15" runs from 5 to the end of the field
+			hi = v
+			if step > 1 {
+				hi = f.max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+
+	return set, nil
+}
+
+func (f field) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("bad value %q", s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%d is out of range %d-%d", v, f.min, f.max)
+	}
+
+	return v, nil
+}
+
+// Next returns the first time after t the schedule fires, in the location
+// of t. The zero time is returned when it never fires, e.g. on 30 February.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	// every combination repeats within a few years, leap days included
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,74 @@
+package scheduler_test
+
+import (
+	"self-payrol/scheduler"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Schedule_Next(t *testing.T) {
+	// a Sunday afternoon
+	from := time.Date(2026, time.October, 18, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected time.Time
+	}{
+		{
+			name:     "Payday at nine on the 25th",
+			expr:     "0 9 25 * *",
+			expected: time.Date(2026, time.October, 25, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Monthly shorthand rolls over to the next month",
+			expr:     "@monthly",
+			expected: time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Every quarter of an hour",
+			expr:     "*/15 * * * *",
+			expected: time.Date(2026, time.October, 18, 14, 45, 0, 0, time.UTC),
+		},
+		{
+			name:     "Weekdays by name",
+			expr:     "0 8 * * mon-fri",
+			expected: time.Date(2026, time.October, 19, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Either day field matches when both are restricted",
+			expr:     "0 0 31 * 3",
+			expected: time.Date(2026, time.October, 21, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "Last day of February in a leap year",
+			expr:     "0 0 29 2 *",
+			expected: time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Never fires",
+			expr: "0 0 30 2 *",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := scheduler.Parse(tt.expr)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, schedule.Next(from))
+		})
+	}
+}
+
+func Test_Parse_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "0 9 25 *", "60 * * * *", "0 9 0 * *", "0 9 * * 1-", "*/0 * * * *", "5-1 * * * *"} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := scheduler.Parse(expr)
+
+			assert.ErrorIs(t, err, scheduler.ErrInvalidSchedule)
+		})
+	}
+}
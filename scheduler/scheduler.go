@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Scheduler runs a job every time its schedule fires until it is stopped.
+// A run that is still going when the next one is due makes that one be
+// skipped rather than overlap.
+type Scheduler struct {
+	schedule *Schedule
+	job      func(ctx context.Context) error
+	now      func() time.Time
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func New(schedule *Schedule, job func(ctx context.Context) error) *Scheduler {
+	return &Scheduler{schedule: schedule, job: job, now: time.Now}
+}
+
+// Start runs the scheduler in the background.
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	s.wg.Add(1)
+	go s.run(ctx)
+}
+
+// Stop stops the scheduler and waits for a job under way to return, its
+// context is cancelled.
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	defer s.wg.Done()
+
+	for {
+		next := s.schedule.Next(s.now())
+		if next.IsZero() {
+			log.Warn().Msg("schedule never fires, scheduler stopped")
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := s.job(ctx); err != nil {
+			log.Error().Err(err).Time("scheduled_at", next).Msg("scheduled job failed")
+		}
+	}
+}
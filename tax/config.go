@@ -0,0 +1,133 @@
+package tax
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+const (
+	MethodProgressive = "progressive"
+	MethodTER         = "ter"
+)
+
+// Currency is the currency of every amount of the rule sets, PTKP, brackets
+// and caps are in minor units of it.
+const Currency = "IDR"
+
+//go:embed pph21.json
+var defaultConfig []byte
+
+type (
+	// Config holds every PPh 21 rule set the service knows about. A payroll
+	// period is taxed with the latest version effective on its first day.
+	Config struct {
+		Versions []Version `json:"versions"`
+	}
+
+	Version struct {
+		Version       string `json:"version"`
+		EffectiveFrom string `json:"effective_from"`
+		// Method is either "progressive" (annualized brackets) or "ter".
+		Method           string           `json:"method"`
+		OccupationalCost OccupationalCost `json:"occupational_cost"`
+		// PTKP maps a tax status such as "K/1" to its yearly allowance.
+		PTKP     map[string]int `json:"ptkp"`
+		Brackets []Bracket      `json:"brackets"`
+		TER      *TER           `json:"ter,omitempty"`
+
+		effectiveFrom time.Time
+	}
+
+	OccupationalCost struct {
+		RateBps    int `json:"rate_bps"`
+		MonthlyCap int `json:"monthly_cap"`
+	}
+
+	// Bracket applies RateBps (1/100 of a percent) to income up to UpTo, a
+	// zero UpTo marks the open-ended top bracket.
+	Bracket struct {
+		UpTo    int `json:"up_to"`
+		RateBps int `json:"rate_bps"`
+	}
+
+	// TER is the average effective rate scheme: Categories maps a category
+	// ("A", "B", "C") to its tax statuses, Tables maps it to monthly gross
+	// brackets whose RateBps applies to the whole gross.
+	TER struct {
+		Categories map[string][]string  `json:"categories"`
+		Tables     map[string][]Bracket `json:"tables"`
+	}
+)
+
+// LoadConfig reads the rule sets from path, or the bundled defaults when path
+// is empty.
+func LoadConfig(path string) (*Config, error) {
+	raw := defaultConfig
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		raw = b
+	}
+
+	return ParseConfig(raw)
+}
+
+func ParseConfig(raw []byte) (*Config, error) {
+	cfg := new(Config)
+	if err := json.Unmarshal(raw, cfg); err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Versions) == 0 {
+		return nil, errors.New("tax config has no versions")
+	}
+
+	for i := range cfg.Versions {
+		v := &cfg.Versions[i]
+
+		from, err := time.Parse("2006-01-02", v.EffectiveFrom)
+		if err != nil {
+			return nil, fmt.Errorf("tax config %s: effective_from: %w", v.Version, err)
+		}
+		v.effectiveFrom = from
+
+		switch v.Method {
+		case MethodProgressive:
+			if len(v.Brackets) == 0 || len(v.PTKP) == 0 {
+				return nil, fmt.Errorf("tax config %s: progressive method needs ptkp and brackets", v.Version)
+			}
+		case MethodTER:
+			if v.TER == nil || len(v.TER.Categories) == 0 || len(v.TER.Tables) == 0 {
+				return nil, fmt.Errorf("tax config %s: ter method needs categories and tables", v.Version)
+			}
+		default:
+			return nil, fmt.Errorf("tax config %s: unknown method %q", v.Version, v.Method)
+		}
+	}
+
+	sort.Slice(cfg.Versions, func(i, j int) bool {
+		return cfg.Versions[i].effectiveFrom.Before(cfg.Versions[j].effectiveFrom)
+	})
+
+	return cfg, nil
+}
+
+// VersionAt returns the rule set effective on day t.
+func (c *Config) VersionAt(t time.Time) (*Version, error) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	for i := len(c.Versions) - 1; i >= 0; i-- {
+		if !c.Versions[i].effectiveFrom.After(day) {
+			return &c.Versions[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no tax config effective on %s", day.Format("2006-01-02"))
+}
@@ -0,0 +1,182 @@
+package tax
+
+import (
+	"errors"
+	"fmt"
+	"self-payrol/model"
+	"self-payrol/money"
+)
+
+var (
+	ErrUnknownTaxStatus = errors.New("tax status not valid")
+	ErrCurrency         = errors.New("PPh 21 is only worked out on pay in IDR")
+)
+
+type pph21Calculator struct {
+	cfg *Config
+}
+
+func NewPPh21Calculator(cfg *Config) model.TaxCalculator {
+	return &pph21Calculator{cfg: cfg}
+}
+
+// Withholding computes the PPh 21 to withhold from one month of pay. gross
+// is the taxable monthly income and deductible the employee paid pension
+// contributions (JHT and JP) that reduce it. Both must be in IDR, the
+// currency of the rule sets.
+func (p *pph21Calculator) Withholding(period model.PayrollPeriod, status string, gross, deductible money.Money) (*model.TaxCalculation, error) {
+	if gross.Currency != Currency {
+		return nil, fmt.Errorf("%w, not %s", ErrCurrency, gross.Currency)
+	}
+	if !deductible.IsZero() && deductible.Currency != Currency {
+		return nil, fmt.Errorf("%w, not %s", ErrCurrency, deductible.Currency)
+	}
+
+	if status == "" {
+		status = model.DefaultTaxStatus
+	}
+
+	version, err := p.cfg.VersionAt(period.Start)
+	if err != nil {
+		return nil, err
+	}
+
+	calc := &model.TaxCalculation{
+		Version:     version.Version,
+		Method:      version.Method,
+		TaxStatus:   status,
+		Gross:       gross,
+		Deductible:  deductible,
+		Withholding: money.New(0, gross.Currency),
+	}
+
+	if gross.Amount <= 0 {
+		return calc, nil
+	}
+
+	if version.Method == MethodTER {
+		return p.ter(version, calc)
+	}
+
+	return p.progressive(version, calc)
+}
+
+func (p *pph21Calculator) progressive(version *Version, calc *model.TaxCalculation) (*model.TaxCalculation, error) {
+	status, ok := version.PTKP[calc.TaxStatus]
+	if !ok {
+		return nil, ErrUnknownTaxStatus
+	}
+	ptkp := money.New(int64(status), Currency)
+
+	occupational, err := calc.Gross.Prorate(int64(version.OccupationalCost.RateBps), 10000)
+	if err != nil {
+		return nil, err
+	}
+	if limit := int64(version.OccupationalCost.MonthlyCap); limit > 0 && occupational.Amount > limit {
+		occupational = money.New(limit, Currency)
+	}
+
+	monthlyNet, err := calc.Gross.Sub(occupational)
+	if err != nil {
+		return nil, err
+	}
+	if monthlyNet, err = monthlyNet.Sub(calc.Deductible); err != nil {
+		return nil, err
+	}
+	if monthlyNet.IsNegative() {
+		monthlyNet = money.New(0, Currency)
+	}
+
+	annualNet, err := monthlyNet.Mul(12)
+	if err != nil {
+		return nil, err
+	}
+
+	// taxable income is rounded down to whole thousands
+	pkp, err := annualNet.Sub(ptkp)
+	if err != nil {
+		return nil, err
+	}
+	if pkp.IsNegative() {
+		pkp = money.New(0, Currency)
+	}
+	if pkp, err = pkp.Prorate(1, 1000); err != nil {
+		return nil, err
+	}
+	if pkp, err = pkp.Mul(1000); err != nil {
+		return nil, err
+	}
+
+	annualTax := money.New(0, Currency)
+	lower := money.New(0, Currency)
+	for _, bracket := range version.Brackets {
+		if pkp.Amount <= lower.Amount {
+			break
+		}
+
+		upper := pkp
+		if limit := int64(bracket.UpTo); limit > 0 && limit < pkp.Amount {
+			upper = money.New(limit, Currency)
+		}
+
+		income, err := upper.Sub(lower)
+		if err != nil {
+			return nil, err
+		}
+		tax, err := income.Prorate(int64(bracket.RateBps), 10000)
+		if err != nil {
+			return nil, err
+		}
+		if annualTax, err = annualTax.Add(tax); err != nil {
+			return nil, err
+		}
+		lower = upper
+	}
+
+	withholding, err := annualTax.Prorate(1, 12)
+	if err != nil {
+		return nil, err
+	}
+
+	calc.OccupationalCost = &occupational
+	calc.AnnualNet = &annualNet
+	calc.PTKP = &ptkp
+	calc.PKP = &pkp
+	calc.AnnualTax = &annualTax
+	calc.Withholding = withholding
+
+	return calc, nil
+}
+
+func (p *pph21Calculator) ter(version *Version, calc *model.TaxCalculation) (*model.TaxCalculation, error) {
+	category := ""
+	for name, statuses := range version.TER.Categories {
+		for _, s := range statuses {
+			if s == calc.TaxStatus {
+				category = name
+			}
+		}
+	}
+	if category == "" {
+		return nil, ErrUnknownTaxStatus
+	}
+
+	rate := 0
+	for _, bracket := range version.TER.Tables[category] {
+		rate = bracket.RateBps
+		if bracket.UpTo == 0 || calc.Gross.Amount <= int64(bracket.UpTo) {
+			break
+		}
+	}
+
+	withholding, err := calc.Gross.Prorate(int64(rate), 10000)
+	if err != nil {
+		return nil, err
+	}
+
+	calc.Category = category
+	calc.RateBps = rate
+	calc.Withholding = withholding
+
+	return calc, nil
+}
@@ -0,0 +1,152 @@
+package tax_test
+
+import (
+	"fmt"
+	"self-payrol/model"
+	"self-payrol/money"
+	"self-payrol/tax"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_pph21Calculator_Withholding_Progressive(t *testing.T) {
+	cfg, err := tax.LoadConfig("")
+	require.NoError(t, err)
+
+	period := model.PayrollPeriodOf(model.PayrollCycleMonthly, time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC))
+	idr := func(amount int64) *money.Money {
+		m := money.New(amount, "IDR")
+		return &m
+	}
+
+	tests := []struct {
+		name        string
+		status      string
+		gross       money.Money
+		deductible  money.Money
+		expected    *model.TaxCalculation
+		expectedErr error
+	}{
+		{
+			// (10,000,000 - 500,000) * 12 - 54,000,000 = 60,000,000 taxed at 5%
+			name:   "Single without dependents",
+			status: "TK/0",
+			gross:  money.New(10000000, "IDR"),
+			expected: &model.TaxCalculation{
+				Version: "2022.1", Method: tax.MethodProgressive, TaxStatus: "TK/0",
+				Gross: money.New(10000000, "IDR"), OccupationalCost: idr(500000), AnnualNet: idr(114000000),
+				PTKP: idr(54000000), PKP: idr(60000000), AnnualTax: idr(3000000), Withholding: money.New(250000, "IDR"),
+			},
+		},
+		{
+			// (20,000,000 - 500,000 - 300,000) * 12 - 63,000,000 = 167,400,000
+			// 60,000,000 at 5% plus 107,400,000 at 15%
+			name:       "Married with one dependent across two brackets",
+			status:     "K/1",
+			gross:      money.New(20000000, "IDR"),
+			deductible: money.New(300000, "IDR"),
+			expected: &model.TaxCalculation{
+				Version: "2022.1", Method: tax.MethodProgressive, TaxStatus: "K/1",
+				Gross: money.New(20000000, "IDR"), Deductible: money.New(300000, "IDR"), OccupationalCost: idr(500000), AnnualNet: idr(230400000),
+				PTKP: idr(63000000), PKP: idr(167400000), AnnualTax: idr(19110000), Withholding: money.New(1592500, "IDR"),
+			},
+		},
+		{
+			name:   "Income below PTKP is not taxed",
+			status: "",
+			gross:  money.New(4000000, "IDR"),
+			expected: &model.TaxCalculation{
+				Version: "2022.1", Method: tax.MethodProgressive, TaxStatus: "TK/0",
+				Gross: money.New(4000000, "IDR"), OccupationalCost: idr(200000), AnnualNet: idr(45600000),
+				PTKP: idr(54000000), PKP: idr(0), AnnualTax: idr(0), Withholding: money.New(0, "IDR"),
+			},
+		},
+		{
+			name:        "Unknown tax status",
+			status:      "X/9",
+			gross:       money.New(10000000, "IDR"),
+			expectedErr: tax.ErrUnknownTaxStatus,
+		},
+		{
+			name:        "Pay in another currency than IDR",
+			status:      "TK/0",
+			gross:       money.New(500000, "USD"),
+			expectedErr: fmt.Errorf("%w, not USD", tax.ErrCurrency),
+		},
+		{
+			name:        "Deductible in another currency than IDR",
+			status:      "TK/0",
+			gross:       money.New(10000000, "IDR"),
+			deductible:  money.New(3000, "USD"),
+			expectedErr: fmt.Errorf("%w, not USD", tax.ErrCurrency),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc, err := tax.NewPPh21Calculator(cfg).Withholding(period, tt.status, tt.gross, tt.deductible)
+
+			assert.Equal(t, tt.expected, calc)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func Test_pph21Calculator_Withholding_TER(t *testing.T) {
+	cfg, err := tax.ParseConfig([]byte(`{
+		"versions": [
+			{
+				"version": "old",
+				"effective_from": "2022-01-01",
+				"method": "progressive",
+				"ptkp": {"TK/0": 54000000},
+				"brackets": [{"up_to": 0, "rate_bps": 500}]
+			},
+			{
+				"version": "ter-test",
+				"effective_from": "2024-01-01",
+				"method": "ter",
+				"ter": {
+					"categories": {"A": ["TK/0"], "B": ["K/1"]},
+					"tables": {
+						"A": [{"up_to": 5000000, "rate_bps": 0}, {"up_to": 10000000, "rate_bps": 200}, {"up_to": 0, "rate_bps": 500}],
+						"B": [{"up_to": 0, "rate_bps": 100}]
+					}
+				}
+			}
+		]
+	}`))
+	require.NoError(t, err)
+
+	october := model.PayrollPeriodOf(model.PayrollCycleMonthly, time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC))
+	calc, err := tax.NewPPh21Calculator(cfg).Withholding(october, "TK/0", money.New(8000000, "IDR"), money.New(0, "IDR"))
+	require.NoError(t, err)
+	assert.Equal(t, "ter-test", calc.Version)
+	assert.Equal(t, "A", calc.Category)
+	assert.Equal(t, 200, calc.RateBps)
+	assert.Equal(t, money.New(160000, "IDR"), calc.Withholding)
+
+	calc, err = tax.NewPPh21Calculator(cfg).Withholding(october, "TK/0", money.New(12000000, "IDR"), money.New(0, "IDR"))
+	require.NoError(t, err)
+	assert.Equal(t, money.New(600000, "IDR"), calc.Withholding)
+
+	// periods before the TER version keep the older rules
+	march2023 := model.PayrollPeriodOf(model.PayrollCycleMonthly, time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC))
+	calc, err = tax.NewPPh21Calculator(cfg).Withholding(march2023, "TK/0", money.New(8000000, "IDR"), money.New(0, "IDR"))
+	require.NoError(t, err)
+	assert.Equal(t, "old", calc.Version)
+	assert.Equal(t, tax.MethodProgressive, calc.Method)
+}
+
+func Test_ParseConfig_Invalid(t *testing.T) {
+	_, err := tax.ParseConfig([]byte(`{"versions": []}`))
+	assert.Error(t, err)
+
+	_, err = tax.ParseConfig([]byte(`{"versions": [{"version": "x", "effective_from": "2024-01-01", "method": "flat"}]}`))
+	assert.Error(t, err)
+
+	_, err = tax.ParseConfig([]byte(`{"versions": [{"version": "x", "effective_from": "2024-01-01", "method": "ter"}]}`))
+	assert.Error(t, err)
+}
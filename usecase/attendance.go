@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"self-payrol/model"
+	"self-payrol/request"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type attendanceUsecase struct {
+	userRepository       model.UserRepository
+	attendanceRepository model.AttendanceRepository
+	companyRepository    model.CompanyRepository
+	now                  func() time.Time
+}
+
+func NewAttendanceUsecase(user model.UserRepository, attendance model.AttendanceRepository, company model.CompanyRepository) model.AttendanceUsecase {
+	return &attendanceUsecase{userRepository: user, attendanceRepository: attendance, companyRepository: company, now: time.Now}
+}
+
+func (a *attendanceUsecase) ClockIn(ctx context.Context, userID int, req *request.ClockRequest) (*model.Attendance, error) {
+	user, err := a.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Status != model.EmploymentActive {
+		return nil, fmt.Errorf("%w: %s", model.ErrEmployeeNotActive, user.Status)
+	}
+
+	now := a.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	_, err = a.attendanceRepository.FindByDate(ctx, userID, today)
+	if err == nil {
+		return nil, model.ErrAlreadyClockedIn
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	attendance, err := a.attendanceRepository.Create(ctx, &model.Attendance{
+		UserID:  userID,
+		Date:    today,
+		ClockIn: now,
+		Note:    req.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attendance, nil
+}
+
+func (a *attendanceUsecase) ClockOut(ctx context.Context, userID int, req *request.ClockRequest) (*model.Attendance, error) {
+	now := a.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	attendance, err := a.attendanceRepository.FindByDate(ctx, userID, today)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, model.ErrNotClockedIn
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if attendance.ClockOut != nil {
+		return nil, model.ErrAlreadyClockedOut
+	}
+
+	attendance, err = a.attendanceRepository.UpdateByID(ctx, attendance.ID, &model.Attendance{
+		ClockOut: &now,
+		Note:     req.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attendance, nil
+}
+
+func (a *attendanceUsecase) FetchAttendance(ctx context.Context, userID int, period string) ([]*model.Attendance, error) {
+	_, err := a.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	company, err := a.companyRepository.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := a.now()
+	payrollPeriod := model.PayrollPeriodOf(company.PayrollCycle, now)
+	if period != "" {
+		payrollPeriod, err = model.ParsePayrollPeriod(period, now.Location())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	attendance, err := a.attendanceRepository.FetchByUserID(ctx, userID, payrollPeriod.Start, payrollPeriod.End)
+	if err != nil {
+		return nil, err
+	}
+
+	return attendance, nil
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"self-payrol/money"
+	"self-payrol/request"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func Test_attendanceUsecase_ClockIn(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 8, 2, 0, 0, time.UTC)
+	today := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		status      string
+		existing    *model.Attendance
+		findErr     error
+		expectedErr error
+	}{
+		{
+			name:    "First clock-in of the day",
+			status:  model.EmploymentActive,
+			findErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name:        "Clocked in already",
+			status:      model.EmploymentActive,
+			existing:    &model.Attendance{ID: 3, UserID: 1, Date: today, ClockIn: now.Add(-time.Hour)},
+			expectedErr: model.ErrAlreadyClockedIn,
+		},
+		{
+			name:        "Suspended employee",
+			status:      model.EmploymentSuspended,
+			expectedErr: fmt.Errorf("%w: %s", model.ErrEmployeeNotActive, model.EmploymentSuspended),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(mocks.UserRepository)
+			mockAttendanceRepository := new(mocks.AttendanceRepository)
+
+			mockUserRepository.On("FindByID", mock.Anything, 1).Return(&model.User{ID: 1, Status: tt.status}, nil)
+			if tt.status == model.EmploymentActive {
+				mockAttendanceRepository.On("FindByDate", mock.Anything, 1, today).Return(tt.existing, tt.findErr)
+			}
+
+			created := &model.Attendance{ID: 4, UserID: 1, Date: today, ClockIn: now, Note: "On site"}
+			if tt.expectedErr == nil {
+				mockAttendanceRepository.On("Create", mock.Anything, &model.Attendance{UserID: 1, Date: today, ClockIn: now, Note: "On site"}).
+					Return(created, nil)
+			}
+
+			a := &attendanceUsecase{
+				userRepository:       mockUserRepository,
+				attendanceRepository: mockAttendanceRepository,
+				now:                  func() time.Time { return now },
+			}
+
+			attendance, err := a.ClockIn(context.TODO(), 1, &request.ClockRequest{Note: "On site"})
+
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, created, attendance)
+			} else {
+				assert.Nil(t, attendance)
+			}
+
+			mockAttendanceRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_payCalculator_unpaidDays(t *testing.T) {
+	tracked := true
+	company := &model.Company{ID: 1, TrackAttendance: &tracked}
+	october := model.PayrollPeriodOf(model.PayrollCycleMonthly, time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC))
+	// Monday 12 October, the days up to Friday 9 October are counted
+	now := time.Date(2026, time.October, 12, 10, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2026, time.October, d, 0, 0, 0, 0, time.UTC) }
+	leaveID := 8
+
+	tests := []struct {
+		name      string
+		startDate *time.Time
+		endDate   *time.Time
+		salary    money.Money
+		expected  []model.PayLine
+	}{
+		{
+			name:   "Absent on a day neither worked nor on leave",
+			salary: money.New(2200000, "IDR"),
+			expected: []model.PayLine{
+				{Code: model.PayCodeUnpaidLeave, Name: "Unpaid leave 5 Oct to 6 Oct 2026, 2 days", Kind: model.PayLineDeduction, Amount: money.New(200000, "IDR"), SourceType: "leave", SourceID: &leaveID},
+				{Code: model.PayCodeAbsence, Name: "Absence 9 Oct 2026", Kind: model.PayLineDeduction, Amount: money.New(100000, "IDR"), SourceType: "absence"},
+			},
+		},
+		{
+			name:      "Days before the employment start are not absences",
+			startDate: func() *time.Time { d := day(8); return &d }(),
+			salary:    money.New(2200000, "IDR"),
+			expected: []model.PayLine{
+				{Code: model.PayCodeAbsence, Name: "Absence 9 Oct 2026", Kind: model.PayLineDeduction, Amount: money.New(100000, "IDR"), SourceType: "absence"},
+			},
+		},
+		{
+			name:    "Nothing counted after the employment ends",
+			endDate: func() *time.Time { d := day(6); return &d }(),
+			salary:  money.New(2200000, "IDR"),
+			expected: []model.PayLine{
+				{Code: model.PayCodeUnpaidLeave, Name: "Unpaid leave 5 Oct to 6 Oct 2026, 2 days", Kind: model.PayLineDeduction, Amount: money.New(200000, "IDR"), SourceType: "leave", SourceID: &leaveID},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &model.User{ID: 1, StartDate: tt.startDate, EndDate: tt.endDate}
+			leaves := []*model.Leave{
+				{ID: 7, UserID: 1, Type: model.LeaveAnnual, StartDate: day(1), EndDate: day(2), Days: 2},
+				{ID: leaveID, UserID: 1, Type: model.LeaveUnpaid, StartDate: day(5), EndDate: day(6), Days: 2},
+			}
+			attendance := []*model.Attendance{
+				{UserID: 1, Date: day(7)},
+				{UserID: 1, Date: day(8)},
+			}
+
+			from, until := october.Start, day(12)
+			if tt.startDate != nil {
+				from = *tt.startDate
+			}
+			if tt.endDate != nil {
+				until = tt.endDate.AddDate(0, 0, 1)
+			}
+
+			mockLeaveRepository := new(mocks.LeaveRepository)
+			mockLeaveRepository.On("FetchBetween", mock.Anything, 1, october.Start, october.End, model.LeaveStatusApproved).Return(leaves, nil)
+			mockAttendanceRepository := new(mocks.AttendanceRepository)
+			mockAttendanceRepository.On("FetchByUserID", mock.Anything, 1, from, until).Return(attendance, nil)
+
+			c := &payCalculator{
+				leaveRepo:      mockLeaveRepository,
+				attendanceRepo: mockAttendanceRepository,
+				now:            func() time.Time { return now },
+			}
+
+			proration := october.Proration(model.ProrationWorkingDays, tt.startDate, tt.endDate)
+			lines, err := c.unpaidDays(context.TODO(), company, user, october, proration, tt.salary)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, lines)
+		})
+	}
+}
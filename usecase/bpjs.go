@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"self-payrol/model"
+	"self-payrol/money"
+	"self-payrol/request"
+	"time"
+)
+
+type bpjsUsecase struct {
+	companyRepo      model.CompanyRepository
+	rateRepo         model.BPJSRateRepository
+	contributionRepo model.BPJSContributionRepository
+	txManager        model.TxManager
+}
+
+func NewBPJSUsecase(company model.CompanyRepository, rate model.BPJSRateRepository, contribution model.BPJSContributionRepository, tx model.TxManager) model.BPJSUsecase {
+	return &bpjsUsecase{companyRepo: company, rateRepo: rate, contributionRepo: contribution, txManager: tx}
+}
+
+func (b *bpjsUsecase) FetchRates(ctx context.Context) ([]*model.BPJSRate, error) {
+	rates, err := companyBPJSRates(ctx, b.companyRepo, b.rateRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// UpdateRates saves the programs in req on top of the rates in effect, so
+// the first update also stores the defaults of the programs left out. Wage
+// caps are compared with salaries, so they have to be in the currency of
+// the company balance.
+func (b *bpjsUsecase) UpdateRates(ctx context.Context, req *request.BPJSRateRequest) ([]*model.BPJSRate, error) {
+	company, err := b.companyRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := bpjsRatesOf(ctx, b.rateRepo, company)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range req.Rates {
+		if !item.WageCap.IsZero() && !item.WageCap.SameCurrency(company.Balance) {
+			return nil, fmt.Errorf("%w: the %s wage cap is in %s, the balance is in %s", money.ErrCurrencyMismatch, item.Program, item.WageCap.Currency, company.Balance.Currency)
+		}
+	}
+
+	for _, item := range req.Rates {
+		for _, rate := range rates {
+			if rate.Program == item.Program {
+				rate.EmployeeRateBps = item.EmployeeRateBps
+				rate.EmployerRateBps = item.EmployerRateBps
+				rate.WageCap = item.WageCap
+			}
+		}
+	}
+
+	rates, err = b.rateRepo.Upsert(ctx, rates)
+	if err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+func (b *bpjsUsecase) Report(ctx context.Context, month string) (*model.BPJSReport, error) {
+	if _, err := time.Parse("2006-01", month); err != nil {
+		return nil, model.ErrInvalidPayrollPeriod
+	}
+
+	company, err := b.companyRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contributions, err := b.contributionRepo.FetchByMonth(ctx, month)
+	if err != nil {
+		return nil, err
+	}
+
+	zero := money.New(0, company.Balance.Currency)
+	report := &model.BPJSReport{
+		Month:         month,
+		Rows:          make([]model.BPJSReportRow, 0, len(contributions)),
+		TotalEmployee: zero,
+		TotalEmployer: zero,
+		Outstanding:   zero,
+	}
+	for _, contribution := range contributions {
+		row := model.BPJSReportRow{
+			UserID:         contribution.UserID,
+			Period:         contribution.Period,
+			Program:        contribution.Program,
+			Wage:           contribution.Wage,
+			EmployeeAmount: contribution.EmployeeAmount,
+			EmployerAmount: contribution.EmployerAmount,
+			Paid:           contribution.PaidTransactionID != nil,
+		}
+		if contribution.User != nil {
+			row.Name = contribution.User.Name
+		}
+
+		report.Rows = append(report.Rows, row)
+		if report.TotalEmployee, err = report.TotalEmployee.Add(contribution.EmployeeAmount); err != nil {
+			return nil, err
+		}
+		if report.TotalEmployer, err = report.TotalEmployer.Add(contribution.EmployerAmount); err != nil {
+			return nil, err
+		}
+		if !row.Paid {
+			owed, err := contribution.Owed()
+			if err != nil {
+				return nil, err
+			}
+			if report.Outstanding, err = report.Outstanding.Add(owed); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if report.Total, err = report.TotalEmployee.Add(report.TotalEmployer); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// PayContributions pays BPJS what is owed for month out of the company
+// balance, the employee shares were withheld from salaries and never left it.
+func (b *bpjsUsecase) PayContributions(ctx context.Context, month string) (*model.Transaction, error) {
+	if _, err := time.Parse("2006-01", month); err != nil {
+		return nil, model.ErrInvalidPayrollPeriod
+	}
+
+	company, err := b.companyRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var trx *model.Transaction
+	err = b.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		contributions, err := b.contributionRepo.LockUnpaidByMonth(ctx, month)
+		if err != nil {
+			return err
+		}
+
+		if len(contributions) == 0 {
+			return model.ErrNoContributionsToPay
+		}
+
+		total := money.New(0, company.Balance.Currency)
+		ids := make([]int, 0, len(contributions))
+		for _, contribution := range contributions {
+			owed, err := contribution.Owed()
+			if err != nil {
+				return err
+			}
+			if total, err = total.Add(owed); err != nil {
+				return err
+			}
+			ids = append(ids, contribution.ID)
+		}
+
+		trx, err = b.companyRepo.DebitBalance(ctx, &model.Transaction{
+			Amount: total,
+			Note:   "BPJS contributions " + month,
+			Period: &month,
+		})
+		if err != nil {
+			return err
+		}
+
+		return b.contributionRepo.MarkPaid(ctx, ids, trx.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return trx, nil
+}
@@ -0,0 +1,220 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"self-payrol/money"
+	"self-payrol/request"
+	"self-payrol/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_bpjsUsecase_UpdateRates(t *testing.T) {
+	tests := []struct {
+		name          string
+		savedRates    []*model.BPJSRate
+		req           *request.BPJSRateRequest
+		expectedRates []*model.BPJSRate
+		expectedErr   error
+	}{
+		{
+			name: "First update stores the defaults of the other programs",
+			req: &request.BPJSRateRequest{Rates: []request.BPJSRateItem{
+				{Program: model.BPJSProgramJKK, EmployerRateBps: 89},
+			}},
+			expectedRates: func() []*model.BPJSRate {
+				rates := model.DefaultBPJSRates(1)
+				rates[2].EmployerRateBps = 89
+				return rates
+			}(),
+		},
+		{
+			name: "Saved rates are updated in place",
+			savedRates: []*model.BPJSRate{
+				{ID: 7, CompanyID: 1, Program: model.BPJSProgramJHT, EmployeeRateBps: 200, EmployerRateBps: 370},
+			},
+			req: &request.BPJSRateRequest{Rates: []request.BPJSRateItem{
+				{Program: model.BPJSProgramJHT, EmployeeRateBps: 300, EmployerRateBps: 400, WageCap: money.New(1000, "IDR")},
+			}},
+			expectedRates: []*model.BPJSRate{
+				{ID: 7, CompanyID: 1, Program: model.BPJSProgramJHT, EmployeeRateBps: 300, EmployerRateBps: 400, WageCap: money.New(1000, "IDR")},
+			},
+		},
+		{
+			name: "Wage cap in another currency than the balance",
+			req: &request.BPJSRateRequest{Rates: []request.BPJSRateItem{
+				{Program: model.BPJSProgramJP, EmployeeRateBps: 100, EmployerRateBps: 200, WageCap: money.New(70000, "USD")},
+			}},
+			expectedErr: fmt.Errorf("%w: the jp wage cap is in USD, the balance is in IDR", money.ErrCurrencyMismatch),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockRateRepository := new(mocks.BPJSRateRepository)
+
+			mockCompanyRepository.On("Get", mock.Anything).Return(&model.Company{ID: 1, Balance: money.New(1000000, "IDR")}, nil)
+			mockRateRepository.On("FetchByCompanyID", mock.Anything, 1).Return(tt.savedRates, nil)
+			if tt.expectedErr == nil {
+				mockRateRepository.On("Upsert", mock.Anything, tt.expectedRates).Return(tt.expectedRates, nil)
+			}
+
+			b := usecase.NewBPJSUsecase(mockCompanyRepository, mockRateRepository, new(mocks.BPJSContributionRepository), new(mocks.TxManager))
+
+			rates, err := b.UpdateRates(context.TODO(), tt.req)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedRates, rates)
+
+			mockCompanyRepository.AssertExpectations(t)
+			mockRateRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_bpjsUsecase_Report(t *testing.T) {
+	paidID := 3
+	contributions := []*model.BPJSContribution{
+		{ID: 1, UserID: 1, User: &model.User{ID: 1, Name: "test"}, Period: "2026-10-1", Program: model.BPJSProgramJHT, Wage: money.New(2500, "IDR"), EmployeeAmount: money.New(50, "IDR"), EmployerAmount: money.New(92, "IDR"), PaidTransactionID: &paidID},
+		{ID: 2, UserID: 1, User: &model.User{ID: 1, Name: "test"}, Period: "2026-10-2", Program: model.BPJSProgramJHT, Wage: money.New(2500, "IDR"), EmployeeAmount: money.New(50, "IDR"), EmployerAmount: money.New(92, "IDR")},
+	}
+
+	tests := []struct {
+		name           string
+		month          string
+		repoResponse   []*model.BPJSContribution
+		repoErr        error
+		expectedReport *model.BPJSReport
+		expectedErr    error
+	}{
+		{
+			name:         "Both halves of a semimonthly month",
+			month:        "2026-10",
+			repoResponse: contributions,
+			expectedReport: &model.BPJSReport{
+				Month: "2026-10",
+				Rows: []model.BPJSReportRow{
+					{UserID: 1, Name: "test", Period: "2026-10-1", Program: model.BPJSProgramJHT, Wage: money.New(2500, "IDR"), EmployeeAmount: money.New(50, "IDR"), EmployerAmount: money.New(92, "IDR"), Paid: true},
+					{UserID: 1, Name: "test", Period: "2026-10-2", Program: model.BPJSProgramJHT, Wage: money.New(2500, "IDR"), EmployeeAmount: money.New(50, "IDR"), EmployerAmount: money.New(92, "IDR")},
+				},
+				TotalEmployee: money.New(100, "IDR"),
+				TotalEmployer: money.New(184, "IDR"),
+				Total:         money.New(284, "IDR"),
+				Outstanding:   money.New(142, "IDR"),
+			},
+		},
+		{
+			name:        "Invalid month",
+			month:       "2026-10-1",
+			expectedErr: model.ErrInvalidPayrollPeriod,
+		},
+		{
+			name:        "Failed to fetch contributions",
+			month:       "2026-10",
+			repoErr:     assert.AnError,
+			expectedErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockContributionRepository := new(mocks.BPJSContributionRepository)
+
+			if tt.expectedErr != model.ErrInvalidPayrollPeriod {
+				mockCompanyRepository.On("Get", mock.Anything).Return(&model.Company{ID: 1, Balance: money.New(1000000, "IDR")}, nil)
+				mockContributionRepository.On("FetchByMonth", mock.Anything, tt.month).
+					Return(tt.repoResponse, tt.repoErr)
+			}
+
+			b := usecase.NewBPJSUsecase(mockCompanyRepository, new(mocks.BPJSRateRepository), mockContributionRepository, new(mocks.TxManager))
+
+			report, err := b.Report(context.TODO(), tt.month)
+
+			assert.Equal(t, tt.expectedReport, report)
+			assert.Equal(t, tt.expectedErr, err)
+
+			mockCompanyRepository.AssertExpectations(t)
+			mockContributionRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_bpjsUsecase_PayContributions(t *testing.T) {
+	month := "2026-10"
+
+	tests := []struct {
+		name        string
+		unpaid      []*model.BPJSContribution
+		debitAmount int64
+		errDebit    error
+		expectedTrx *model.Transaction
+		expectedErr error
+	}{
+		{
+			name: "Pays the unpaid contributions",
+			unpaid: []*model.BPJSContribution{
+				{ID: 1, Program: model.BPJSProgramJHT, EmployeeAmount: money.New(100, "IDR"), EmployerAmount: money.New(185, "IDR")},
+				{ID: 2, Program: model.BPJSProgramJKM, EmployerAmount: money.New(15, "IDR")},
+			},
+			debitAmount: 300,
+			expectedTrx: &model.Transaction{ID: 9, Amount: money.New(300, "IDR")},
+		},
+		{
+			name:        "Nothing to pay",
+			expectedErr: model.ErrNoContributionsToPay,
+		},
+		{
+			name: "Company balance too low",
+			unpaid: []*model.BPJSContribution{
+				{ID: 1, Program: model.BPJSProgramJHT, EmployeeAmount: money.New(100, "IDR"), EmployerAmount: money.New(185, "IDR")},
+			},
+			debitAmount: 285,
+			errDebit:    model.ErrInsufficientBalance,
+			expectedErr: model.ErrInsufficientBalance,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockContributionRepository := new(mocks.BPJSContributionRepository)
+			mockTxManager := new(mocks.TxManager)
+
+			mockTxManager.On("WithinTransaction", mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+			mockContributionRepository.On("LockUnpaidByMonth", mock.Anything, month).Return(tt.unpaid, nil)
+			mockCompanyRepository.On("Get", mock.Anything).Return(&model.Company{ID: 1, Balance: money.New(1000, "IDR")}, nil)
+
+			if tt.debitAmount > 0 {
+				mockCompanyRepository.On("DebitBalance", mock.Anything, &model.Transaction{
+					Amount: money.New(tt.debitAmount, "IDR"),
+					Note:   "BPJS contributions " + month,
+					Period: &month,
+				}).Return(tt.expectedTrx, tt.errDebit)
+			}
+
+			if tt.expectedTrx != nil {
+				ids := make([]int, 0, len(tt.unpaid))
+				for _, contribution := range tt.unpaid {
+					ids = append(ids, contribution.ID)
+				}
+				mockContributionRepository.On("MarkPaid", mock.Anything, ids, tt.expectedTrx.ID).Return(nil)
+			}
+
+			b := usecase.NewBPJSUsecase(mockCompanyRepository, new(mocks.BPJSRateRepository), mockContributionRepository, mockTxManager)
+
+			trx, err := b.PayContributions(context.TODO(), month)
+
+			assert.Equal(t, tt.expectedTrx, trx)
+			assert.Equal(t, tt.expectedErr, err)
+
+			mockCompanyRepository.AssertExpectations(t)
+			mockContributionRepository.AssertExpectations(t)
+			mockTxManager.AssertExpectations(t)
+		})
+	}
+}
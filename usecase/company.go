@@ -2,17 +2,25 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"self-payrol/model"
 	"self-payrol/request"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 type companyUsecase struct {
-	companyRepo model.CompanyRepository
+	companyRepo    model.CompanyRepository
+	withdrawalRepo model.WithdrawalRepository
+	notifier       model.Notifier
+	now            func() time.Time
 }
 
-func NewCompanyUsecase(repo model.CompanyRepository) model.CompanyUsecase {
-	return &companyUsecase{companyRepo: repo}
+func NewCompanyUsecase(repo model.CompanyRepository, withdrawal model.WithdrawalRepository, notifier model.Notifier) model.CompanyUsecase {
+	return &companyUsecase{companyRepo: repo, withdrawalRepo: withdrawal, notifier: notifier, now: time.Now}
 }
 
 func (c *companyUsecase) GetCompanyInfo(ctx context.Context) (*model.Company, int, error) {
@@ -24,10 +32,22 @@ func (c *companyUsecase) GetCompanyInfo(ctx context.Context) (*model.Company, in
 }
 
 func (c *companyUsecase) CreateOrUpdateCompany(ctx context.Context, req request.CompanyRequest) (*model.Company, int, error) {
+	if err := c.checkCycleChange(ctx, req.PayrollCycle); err != nil {
+		if errors.Is(err, model.ErrPayrollCycleInUse) {
+			return nil, http.StatusConflict, err
+		}
+		return nil, http.StatusUnprocessableEntity, err
+	}
+
 	company, err := c.companyRepo.CreateOrUpdate(ctx, &model.Company{
-		Name:    req.Name,
-		Address: req.Address,
-		Balance: req.Balance,
+		Name:            req.Name,
+		Address:         req.Address,
+		Email:           req.Email,
+		Balance:         req.Balance,
+		PayrollCycle:    req.PayrollCycle,
+		ProrationMethod: req.ProrationMethod,
+		AnnualLeaveDays: req.AnnualLeaveDays,
+		TrackAttendance: req.TrackAttendance,
 	})
 
 	if err != nil {
@@ -41,8 +61,62 @@ func (c *companyUsecase) CreateOrUpdateCompany(ctx context.Context, req request.
 func (c *companyUsecase) TopupBalance(ctx context.Context, req request.TopupCompanyBalance) (*model.Company, int, error) {
 	company, err := c.companyRepo.AddBalance(ctx, req.Balance)
 	if err != nil {
+		if errors.Is(err, model.ErrInsufficientBalance) {
+			return nil, http.StatusConflict, err
+		}
 		return nil, http.StatusUnprocessableEntity, err
 	}
 
+	c.notifier.BalanceToppedUp(ctx, company, req.Balance)
+
 	return company, http.StatusOK, nil
 }
+
+// checkCycleChange refuses a new payroll cycle while the days of its
+// current period were already paid in a period of the old cycle. The
+// periods of the new cycle have other codes, so they would not see those
+// withdrawals and pay the same days again.
+func (c *companyUsecase) checkCycleChange(ctx context.Context, cycle string) error {
+	if cycle == "" {
+		return nil
+	}
+
+	company, err := c.companyRepo.Get(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	current := company.PayrollCycle
+	if current == "" {
+		current = model.PayrollCycleMonthly
+	}
+	if current == cycle {
+		return nil
+	}
+
+	now := c.now()
+	next := model.PayrollPeriodOf(cycle, now)
+	checked := make(map[string]bool)
+	for _, day := range []time.Time{now, next.Start, next.End.AddDate(0, 0, -1)} {
+		period := model.PayrollPeriodOf(current, day)
+		if checked[period.Code] {
+			continue
+		}
+		checked[period.Code] = true
+
+		withdrawals, err := c.withdrawalRepo.FetchByPeriod(ctx, period.Code)
+		if err != nil {
+			return err
+		}
+		for _, withdrawal := range withdrawals {
+			if withdrawal.Amount.Amount > 0 {
+				return fmt.Errorf("%w: %s is paid on the %s cycle", model.ErrPayrollCycleInUse, period.Code, current)
+			}
+		}
+	}
+
+	return nil
+}
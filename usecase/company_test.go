@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"self-payrol/model"
 	"self-payrol/model/mocks"
+	"self-payrol/money"
 	"self-payrol/request"
 	"self-payrol/usecase"
 	"testing"
@@ -37,14 +38,14 @@ func Test_companyUsecase_GetCompanyInfo(t *testing.T) {
 				ID:      1,
 				Name:    "PT SEJAHTERA SELAMANYA",
 				Address: "Jln. Malioboro",
-				Balance: 20000000,
+				Balance: money.New(20000000, "IDR"),
 			},
 			repoResponseErr: nil,
 			expectedCompany: &model.Company{
 				ID:      1,
 				Name:    "PT SEJAHTERA SELAMANYA",
 				Address: "Jln. Malioboro",
-				Balance: 20000000,
+				Balance: money.New(20000000, "IDR"),
 			},
 			expectedStatusCode: http.StatusOK,
 			expectedErr:        nil,
@@ -68,7 +69,7 @@ func Test_companyUsecase_GetCompanyInfo(t *testing.T) {
 			mockCompanyRepository.On("Get", mock.Anything).
 				Return(tt.repoResponseCompany, tt.repoResponseErr)
 
-			c := usecase.NewCompanyUsecase(mockCompanyRepository)
+			c := usecase.NewCompanyUsecase(mockCompanyRepository, new(mocks.WithdrawalRepository), new(mocks.Notifier))
 
 			company, statusCode, err := c.GetCompanyInfo(tt.args.ctx)
 
@@ -103,26 +104,26 @@ func Test_companyUsecase_CreateOrUpdateCompany(t *testing.T) {
 				ctx: context.TODO(),
 				req: request.CompanyRequest{
 					Name:    "PT SEJAHTERA SELAMANYA",
-					Balance: 20000000,
+					Balance: money.New(20000000, "IDR"),
 					Address: "Jln. Malioboro",
 				},
 			},
 			repoCompany: &model.Company{
 				Name:    "PT SEJAHTERA SELAMANYA",
-				Balance: 20000000,
+				Balance: money.New(20000000, "IDR"),
 				Address: "Jln. Malioboro",
 			},
 			repoResponseCompany: &model.Company{
 				ID:      1,
 				Name:    "PT SEJAHTERA SELAMANYA",
-				Balance: 20000000,
+				Balance: money.New(20000000, "IDR"),
 				Address: "Jln. Malioboro",
 			},
 			repoResponseErr: nil,
 			expectedCompany: &model.Company{
 				ID:      1,
 				Name:    "PT SEJAHTERA SELAMANYA",
-				Balance: 20000000,
+				Balance: money.New(20000000, "IDR"),
 				Address: "Jln. Malioboro",
 			},
 			expectedStatusCode: http.StatusOK,
@@ -134,26 +135,26 @@ func Test_companyUsecase_CreateOrUpdateCompany(t *testing.T) {
 				ctx: context.TODO(),
 				req: request.CompanyRequest{
 					Name:    "PT MANTAP MANTAP",
-					Balance: 20000000,
+					Balance: money.New(20000000, "IDR"),
 					Address: "Jln. Malioboro No.42",
 				},
 			},
 			repoCompany: &model.Company{
 				Name:    "PT MANTAP MANTAP",
-				Balance: 20000000,
+				Balance: money.New(20000000, "IDR"),
 				Address: "Jln. Malioboro No.42",
 			},
 			repoResponseCompany: &model.Company{
 				ID:      1,
 				Name:    "PT MANTAP MANTAP",
-				Balance: 20000000,
+				Balance: money.New(20000000, "IDR"),
 				Address: "Jln. Malioboro No.42",
 			},
 			repoResponseErr: nil,
 			expectedCompany: &model.Company{
 				ID:      1,
 				Name:    "PT MANTAP MANTAP",
-				Balance: 20000000,
+				Balance: money.New(20000000, "IDR"),
 				Address: "Jln. Malioboro No.42",
 			},
 			expectedStatusCode: http.StatusOK,
@@ -165,13 +166,13 @@ func Test_companyUsecase_CreateOrUpdateCompany(t *testing.T) {
 				ctx: context.TODO(),
 				req: request.CompanyRequest{
 					Name:    "PT MANTAP MANTAP",
-					Balance: 20000000,
+					Balance: money.New(20000000, "IDR"),
 					Address: "Jln. Malioboro No.42",
 				},
 			},
 			repoCompany: &model.Company{
 				Name:    "PT MANTAP MANTAP",
-				Balance: 20000000,
+				Balance: money.New(20000000, "IDR"),
 				Address: "Jln. Malioboro No.42",
 			},
 			repoResponseCompany: nil,
@@ -188,7 +189,7 @@ func Test_companyUsecase_CreateOrUpdateCompany(t *testing.T) {
 			mockCompanyRepository.On("CreateOrUpdate", mock.Anything, tt.repoCompany).
 				Return(tt.repoResponseCompany, tt.repoResponseErr)
 
-			c := usecase.NewCompanyUsecase(mockCompanyRepository)
+			c := usecase.NewCompanyUsecase(mockCompanyRepository, new(mocks.WithdrawalRepository), new(mocks.Notifier))
 
 			company, statusCode, err := c.CreateOrUpdateCompany(tt.args.ctx, tt.args.req)
 
@@ -201,6 +202,62 @@ func Test_companyUsecase_CreateOrUpdateCompany(t *testing.T) {
 	}
 }
 
+func Test_companyUsecase_CreateOrUpdateCompany_PayrollCycle(t *testing.T) {
+	company := &model.Company{ID: 1, Name: "PT MANTAP MANTAP", Address: "Jln. Malioboro", Balance: money.New(20000000, "IDR"), PayrollCycle: model.PayrollCycleMonthly}
+
+	tests := []struct {
+		name               string
+		cycle              string
+		withdrawals        []*model.Withdrawal
+		expectedStatusCode int
+		expectedErr        error
+	}{
+		{
+			name:               "Same cycle",
+			cycle:              model.PayrollCycleMonthly,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "New cycle before anyone is paid",
+			cycle:              model.PayrollCycleSemiMonthly,
+			withdrawals:        []*model.Withdrawal{{ID: 1, UserID: 1, Amount: money.New(0, "IDR")}},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "New cycle once the current period is paid",
+			cycle:              model.PayrollCycleSemiMonthly,
+			withdrawals:        []*model.Withdrawal{{ID: 1, UserID: 1, Amount: money.New(5000000, "IDR")}},
+			expectedStatusCode: http.StatusConflict,
+			expectedErr:        model.ErrPayrollCycleInUse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockWithdrawalRepository := new(mocks.WithdrawalRepository)
+
+			mockCompanyRepository.On("Get", mock.Anything).Return(company, nil)
+			if tt.cycle != company.PayrollCycle {
+				mockWithdrawalRepository.On("FetchByPeriod", mock.Anything, mock.AnythingOfType("string")).Return(tt.withdrawals, nil)
+			}
+			if tt.expectedErr == nil {
+				mockCompanyRepository.On("CreateOrUpdate", mock.Anything, &model.Company{Name: company.Name, Address: company.Address, PayrollCycle: tt.cycle}).
+					Return(company, nil)
+			}
+
+			c := usecase.NewCompanyUsecase(mockCompanyRepository, mockWithdrawalRepository, new(mocks.Notifier))
+
+			_, statusCode, err := c.CreateOrUpdateCompany(context.TODO(), request.CompanyRequest{Name: company.Name, Address: company.Address, PayrollCycle: tt.cycle})
+
+			assert.Equal(t, tt.expectedStatusCode, statusCode)
+			assert.ErrorIs(t, err, tt.expectedErr)
+
+			mockCompanyRepository.AssertExpectations(t)
+			mockWithdrawalRepository.AssertExpectations(t)
+		})
+	}
+}
+
 func Test_companyUsecase_TopupBalance(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -222,20 +279,20 @@ func Test_companyUsecase_TopupBalance(t *testing.T) {
 			args: args{
 				ctx: context.TODO(),
 				req: request.TopupCompanyBalance{
-					Balance: 5000000,
+					Balance: money.New(5000000, "IDR"),
 				},
 			},
 			repoResponseCompany: &model.Company{
 				ID:      1,
 				Name:    "PT SEJAHTERA SELAMANYA",
-				Balance: 25000000,
+				Balance: money.New(25000000, "IDR"),
 				Address: "Jln. Malioboro",
 			},
 			repoResponseErr: nil,
 			expectedCompany: &model.Company{
 				ID:      1,
 				Name:    "PT SEJAHTERA SELAMANYA",
-				Balance: 25000000,
+				Balance: money.New(25000000, "IDR"),
 				Address: "Jln. Malioboro",
 			},
 			expectedStatusCode: http.StatusOK,
@@ -246,7 +303,7 @@ func Test_companyUsecase_TopupBalance(t *testing.T) {
 			args: args{
 				ctx: context.TODO(),
 				req: request.TopupCompanyBalance{
-					Balance: 5000000,
+					Balance: money.New(5000000, "IDR"),
 				},
 			},
 			repoResponseCompany: nil,
@@ -255,15 +312,35 @@ func Test_companyUsecase_TopupBalance(t *testing.T) {
 			expectedStatusCode:  http.StatusUnprocessableEntity,
 			expectedErr:         assert.AnError,
 		},
+		{
+			name: "Topup in another currency than the balance",
+			args: args{
+				ctx: context.TODO(),
+				req: request.TopupCompanyBalance{
+					Balance: money.New(500000, "USD"),
+				},
+			},
+			repoResponseCompany: nil,
+			repoResponseErr:     money.ErrCurrencyMismatch,
+			expectedCompany:     nil,
+			expectedStatusCode:  http.StatusUnprocessableEntity,
+			expectedErr:         money.ErrCurrencyMismatch,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockCompanyRepository := new(mocks.CompanyRepository)
 
+			mockNotifier := new(mocks.Notifier)
+
 			mockCompanyRepository.On("AddBalance", mock.Anything, tt.args.req.Balance).
 				Return(tt.repoResponseCompany, tt.repoResponseErr)
 
-			c := usecase.NewCompanyUsecase(mockCompanyRepository)
+			if tt.repoResponseErr == nil {
+				mockNotifier.On("BalanceToppedUp", mock.Anything, tt.repoResponseCompany, tt.args.req.Balance).Return()
+			}
+
+			c := usecase.NewCompanyUsecase(mockCompanyRepository, new(mocks.WithdrawalRepository), mockNotifier)
 
 			company, statusCode, err := c.TopupBalance(tt.args.ctx, tt.args.req)
 
@@ -272,6 +349,7 @@ func Test_companyUsecase_TopupBalance(t *testing.T) {
 			assert.Equal(t, tt.expectedErr, err)
 
 			mockCompanyRepository.AssertExpectations(t)
+			mockNotifier.AssertExpectations(t)
 		})
 	}
 }
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/request"
+	"time"
+)
+
+type employmentUsecase struct {
+	userRepository  model.UserRepository
+	eventRepository model.EmploymentEventRepository
+	txManager       model.TxManager
+	now             func() time.Time
+}
+
+func NewEmploymentUsecase(user model.UserRepository, event model.EmploymentEventRepository, tx model.TxManager) model.EmploymentUsecase {
+	return &employmentUsecase{userRepository: user, eventRepository: event, txManager: tx, now: time.Now}
+}
+
+func (e *employmentUsecase) ChangeStatus(ctx context.Context, userID int, status string, req *request.EmploymentStatusRequest) (*model.User, error) {
+	now := e.now()
+	date := dateOf(now)
+	if req.Date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", req.Date, now.Location())
+		if err != nil {
+			return nil, err
+		}
+		date = parsed
+	}
+
+	var user *model.User
+	err := e.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := e.userRepository.FindByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if !model.CanChangeStatus(current.Status, status) {
+			return model.ErrInvalidStatusTransition
+		}
+
+		update := &model.User{Status: status}
+		if status == model.EmploymentTerminated {
+			if current.StartDate != nil && date.Before(*current.StartDate) {
+				return model.ErrTerminationBeforeStart
+			}
+			// the last period is prorated up to the last day worked
+			update.EndDate = &date
+		}
+
+		user, err = e.userRepository.UpdateByID(ctx, userID, update)
+		if err != nil {
+			return err
+		}
+
+		_, err = e.eventRepository.Create(ctx, &model.EmploymentEvent{
+			UserID:        userID,
+			From:          current.Status,
+			To:            status,
+			Reason:        req.Reason,
+			EffectiveDate: date,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (e *employmentUsecase) FetchStatusHistory(ctx context.Context, userID int) ([]*model.EmploymentEvent, error) {
+	_, err := e.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := e.eventRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
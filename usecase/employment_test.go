@@ -0,0 +1,100 @@
+package usecase_test
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"self-payrol/request"
+	"self-payrol/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func Test_employmentUsecase_ChangeStatus(t *testing.T) {
+	started := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.Local)
+	lastDay := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name          string
+		current       *model.User
+		repoUserErr   error
+		status        string
+		req           *request.EmploymentStatusRequest
+		expectedWrite *model.User
+		expectedEvent *model.EmploymentEvent
+		expectedErr   error
+	}{
+		{
+			name:          "Active employee is suspended",
+			current:       &model.User{ID: 1, Status: model.EmploymentActive},
+			status:        model.EmploymentSuspended,
+			req:           &request.EmploymentStatusRequest{Reason: "Under investigation", Date: "2026-10-16"},
+			expectedWrite: &model.User{Status: model.EmploymentSuspended},
+			expectedEvent: &model.EmploymentEvent{UserID: 1, From: model.EmploymentActive, To: model.EmploymentSuspended, Reason: "Under investigation", EffectiveDate: lastDay},
+		},
+		{
+			name:          "Termination ends the employment on the last day worked",
+			current:       &model.User{ID: 1, Status: model.EmploymentSuspended, StartDate: &started},
+			status:        model.EmploymentTerminated,
+			req:           &request.EmploymentStatusRequest{Reason: "Resigned", Date: "2026-10-16"},
+			expectedWrite: &model.User{Status: model.EmploymentTerminated, EndDate: &lastDay},
+			expectedEvent: &model.EmploymentEvent{UserID: 1, From: model.EmploymentSuspended, To: model.EmploymentTerminated, Reason: "Resigned", EffectiveDate: lastDay},
+		},
+		{
+			name:        "Termination before the employment started",
+			current:     &model.User{ID: 1, Status: model.EmploymentActive, StartDate: &started},
+			status:      model.EmploymentTerminated,
+			req:         &request.EmploymentStatusRequest{Reason: "Offer withdrawn", Date: "2026-02-27"},
+			expectedErr: model.ErrTerminationBeforeStart,
+		},
+		{
+			name:        "Terminated employee cannot be reinstated",
+			current:     &model.User{ID: 1, Status: model.EmploymentTerminated},
+			status:      model.EmploymentActive,
+			req:         &request.EmploymentStatusRequest{Reason: "Rehired"},
+			expectedErr: model.ErrInvalidStatusTransition,
+		},
+		{
+			name:        "Employee not found",
+			repoUserErr: gorm.ErrRecordNotFound,
+			status:      model.EmploymentSuspended,
+			req:         &request.EmploymentStatusRequest{Reason: "Under investigation"},
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(mocks.UserRepository)
+			mockEventRepository := new(mocks.EmploymentEventRepository)
+			mockTxManager := new(mocks.TxManager)
+
+			mockTxManager.On("WithinTransaction", mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+			mockUserRepository.On("FindByID", mock.Anything, 1).Return(tt.current, tt.repoUserErr)
+
+			updated := &model.User{ID: 1, Status: tt.status}
+			if tt.expectedWrite != nil {
+				mockUserRepository.On("UpdateByID", mock.Anything, 1, tt.expectedWrite).Return(updated, nil)
+				mockEventRepository.On("Create", mock.Anything, tt.expectedEvent).Return(tt.expectedEvent, nil)
+			}
+
+			e := usecase.NewEmploymentUsecase(mockUserRepository, mockEventRepository, mockTxManager)
+
+			user, err := e.ChangeStatus(context.TODO(), 1, tt.status, tt.req)
+
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, updated, user)
+			} else {
+				assert.Nil(t, user)
+			}
+
+			mockUserRepository.AssertExpectations(t)
+			mockEventRepository.AssertExpectations(t)
+		})
+	}
+}
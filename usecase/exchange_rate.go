@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"self-payrol/model"
+	"self-payrol/money"
+	"self-payrol/request"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type exchangeRateUsecase struct {
+	exchangeRateRepository model.ExchangeRateRepository
+	companyRepository      model.CompanyRepository
+	txManager              model.TxManager
+}
+
+func NewExchangeRateUsecase(rate model.ExchangeRateRepository, company model.CompanyRepository, tx model.TxManager) model.ExchangeRateUsecase {
+	return &exchangeRateUsecase{exchangeRateRepository: rate, companyRepository: company, txManager: tx}
+}
+
+func (e *exchangeRateUsecase) FetchRates(ctx context.Context, currency string, limit, offset int) ([]*model.ExchangeRate, error) {
+	rates, err := e.exchangeRateRepository.Fetch(ctx, currency, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// StoreRates saves all the rates or none of them, so a rejected row of an
+// upload can be fixed and the whole file sent again.
+func (e *exchangeRateUsecase) StoreRates(ctx context.Context, req *request.ExchangeRateRequest) ([]*model.ExchangeRate, error) {
+	company, err := e.companyRepository.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make([]*model.ExchangeRate, 0, len(req.Rates))
+	for _, item := range req.Rates {
+		effectiveFrom, err := time.ParseInLocation("2006-01-02", item.EffectiveFrom, time.Local)
+		if err != nil {
+			return nil, err
+		}
+
+		quoteCurrency := item.QuoteCurrency
+		if quoteCurrency == "" {
+			quoteCurrency = company.Balance.Currency
+		}
+		if quoteCurrency == item.Currency {
+			return nil, model.ErrExchangeRateSameCurrency
+		}
+
+		rates = append(rates, &model.ExchangeRate{
+			Currency:      item.Currency,
+			QuoteCurrency: quoteCurrency,
+			Rate:          item.Rate,
+			EffectiveFrom: effectiveFrom,
+		})
+	}
+
+	err = e.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		for _, rate := range rates {
+			if _, err := e.exchangeRateRepository.Upsert(ctx, rate); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// convertMoney converts amount into currency at the rate in effect on t.
+// Rates are kept for a pay currency quoted in balanceCurrency, the one
+// the amount is in or the one it goes to. An amount already in currency
+// comes back as it is, with a nil rate.
+func convertMoney(ctx context.Context, rates model.ExchangeRateRepository, amount money.Money, currency, balanceCurrency string, t time.Time) (money.Money, *model.ExchangeRate, error) {
+	if amount.Currency == currency || amount.Currency == "" {
+		return amount, nil, nil
+	}
+
+	pair := amount.Currency
+	if pair == balanceCurrency {
+		pair = currency
+	}
+
+	rate, err := rates.FindEffective(ctx, pair, balanceCurrency, t)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return money.Money{}, nil, model.ErrNoExchangeRate
+	}
+	if err != nil {
+		return money.Money{}, nil, err
+	}
+
+	converted, err := rate.Convert(amount)
+	if err != nil {
+		return money.Money{}, nil, err
+	}
+
+	return converted, rate, nil
+}
@@ -0,0 +1,70 @@
+package usecase_test
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"self-payrol/money"
+	"self-payrol/request"
+	"self-payrol/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_exchangeRateUsecase_StoreRates(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           *request.ExchangeRateRequest
+		expectedRates []*model.ExchangeRate
+		expectedErr   error
+	}{
+		{
+			name: "Quote currency defaults to the balance currency",
+			req: &request.ExchangeRateRequest{Rates: []request.ExchangeRateItem{
+				{Currency: "USD", Rate: "15825.5", EffectiveFrom: "2026-10-01"},
+				{Currency: "USD", QuoteCurrency: "SGD", Rate: "1.29", EffectiveFrom: "2026-10-01"},
+			}},
+			expectedRates: []*model.ExchangeRate{
+				{Currency: "USD", QuoteCurrency: "IDR", Rate: "15825.5", EffectiveFrom: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.Local)},
+				{Currency: "USD", QuoteCurrency: "SGD", Rate: "1.29", EffectiveFrom: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.Local)},
+			},
+		},
+		{
+			name: "Rate of the balance currency",
+			req: &request.ExchangeRateRequest{Rates: []request.ExchangeRateItem{
+				{Currency: "IDR", Rate: "1", EffectiveFrom: "2026-10-01"},
+			}},
+			expectedErr: model.ErrExchangeRateSameCurrency,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExchangeRateRepository := new(mocks.ExchangeRateRepository)
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockTxManager := new(mocks.TxManager)
+
+			mockCompanyRepository.On("Get", mock.Anything).Return(&model.Company{ID: 1, Balance: money.New(0, "IDR")}, nil)
+
+			if tt.expectedErr == nil {
+				mockTxManager.On("WithinTransaction", mock.Anything, mock.Anything).
+					Return(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+				for _, rate := range tt.expectedRates {
+					mockExchangeRateRepository.On("Upsert", mock.Anything, rate).Return(rate, nil).Once()
+				}
+			}
+
+			e := usecase.NewExchangeRateUsecase(mockExchangeRateRepository, mockCompanyRepository, mockTxManager)
+
+			rates, err := e.StoreRates(context.TODO(), tt.req)
+
+			assert.Equal(t, tt.expectedRates, rates)
+			assert.Equal(t, tt.expectedErr, err)
+
+			mockExchangeRateRepository.AssertExpectations(t)
+			mockTxManager.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"self-payrol/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type idempotencyUsecase struct {
+	idempotencyRepo model.IdempotencyRepository
+	ttl             time.Duration
+	now             func() time.Time
+}
+
+func NewIdempotencyUsecase(repo model.IdempotencyRepository, ttl time.Duration) model.IdempotencyUsecase {
+	return &idempotencyUsecase{idempotencyRepo: repo, ttl: ttl, now: time.Now}
+}
+
+func (i *idempotencyUsecase) Begin(ctx context.Context, key, fingerprint string) (*model.IdempotencyKey, error) {
+	record, err := i.idempotencyRepo.FindByKey(ctx, key)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// an expired key is as good as a fresh one
+	if record != nil && !record.ExpiresAt.After(i.now()) {
+		if err := i.idempotencyRepo.DeleteByKey(ctx, key); err != nil {
+			return nil, err
+		}
+		record = nil
+	}
+
+	if record == nil {
+		_, err := i.idempotencyRepo.Create(ctx, &model.IdempotencyKey{
+			Key:         key,
+			Fingerprint: fingerprint,
+			ExpiresAt:   i.now().Add(i.ttl),
+		})
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.Is(err, model.ErrIdempotencyKeyExists) {
+			return nil, err
+		}
+
+		// another request claimed the key between our read and insert
+		record, err = i.idempotencyRepo.FindByKey(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if record.Fingerprint != fingerprint {
+		return nil, model.ErrIdempotencyKeyReused
+	}
+
+	if record.StatusCode == 0 {
+		return nil, model.ErrIdempotencyKeyInProgress
+	}
+
+	return record, nil
+}
+
+func (i *idempotencyUsecase) Complete(ctx context.Context, key string, statusCode int, response []byte) error {
+	return i.idempotencyRepo.UpdateByKey(ctx, key, &model.IdempotencyKey{
+		StatusCode: statusCode,
+		Response:   response,
+	})
+}
+
+func (i *idempotencyUsecase) Release(ctx context.Context, key string) error {
+	return i.idempotencyRepo.DeleteByKey(ctx, key)
+}
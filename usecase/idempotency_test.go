@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func Test_idempotencyUsecase_Begin(t *testing.T) {
+	type args struct {
+		ctx         context.Context
+		key         string
+		fingerprint string
+	}
+	now := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+	completed := &model.IdempotencyKey{
+		ID:          1,
+		Key:         "key-1",
+		Fingerprint: "abc",
+		StatusCode:  200,
+		Response:    []byte(`{"success":true}`),
+		ExpiresAt:   now.Add(time.Hour),
+	}
+	tests := []struct {
+		name           string
+		args           args
+		repoFound      *model.IdempotencyKey
+		repoFindErr    error
+		expectDelete   bool
+		expectCreate   bool
+		repoCreateErr  error
+		repoRefound    *model.IdempotencyKey
+		expectedRecord *model.IdempotencyKey
+		expectedErr    error
+	}{
+		{
+			name:           "New key is claimed",
+			args:           args{ctx: context.TODO(), key: "key-1", fingerprint: "abc"},
+			repoFindErr:    gorm.ErrRecordNotFound,
+			expectCreate:   true,
+			expectedRecord: nil,
+			expectedErr:    nil,
+		},
+		{
+			name:           "Completed key with same fingerprint is replayed",
+			args:           args{ctx: context.TODO(), key: "key-1", fingerprint: "abc"},
+			repoFound:      completed,
+			expectedRecord: completed,
+			expectedErr:    nil,
+		},
+		{
+			name:        "Same key with a different body",
+			args:        args{ctx: context.TODO(), key: "key-1", fingerprint: "other"},
+			repoFound:   completed,
+			expectedErr: model.ErrIdempotencyKeyReused,
+		},
+		{
+			name: "Key still in progress",
+			args: args{ctx: context.TODO(), key: "key-1", fingerprint: "abc"},
+			repoFound: &model.IdempotencyKey{
+				ID:          1,
+				Key:         "key-1",
+				Fingerprint: "abc",
+				ExpiresAt:   now.Add(time.Hour),
+			},
+			expectedErr: model.ErrIdempotencyKeyInProgress,
+		},
+		{
+			name: "Expired key is claimed again",
+			args: args{ctx: context.TODO(), key: "key-1", fingerprint: "other"},
+			repoFound: &model.IdempotencyKey{
+				ID:          1,
+				Key:         "key-1",
+				Fingerprint: "abc",
+				StatusCode:  200,
+				ExpiresAt:   now.Add(-time.Minute),
+			},
+			expectDelete:   true,
+			expectCreate:   true,
+			expectedRecord: nil,
+			expectedErr:    nil,
+		},
+		{
+			name:          "Lost the race to a concurrent request",
+			args:          args{ctx: context.TODO(), key: "key-1", fingerprint: "abc"},
+			repoFindErr:   gorm.ErrRecordNotFound,
+			expectCreate:  true,
+			repoCreateErr: model.ErrIdempotencyKeyExists,
+			repoRefound: &model.IdempotencyKey{
+				ID:          1,
+				Key:         "key-1",
+				Fingerprint: "abc",
+				ExpiresAt:   now.Add(time.Hour),
+			},
+			expectedErr: model.ErrIdempotencyKeyInProgress,
+		},
+		{
+			name:        "Failed to find key",
+			args:        args{ctx: context.TODO(), key: "key-1", fingerprint: "abc"},
+			repoFindErr: assert.AnError,
+			expectedErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockIdempotencyRepository := new(mocks.IdempotencyRepository)
+
+			mockIdempotencyRepository.On("FindByKey", mock.Anything, tt.args.key).
+				Return(tt.repoFound, tt.repoFindErr).Once()
+
+			if tt.expectDelete {
+				mockIdempotencyRepository.On("DeleteByKey", mock.Anything, tt.args.key).
+					Return(nil)
+			}
+
+			if tt.expectCreate {
+				mockIdempotencyRepository.On("Create", mock.Anything, &model.IdempotencyKey{
+					Key:         tt.args.key,
+					Fingerprint: tt.args.fingerprint,
+					ExpiresAt:   now.Add(24 * time.Hour),
+				}).Return(nil, tt.repoCreateErr)
+			}
+
+			if tt.repoRefound != nil {
+				mockIdempotencyRepository.On("FindByKey", mock.Anything, tt.args.key).
+					Return(tt.repoRefound, nil).Once()
+			}
+
+			i := &idempotencyUsecase{
+				idempotencyRepo: mockIdempotencyRepository,
+				ttl:             24 * time.Hour,
+				now:             func() time.Time { return now },
+			}
+
+			record, err := i.Begin(tt.args.ctx, tt.args.key, tt.args.fingerprint)
+
+			assert.Equal(t, tt.expectedRecord, record)
+			assert.Equal(t, tt.expectedErr, err)
+
+			mockIdempotencyRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_idempotencyUsecase_Complete(t *testing.T) {
+	mockIdempotencyRepository := new(mocks.IdempotencyRepository)
+
+	mockIdempotencyRepository.On("UpdateByKey", mock.Anything, "key-1", &model.IdempotencyKey{
+		StatusCode: 200,
+		Response:   []byte(`{"success":true}`),
+	}).Return(nil)
+
+	i := NewIdempotencyUsecase(mockIdempotencyRepository, time.Hour)
+
+	err := i.Complete(context.TODO(), "key-1", 200, []byte(`{"success":true}`))
+
+	assert.NoError(t, err)
+	mockIdempotencyRepository.AssertExpectations(t)
+}
@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/request"
+	"time"
+)
+
+type leaveUsecase struct {
+	userRepository    model.UserRepository
+	leaveRepository   model.LeaveRepository
+	companyRepository model.CompanyRepository
+	txManager         model.TxManager
+	now               func() time.Time
+}
+
+func NewLeaveUsecase(user model.UserRepository, leave model.LeaveRepository, company model.CompanyRepository, tx model.TxManager) model.LeaveUsecase {
+	return &leaveUsecase{userRepository: user, leaveRepository: leave, companyRepository: company, txManager: tx, now: time.Now}
+}
+
+func (l *leaveUsecase) GetByID(ctx context.Context, userID, id int) (*model.Leave, error) {
+	leave, err := l.leaveRepository.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return leave, nil
+}
+
+func (l *leaveUsecase) FetchLeave(ctx context.Context, userID int) ([]*model.Leave, error) {
+	_, err := l.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	leaves, err := l.leaveRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return leaves, nil
+}
+
+// RequestLeave checks annual leave against what is left of the balance,
+// counting the requests still waiting for review.
+func (l *leaveUsecase) RequestLeave(ctx context.Context, userID int, req *request.LeaveRequest) (*model.Leave, error) {
+	user, err := l.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := l.now().Location()
+	start, err := time.ParseInLocation("2006-01-02", req.StartDate, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := time.ParseInLocation("2006-01-02", req.EndDate, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	days := model.LeaveDays(start, end)
+	if days == 0 {
+		return nil, model.ErrLeaveNoWorkingDays
+	}
+
+	overlapping, err := l.leaveRepository.FetchBetween(ctx, userID, start, end.AddDate(0, 0, 1), model.LeaveStatusPending, model.LeaveStatusApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(overlapping) > 0 {
+		return nil, model.ErrLeaveOverlaps
+	}
+
+	if req.Type == model.LeaveAnnual {
+		balance, err := l.balance(ctx, user, start.Year())
+		if err != nil {
+			return nil, err
+		}
+
+		if days > balance.Remaining {
+			return nil, model.ErrInsufficientLeave
+		}
+	}
+
+	leave, err := l.leaveRepository.Create(ctx, &model.Leave{
+		UserID:    userID,
+		Type:      req.Type,
+		StartDate: start,
+		EndDate:   end,
+		Days:      days,
+		Reason:    req.Reason,
+		Status:    model.LeaveStatusPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return leave, nil
+}
+
+// ApproveLeave checks annual leave against the balance again, other
+// requests may have been approved since it was made.
+func (l *leaveUsecase) ApproveLeave(ctx context.Context, userID, id int) (*model.Leave, error) {
+	user, err := l.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.review(ctx, userID, id, model.LeaveStatusApproved, func(leave *model.Leave) error {
+		if leave.Type != model.LeaveAnnual {
+			return nil
+		}
+
+		balance, err := l.balance(ctx, user, leave.StartDate.Year())
+		if err != nil {
+			return err
+		}
+
+		if balance.Taken+leave.Days > balance.Accrued {
+			return model.ErrInsufficientLeave
+		}
+		return nil
+	})
+}
+
+func (l *leaveUsecase) RejectLeave(ctx context.Context, userID, id int) (*model.Leave, error) {
+	return l.review(ctx, userID, id, model.LeaveStatusRejected, func(leave *model.Leave) error {
+		return nil
+	})
+}
+
+func (l *leaveUsecase) review(ctx context.Context, userID, id int, status string, check func(leave *model.Leave) error) (*model.Leave, error) {
+	var leave *model.Leave
+	err := l.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := l.leaveRepository.Lock(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		if current.Status != model.LeaveStatusPending {
+			return model.ErrLeaveNotPending
+		}
+
+		if err := check(current); err != nil {
+			return err
+		}
+
+		now := l.now()
+		leave, err = l.leaveRepository.UpdateByID(ctx, id, &model.Leave{
+			Status:     status,
+			ReviewedAt: &now,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return leave, nil
+}
+
+func (l *leaveUsecase) GetBalance(ctx context.Context, userID int) (*model.LeaveBalance, error) {
+	user, err := l.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.balance(ctx, user, l.now().Year())
+}
+
+// balance works out the annual leave of year accrued by today.
+func (l *leaveUsecase) balance(ctx context.Context, user *model.User, year int) (*model.LeaveBalance, error) {
+	company, err := l.companyRepository.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := l.now()
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, now.Location())
+
+	leaves, err := l.leaveRepository.FetchBetween(ctx, user.ID, from, from.AddDate(1, 0, 0), model.LeaveStatusPending, model.LeaveStatusApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	balance := &model.LeaveBalance{
+		Year:    year,
+		Accrued: model.AnnualLeaveAccrued(company.AnnualLeaveDays, user.StartDate, year, now),
+	}
+
+	for _, leave := range leaves {
+		if leave.Type != model.LeaveAnnual || leave.StartDate.Year() != year {
+			continue
+		}
+
+		if leave.Status == model.LeaveStatusApproved {
+			balance.Taken += leave.Days
+		} else {
+			balance.Pending += leave.Days
+		}
+	}
+
+	balance.Remaining = balance.Accrued - balance.Taken - balance.Pending
+	return balance, nil
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"self-payrol/request"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_leaveUsecase_RequestLeave(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+	hired := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	user := &model.User{ID: 1, StartDate: &hired}
+	day := func(m time.Month, d int) time.Time { return time.Date(2026, m, d, 0, 0, 0, 0, time.UTC) }
+
+	// nine months accrued by 18 October, five days taken and two waiting
+	taken := []*model.Leave{
+		{ID: 1, UserID: 1, Type: model.LeaveAnnual, StartDate: day(time.May, 4), EndDate: day(time.May, 8), Days: 5, Status: model.LeaveStatusApproved},
+		{ID: 2, UserID: 1, Type: model.LeaveAnnual, StartDate: day(time.December, 1), EndDate: day(time.December, 2), Days: 2, Status: model.LeaveStatusPending},
+		{ID: 3, UserID: 1, Type: model.LeaveSick, StartDate: day(time.June, 1), EndDate: day(time.June, 3), Days: 3, Status: model.LeaveStatusApproved},
+	}
+
+	tests := []struct {
+		name        string
+		req         *request.LeaveRequest
+		overlapping []*model.Leave
+		expected    *model.Leave
+		expectedErr error
+	}{
+		{
+			name: "Annual leave within what is left",
+			req:  &request.LeaveRequest{Type: model.LeaveAnnual, StartDate: "2026-10-22", EndDate: "2026-10-23", Reason: "Wedding"},
+			expected: &model.Leave{
+				UserID: 1, Type: model.LeaveAnnual, StartDate: day(time.October, 22), EndDate: day(time.October, 23),
+				Days: 2, Reason: "Wedding", Status: model.LeaveStatusPending,
+			},
+		},
+		{
+			name:        "Annual leave beyond the balance",
+			req:         &request.LeaveRequest{Type: model.LeaveAnnual, StartDate: "2026-10-21", EndDate: "2026-10-23"},
+			expectedErr: model.ErrInsufficientLeave,
+		},
+		{
+			name: "Unpaid leave over a weekend counts working days only",
+			req:  &request.LeaveRequest{Type: model.LeaveUnpaid, StartDate: "2026-10-23", EndDate: "2026-10-27"},
+			expected: &model.Leave{
+				UserID: 1, Type: model.LeaveUnpaid, StartDate: day(time.October, 23), EndDate: day(time.October, 27),
+				Days: 3, Status: model.LeaveStatusPending,
+			},
+		},
+		{
+			name:        "Overlapping another request",
+			req:         &request.LeaveRequest{Type: model.LeaveSick, StartDate: "2026-12-02", EndDate: "2026-12-02"},
+			overlapping: taken[1:2],
+			expectedErr: model.ErrLeaveOverlaps,
+		},
+		{
+			name:        "Weekend only",
+			req:         &request.LeaveRequest{Type: model.LeaveUnpaid, StartDate: "2026-10-24", EndDate: "2026-10-25"},
+			expectedErr: model.ErrLeaveNoWorkingDays,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(mocks.UserRepository)
+			mockLeaveRepository := new(mocks.LeaveRepository)
+			mockCompanyRepository := new(mocks.CompanyRepository)
+
+			mockUserRepository.On("FindByID", mock.Anything, 1).Return(user, nil)
+			mockCompanyRepository.On("Get", mock.Anything).Return(&model.Company{ID: 1, AnnualLeaveDays: 12}, nil)
+
+			if tt.expectedErr != model.ErrLeaveNoWorkingDays {
+				start, _ := time.Parse("2006-01-02", tt.req.StartDate)
+				end, _ := time.Parse("2006-01-02", tt.req.EndDate)
+				mockLeaveRepository.On("FetchBetween", mock.Anything, 1, start, end.AddDate(0, 0, 1), model.LeaveStatusPending, model.LeaveStatusApproved).
+					Return(tt.overlapping, nil)
+			}
+			mockLeaveRepository.On("FetchBetween", mock.Anything, 1, day(time.January, 1), time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC), model.LeaveStatusPending, model.LeaveStatusApproved).
+				Return(taken, nil)
+
+			created := &model.Leave{ID: 9, UserID: 1}
+			if tt.expected != nil {
+				mockLeaveRepository.On("Create", mock.Anything, tt.expected).Return(created, nil)
+			}
+
+			l := &leaveUsecase{
+				userRepository:    mockUserRepository,
+				leaveRepository:   mockLeaveRepository,
+				companyRepository: mockCompanyRepository,
+				now:               func() time.Time { return now },
+			}
+
+			leave, err := l.RequestLeave(context.TODO(), 1, tt.req)
+
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, created, leave)
+			} else {
+				assert.Nil(t, leave)
+			}
+		})
+	}
+}
+
+func Test_AnnualLeaveAccrued(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+	hired := time.Date(2026, time.March, 20, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 9, model.AnnualLeaveAccrued(12, nil, 2026, now))
+	// 20 March to 18 October is six completed months
+	assert.Equal(t, 6, model.AnnualLeaveAccrued(12, &hired, 2026, now))
+	assert.Equal(t, 12, model.AnnualLeaveAccrued(12, nil, 2025, now))
+	assert.Equal(t, 0, model.AnnualLeaveAccrued(12, nil, 2027, now))
+}
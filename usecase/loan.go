@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"self-payrol/model"
+	"self-payrol/request"
+	"time"
+)
+
+type loanUsecase struct {
+	userRepository    model.UserRepository
+	loanRepository    model.LoanRepository
+	companyRepository model.CompanyRepository
+	txManager         model.TxManager
+	now               func() time.Time
+}
+
+func NewLoanUsecase(user model.UserRepository, loan model.LoanRepository, company model.CompanyRepository, tx model.TxManager) model.LoanUsecase {
+	return &loanUsecase{userRepository: user, loanRepository: loan, companyRepository: company, txManager: tx, now: time.Now}
+}
+
+func (l *loanUsecase) GetByID(ctx context.Context, userID, id int) (*model.Loan, error) {
+	loan, err := l.loanRepository.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	company, err := l.companyRepository.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the installment of the current period may have been taken already
+	period := model.PayrollPeriodOf(company.PayrollCycle, l.now())
+	for _, repayment := range loan.Repayments {
+		if repayment.Period == period.Code {
+			period = model.PayrollPeriodOf(company.PayrollCycle, period.End)
+			break
+		}
+	}
+	loan.Schedule, err = loan.ScheduleFrom(company.PayrollCycle, period)
+	if err != nil {
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+func (l *loanUsecase) FetchLoan(ctx context.Context, userID int) ([]*model.Loan, error) {
+	_, err := l.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loans, err := l.loanRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return loans, nil
+}
+
+// GrantLoan splits the loan in req.Installments equal installments rounded
+// down, the first amount % installments ones taking a minor unit more, so
+// it is repaid in exactly that many periods.
+func (l *loanUsecase) GrantLoan(ctx context.Context, userID int, req *request.LoanRequest) (*model.Loan, error) {
+	user, err := l.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Status != model.EmploymentActive {
+		return nil, fmt.Errorf("%w: %s", model.ErrEmployeeNotActive, user.Status)
+	}
+
+	installment, err := req.Amount.Prorate(1, int64(req.Installments))
+	if err != nil {
+		return nil, err
+	}
+	if installment.Amount <= 0 {
+		return nil, fmt.Errorf("%w: %s in %d", model.ErrLoanTooSmall, req.Amount, req.Installments)
+	}
+
+	company, err := l.companyRepository.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := l.now()
+	period := model.PayrollPeriodOf(company.PayrollCycle, now)
+	if req.FirstPeriod != "" {
+		period, err = model.ParsePayrollPeriod(req.FirstPeriod, now.Location())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var loan *model.Loan
+	err = l.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		loan, err = l.loanRepository.Create(ctx, &model.Loan{
+			UserID:       userID,
+			Principal:    req.Amount,
+			Reason:       req.Reason,
+			Installments: req.Installments,
+			Installment:  installment,
+			FirstPeriod:  period.Code,
+			FirstDue:     period.Start,
+			Outstanding:  req.Amount,
+			Status:       model.LoanStatusActive,
+		})
+		if err != nil {
+			return err
+		}
+
+		trx, err := l.companyRepository.DebitBalance(ctx, &model.Transaction{
+			Amount:     req.Amount,
+			Note:       user.Name + " loan: " + req.Reason,
+			Category:   model.TransactionCategoryLoan,
+			UserID:     &user.ID,
+			PositionID: &user.PositionID,
+			Lines: []model.TransactionLine{{
+				Code:       model.PayCodeLoan,
+				Name:       req.Reason,
+				Kind:       model.PayLineEarning,
+				Amount:     req.Amount,
+				SourceType: loanSource,
+				SourceID:   &loan.ID,
+			}},
+		})
+		if err != nil {
+			return err
+		}
+
+		loan.TransactionID = &trx.ID
+		loan, err = l.loanRepository.UpdateByID(ctx, loan.ID, loan)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return loan, nil
+}
+
+func (l *loanUsecase) PayOffLoan(ctx context.Context, userID, id int) (*model.Loan, error) {
+	user, err := l.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	company, err := l.companyRepository.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := l.now()
+	period := model.PayrollPeriodOf(company.PayrollCycle, now)
+
+	err = l.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// the user scoped read keeps employees to their own loans
+		if _, err := l.loanRepository.FindByID(ctx, userID, id); err != nil {
+			return err
+		}
+
+		loan, err := l.loanRepository.Lock(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if loan.Status == model.LoanStatusRepaid {
+			return model.ErrLoanRepaid
+		}
+
+		amount := loan.Outstanding
+		trx, err := l.companyRepository.CreditBalance(ctx, &model.Transaction{
+			Amount:     amount,
+			Note:       user.Name + " loan payoff: " + loan.Reason,
+			Category:   model.TransactionCategoryLoanRepayment,
+			UserID:     &user.ID,
+			PositionID: &user.PositionID,
+			Period:     &period.Code,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := loan.Repay(amount, now); err != nil {
+			return err
+		}
+		if _, err := l.loanRepository.UpdateByID(ctx, id, loan); err != nil {
+			return err
+		}
+
+		_, err = l.loanRepository.CreateRepayment(ctx, &model.LoanRepayment{
+			LoanID:        id,
+			Kind:          model.LoanRepaymentPayoff,
+			Period:        period.Code,
+			Amount:        amount,
+			TransactionID: trx.ID,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return l.GetByID(ctx, userID, id)
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"self-payrol/money"
+	"self-payrol/request"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_loanUsecase_GrantLoan(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+	november := time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)
+	active := &model.User{ID: 1, Name: "Siti", PositionID: 2, Status: model.EmploymentActive}
+	suspended := &model.User{ID: 1, Name: "Siti", PositionID: 2, Status: model.EmploymentSuspended}
+
+	tests := []struct {
+		name         string
+		user         *model.User
+		req          *request.LoanRequest
+		expectedLoan *model.Loan
+		errDebit     error
+		expectedErr  error
+	}{
+		{
+			name: "Granted and repaid from the next period",
+			user: active,
+			req:  &request.LoanRequest{Amount: money.New(1000000, "IDR"), Reason: "School fees", Installments: 3, FirstPeriod: "2026-11"},
+			expectedLoan: &model.Loan{
+				UserID: 1, Principal: money.New(1000000, "IDR"), Reason: "School fees", Installments: 3, Installment: money.New(333333, "IDR"),
+				FirstPeriod: "2026-11", FirstDue: november, Outstanding: money.New(1000000, "IDR"), Status: model.LoanStatusActive,
+			},
+		},
+		{
+			name:         "Company balance too low",
+			user:         active,
+			req:          &request.LoanRequest{Amount: money.New(1000000, "IDR"), Reason: "School fees", Installments: 4},
+			expectedLoan: &model.Loan{UserID: 1, Principal: money.New(1000000, "IDR"), Reason: "School fees", Installments: 4, Installment: money.New(250000, "IDR"), FirstPeriod: "2026-10", FirstDue: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), Outstanding: money.New(1000000, "IDR"), Status: model.LoanStatusActive},
+			errDebit:     model.ErrInsufficientBalance,
+			expectedErr:  model.ErrInsufficientBalance,
+		},
+		{
+			name:        "Suspended employee",
+			user:        suspended,
+			req:         &request.LoanRequest{Amount: money.New(1000000, "IDR"), Reason: "School fees", Installments: 3},
+			expectedErr: fmt.Errorf("%w: %s", model.ErrEmployeeNotActive, model.EmploymentSuspended),
+		},
+		{
+			name:        "Fewer minor units than installments",
+			user:        active,
+			req:         &request.LoanRequest{Amount: money.New(3, "IDR"), Reason: "School fees", Installments: 4},
+			expectedErr: fmt.Errorf("%w: 3 IDR in 4", model.ErrLoanTooSmall),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(mocks.UserRepository)
+			mockLoanRepository := new(mocks.LoanRepository)
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockTxManager := new(mocks.TxManager)
+
+			mockTxManager.On("WithinTransaction", mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+			mockUserRepository.On("FindByID", mock.Anything, 1).Return(tt.user, nil)
+
+			granted := &model.Loan{ID: 5}
+			transactionID := 21
+			if tt.expectedLoan != nil {
+				mockCompanyRepository.On("Get", mock.Anything).Return(&model.Company{ID: 1, Balance: money.New(5000000, "IDR"), PayrollCycle: model.PayrollCycleMonthly}, nil)
+				mockLoanRepository.On("Create", mock.Anything, tt.expectedLoan).Return(&model.Loan{ID: 5, UserID: 1, Outstanding: tt.req.Amount}, nil)
+
+				loanID := 5
+				mockCompanyRepository.On("DebitBalance", mock.Anything, &model.Transaction{
+					Amount: tt.req.Amount, Note: "Siti loan: School fees", Category: model.TransactionCategoryLoan,
+					UserID: &tt.user.ID, PositionID: &tt.user.PositionID,
+					Lines: []model.TransactionLine{{Code: model.PayCodeLoan, Name: "School fees", Kind: model.PayLineEarning, Amount: tt.req.Amount, SourceType: "loan", SourceID: &loanID}},
+				}).Return(&model.Transaction{ID: transactionID}, tt.errDebit)
+
+				if tt.errDebit == nil {
+					mockLoanRepository.On("UpdateByID", mock.Anything, 5, &model.Loan{ID: 5, UserID: 1, Outstanding: tt.req.Amount, TransactionID: &transactionID}).
+						Return(granted, nil)
+				}
+			}
+
+			l := &loanUsecase{
+				userRepository:    mockUserRepository,
+				loanRepository:    mockLoanRepository,
+				companyRepository: mockCompanyRepository,
+				txManager:         mockTxManager,
+				now:               func() time.Time { return now },
+			}
+
+			loan, err := l.GrantLoan(context.TODO(), 1, tt.req)
+
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, granted, loan)
+			} else {
+				assert.Nil(t, loan)
+			}
+
+			mockUserRepository.AssertExpectations(t)
+			mockLoanRepository.AssertExpectations(t)
+			mockCompanyRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_loanUsecase_PayOffLoan(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+	user := &model.User{ID: 1, Name: "Siti", PositionID: 2, Status: model.EmploymentActive}
+	period := "2026-10"
+
+	tests := []struct {
+		name        string
+		current     *model.Loan
+		expectedErr error
+	}{
+		{
+			name: "Outstanding principal paid back early",
+			current: &model.Loan{
+				ID: 5, UserID: 1, Reason: "School fees", Installment: money.New(250000, "IDR"), Outstanding: money.New(750000, "IDR"),
+				FirstDue: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC), Status: model.LoanStatusActive,
+			},
+		},
+		{
+			name:        "Already repaid",
+			current:     &model.Loan{ID: 5, UserID: 1, Status: model.LoanStatusRepaid},
+			expectedErr: model.ErrLoanRepaid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(mocks.UserRepository)
+			mockLoanRepository := new(mocks.LoanRepository)
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockTxManager := new(mocks.TxManager)
+
+			mockTxManager.On("WithinTransaction", mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+			mockUserRepository.On("FindByID", mock.Anything, 1).Return(user, nil)
+			mockCompanyRepository.On("Get", mock.Anything).Return(&model.Company{ID: 1, Balance: money.New(5000000, "IDR"), PayrollCycle: model.PayrollCycleMonthly}, nil)
+			mockLoanRepository.On("FindByID", mock.Anything, 1, 5).Return(tt.current, nil).Once()
+			mockLoanRepository.On("Lock", mock.Anything, 5).Return(tt.current, nil)
+
+			repaid := &model.Loan{ID: 5, UserID: 1, Installment: money.New(250000, "IDR"), Status: model.LoanStatusRepaid, RepaidAt: &now}
+			if tt.expectedErr == nil {
+				mockCompanyRepository.On("CreditBalance", mock.Anything, &model.Transaction{
+					Amount: money.New(750000, "IDR"), Note: "Siti loan payoff: School fees", Category: model.TransactionCategoryLoanRepayment,
+					UserID: &user.ID, PositionID: &user.PositionID, Period: &period,
+				}).Return(&model.Transaction{ID: 22}, nil)
+				mockLoanRepository.On("UpdateByID", mock.Anything, 5, mock.MatchedBy(func(loan *model.Loan) bool {
+					return loan.Outstanding.IsZero() && loan.Status == model.LoanStatusRepaid && loan.RepaidAt.Equal(now)
+				})).Return(repaid, nil)
+				mockLoanRepository.On("CreateRepayment", mock.Anything, &model.LoanRepayment{
+					LoanID: 5, Kind: model.LoanRepaymentPayoff, Period: period, Amount: money.New(750000, "IDR"), TransactionID: 22,
+				}).Return(&model.LoanRepayment{ID: 3}, nil)
+				mockLoanRepository.On("FindByID", mock.Anything, 1, 5).Return(repaid, nil).Once()
+			}
+
+			l := &loanUsecase{
+				userRepository:    mockUserRepository,
+				loanRepository:    mockLoanRepository,
+				companyRepository: mockCompanyRepository,
+				txManager:         mockTxManager,
+				now:               func() time.Time { return now },
+			}
+
+			loan, err := l.PayOffLoan(context.TODO(), 1, 5)
+
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, repaid, loan)
+				assert.Empty(t, loan.Schedule)
+			} else {
+				assert.Nil(t, loan)
+			}
+
+			mockLoanRepository.AssertExpectations(t)
+			mockCompanyRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_Loan_ScheduleFrom(t *testing.T) {
+	november := time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)
+	october := model.PayrollPeriodOf(model.PayrollCycleMonthly, time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC))
+
+	// the first installment of 333,334 is paid
+	loan := &model.Loan{Principal: money.New(1000000, "IDR"), Installments: 3, Installment: money.New(333333, "IDR"), Outstanding: money.New(666666, "IDR"), FirstDue: november}
+	schedule, err := loan.ScheduleFrom(model.PayrollCycleMonthly, october)
+	require.NoError(t, err)
+	assert.Equal(t, []model.LoanInstallment{
+		{Period: "2026-11", Amount: money.New(333333, "IDR")},
+		{Period: "2026-12", Amount: money.New(333333, "IDR")},
+	}, schedule)
+
+	// a small loan is still repaid in as many installments as granted
+	loan = &model.Loan{Principal: money.New(5, "IDR"), Installments: 4, Installment: money.New(1, "IDR"), Outstanding: money.New(5, "IDR"), FirstDue: november}
+	schedule, err = loan.ScheduleFrom(model.PayrollCycleMonthly, october)
+	require.NoError(t, err)
+	assert.Equal(t, []model.LoanInstallment{
+		{Period: "2026-11", Amount: money.New(2, "IDR")},
+		{Period: "2026-12", Amount: money.New(1, "IDR")},
+		{Period: "2027-01", Amount: money.New(1, "IDR")},
+		{Period: "2027-02", Amount: money.New(1, "IDR")},
+	}, schedule)
+
+	// an installment cut short by a small pay is finished by the next one
+	loan = &model.Loan{Principal: money.New(3000, "IDR"), Installments: 3, Installment: money.New(1000, "IDR"), Outstanding: money.New(2600, "IDR"), FirstDue: november}
+	schedule, err = loan.ScheduleFrom(model.PayrollCycleMonthly, october)
+	require.NoError(t, err)
+	assert.Equal(t, []model.LoanInstallment{
+		{Period: "2026-11", Amount: money.New(600, "IDR")},
+		{Period: "2026-12", Amount: money.New(1000, "IDR")},
+		{Period: "2027-01", Amount: money.New(1000, "IDR")},
+	}, schedule)
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"self-payrol/model"
+	"self-payrol/request"
+	"time"
+)
+
+type overtimeUsecase struct {
+	userRepository     model.UserRepository
+	overtimeRepository model.OvertimeRepository
+	salaryRepository   model.PositionSalaryRepository
+	userSalaryRepo     model.UserSalaryRepository
+	now                func() time.Time
+}
+
+func NewOvertimeUsecase(user model.UserRepository, overtime model.OvertimeRepository, salary model.PositionSalaryRepository, userSalary model.UserSalaryRepository) model.OvertimeUsecase {
+	return &overtimeUsecase{userRepository: user, overtimeRepository: overtime, salaryRepository: salary, userSalaryRepo: userSalary, now: time.Now}
+}
+
+func (o *overtimeUsecase) GetByID(ctx context.Context, userID, id int) (*model.Overtime, error) {
+	overtime, err := o.overtimeRepository.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return overtime, nil
+}
+
+func (o *overtimeUsecase) FetchOvertime(ctx context.Context, userID int) ([]*model.Overtime, error) {
+	_, err := o.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	overtime, err := o.overtimeRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return overtime, nil
+}
+
+// StoreOvertime prices the hours at the base salary of the employee on the
+// day worked, the amount does not change when the salary does later.
+func (o *overtimeUsecase) StoreOvertime(ctx context.Context, userID int, req *request.OvertimeRequest) (*model.Overtime, error) {
+	user, err := o.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", req.Date, o.now().Location())
+	if err != nil {
+		return nil, err
+	}
+
+	minutes := int(math.Round(req.Hours * 60))
+	restDay := req.RestDay || model.IsRestDay(date)
+
+	salary, err := baseSalary(ctx, o.salaryRepository, o.userSalaryRepo, user, date)
+	if err != nil {
+		return nil, err
+	}
+
+	hourlyRate, amount, err := model.OvertimePay(salary, minutes, restDay)
+	if err != nil {
+		return nil, err
+	}
+
+	overtime, err := o.overtimeRepository.Create(ctx, &model.Overtime{
+		UserID:     userID,
+		Date:       date,
+		Minutes:    minutes,
+		RestDay:    restDay,
+		HourlyRate: hourlyRate,
+		Amount:     amount,
+		Status:     model.OvertimeStatusPending,
+		Note:       req.Note,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return overtime, nil
+}
+
+func (o *overtimeUsecase) ApproveOvertime(ctx context.Context, userID, id int) (*model.Overtime, error) {
+	return o.review(ctx, userID, id, model.OvertimeStatusApproved)
+}
+
+func (o *overtimeUsecase) RejectOvertime(ctx context.Context, userID, id int) (*model.Overtime, error) {
+	return o.review(ctx, userID, id, model.OvertimeStatusRejected)
+}
+
+func (o *overtimeUsecase) review(ctx context.Context, userID, id int, status string) (*model.Overtime, error) {
+	overtime, err := o.overtimeRepository.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if overtime.Status != model.OvertimeStatusPending {
+		return nil, model.ErrOvertimeNotPending
+	}
+
+	now := o.now()
+	overtime, err = o.overtimeRepository.UpdateByID(ctx, id, &model.Overtime{
+		Status:     status,
+		ReviewedAt: &now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return overtime, nil
+}
+
+func (o *overtimeUsecase) DestroyOvertime(ctx context.Context, userID, id int) error {
+	overtime, err := o.overtimeRepository.FindByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if overtime.PaidTransactionID != nil {
+		return model.ErrOvertimePaid
+	}
+
+	err = o.overtimeRepository.Delete(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package usecase_test
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"self-payrol/money"
+	"self-payrol/request"
+	"self-payrol/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func Test_overtimeUsecase_StoreOvertime(t *testing.T) {
+	// 3,460,000 / 173 = 20,000 an hour
+	user := &model.User{ID: 1, PositionID: 1, Position: &model.Position{ID: 1, Salary: money.New(3460000, "IDR")}}
+	negotiated := money.New(6920000, "IDR")
+
+	tests := []struct {
+		name        string
+		req         *request.OvertimeRequest
+		repoUserErr error
+		salaries    []*model.UserSalary
+		expected    *model.Overtime
+		expectedErr error
+	}{
+		{
+			name: "Working day, first hour 1.5x and the rest 2x",
+			req:  &request.OvertimeRequest{Date: "2026-10-12", Hours: 2},
+			expected: &model.Overtime{
+				UserID: 1, Date: time.Date(2026, time.October, 12, 0, 0, 0, 0, time.Local), Minutes: 120,
+				HourlyRate: money.New(20000, "IDR"), Amount: money.New(70000, "IDR"), Status: model.OvertimeStatusPending,
+			},
+		},
+		{
+			name: "Saturday, 8 hours 2x, the 9th 3x and the 10th 4x",
+			req:  &request.OvertimeRequest{Date: "2026-10-10", Hours: 10, Note: "stock take"},
+			expected: &model.Overtime{
+				UserID: 1, Date: time.Date(2026, time.October, 10, 0, 0, 0, 0, time.Local), Minutes: 600, RestDay: true,
+				HourlyRate: money.New(20000, "IDR"), Amount: money.New(460000, "IDR"), Status: model.OvertimeStatusPending, Note: "stock take",
+			},
+		},
+		{
+			name: "Public holiday on a working day",
+			req:  &request.OvertimeRequest{Date: "2026-10-12", Hours: 1.5, RestDay: true},
+			expected: &model.Overtime{
+				UserID: 1, Date: time.Date(2026, time.October, 12, 0, 0, 0, 0, time.Local), Minutes: 90, RestDay: true,
+				HourlyRate: money.New(20000, "IDR"), Amount: money.New(60000, "IDR"), Status: model.OvertimeStatusPending,
+			},
+		},
+		{
+			name: "Negotiated salary",
+			req:  &request.OvertimeRequest{Date: "2026-10-12", Hours: 2},
+			salaries: []*model.UserSalary{
+				{ID: 1, UserID: 1, Salary: &negotiated, EffectiveFrom: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			expected: &model.Overtime{
+				UserID: 1, Date: time.Date(2026, time.October, 12, 0, 0, 0, 0, time.Local), Minutes: 120,
+				HourlyRate: money.New(40000, "IDR"), Amount: money.New(140000, "IDR"), Status: model.OvertimeStatusPending,
+			},
+		},
+		{
+			name:        "More than 4 hours on a working day",
+			req:         &request.OvertimeRequest{Date: "2026-10-12", Hours: 5},
+			expectedErr: model.ErrOvertimeExceedsLimit,
+		},
+		{
+			name:        "Employee not found",
+			req:         &request.OvertimeRequest{Date: "2026-10-12", Hours: 2},
+			repoUserErr: gorm.ErrRecordNotFound,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(mocks.UserRepository)
+			mockOvertimeRepository := new(mocks.OvertimeRepository)
+			mockSalaryRepository := new(mocks.PositionSalaryRepository)
+			mockUserSalaryRepository := new(mocks.UserSalaryRepository)
+
+			if tt.repoUserErr != nil {
+				mockUserRepository.On("FindByID", mock.Anything, 1).Return(nil, tt.repoUserErr)
+			} else {
+				mockUserRepository.On("FindByID", mock.Anything, 1).Return(user, nil)
+				mockUserSalaryRepository.On("FetchByUserID", mock.Anything, 1).Return(tt.salaries, nil)
+				if tt.salaries == nil {
+					mockSalaryRepository.On("FetchByPositionID", mock.Anything, 1).Return(nil, nil)
+				}
+			}
+
+			if tt.expected != nil {
+				mockOvertimeRepository.On("Create", mock.Anything, tt.expected).Return(tt.expected, nil)
+			}
+
+			o := usecase.NewOvertimeUsecase(mockUserRepository, mockOvertimeRepository, mockSalaryRepository, mockUserSalaryRepository)
+
+			overtime, err := o.StoreOvertime(context.TODO(), 1, tt.req)
+
+			assert.Equal(t, tt.expected, overtime)
+			assert.Equal(t, tt.expectedErr, err)
+
+			mockUserRepository.AssertExpectations(t)
+			mockOvertimeRepository.AssertExpectations(t)
+			mockSalaryRepository.AssertExpectations(t)
+			mockUserSalaryRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_overtimeUsecase_ApproveOvertime(t *testing.T) {
+	tests := []struct {
+		name        string
+		overtime    *model.Overtime
+		expectedErr error
+	}{
+		{
+			name:     "Pending overtime is approved",
+			overtime: &model.Overtime{ID: 3, UserID: 1, Status: model.OvertimeStatusPending},
+		},
+		{
+			name:        "Rejected overtime cannot be approved",
+			overtime:    &model.Overtime{ID: 3, UserID: 1, Status: model.OvertimeStatusRejected},
+			expectedErr: model.ErrOvertimeNotPending,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOvertimeRepository := new(mocks.OvertimeRepository)
+
+			mockOvertimeRepository.On("FindByID", mock.Anything, 1, 3).Return(tt.overtime, nil)
+
+			approved := &model.Overtime{ID: 3, UserID: 1, Status: model.OvertimeStatusApproved}
+			if tt.expectedErr == nil {
+				mockOvertimeRepository.On("UpdateByID", mock.Anything, 3, mock.MatchedBy(func(o *model.Overtime) bool {
+					return o.Status == model.OvertimeStatusApproved && o.ReviewedAt != nil
+				})).Return(approved, nil)
+			}
+
+			o := usecase.NewOvertimeUsecase(new(mocks.UserRepository), mockOvertimeRepository, new(mocks.PositionSalaryRepository), new(mocks.UserSalaryRepository))
+
+			overtime, err := o.ApproveOvertime(context.TODO(), 1, 3)
+
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, approved, overtime)
+			}
+
+			mockOvertimeRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_overtimeUsecase_DestroyOvertime(t *testing.T) {
+	paidID := 9
+
+	tests := []struct {
+		name        string
+		overtime    *model.Overtime
+		expectedErr error
+	}{
+		{
+			name:     "Unpaid overtime is deleted",
+			overtime: &model.Overtime{ID: 3, UserID: 1, Status: model.OvertimeStatusApproved},
+		},
+		{
+			name:        "Paid overtime is kept",
+			overtime:    &model.Overtime{ID: 3, UserID: 1, Status: model.OvertimeStatusApproved, PaidTransactionID: &paidID},
+			expectedErr: model.ErrOvertimePaid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOvertimeRepository := new(mocks.OvertimeRepository)
+
+			mockOvertimeRepository.On("FindByID", mock.Anything, 1, 3).Return(tt.overtime, nil)
+			if tt.expectedErr == nil {
+				mockOvertimeRepository.On("Delete", mock.Anything, 1, 3).Return(nil)
+			}
+
+			o := usecase.NewOvertimeUsecase(new(mocks.UserRepository), mockOvertimeRepository, new(mocks.PositionSalaryRepository), new(mocks.UserSalaryRepository))
+
+			err := o.DestroyOvertime(context.TODO(), 1, 3)
+
+			assert.Equal(t, tt.expectedErr, err)
+
+			mockOvertimeRepository.AssertExpectations(t)
+		})
+	}
+}
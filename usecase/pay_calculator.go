@@ -0,0 +1,762 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"self-payrol/model"
+	"self-payrol/money"
+	"strings"
+	"time"
+)
+
+const (
+	overtimeSource      = "overtime"
+	paymentSource       = "payment"
+	loanSource          = "loan"
+	reimbursementSource = "reimbursement"
+	leaveSource         = "leave"
+	absenceSource       = "absence"
+)
+
+type payCalculator struct {
+	salaryRepo        model.PositionSalaryRepository
+	userSalaryRepo    model.UserSalaryRepository
+	componentRepo     model.PositionComponentRepository
+	companyRepo       model.CompanyRepository
+	bpjsRateRepo      model.BPJSRateRepository
+	contributionRepo  model.BPJSContributionRepository
+	overtimeRepo      model.OvertimeRepository
+	paymentRepo       model.PaymentRepository
+	loanRepo          model.LoanRepository
+	reimbursementRepo model.ReimbursementRepository
+	leaveRepo         model.LeaveRepository
+	attendanceRepo    model.AttendanceRepository
+	exchangeRateRepo  model.ExchangeRateRepository
+	taxCalculator     model.TaxCalculator
+	now               func() time.Time
+}
+
+func NewPayCalculator(salary model.PositionSalaryRepository, userSalary model.UserSalaryRepository, component model.PositionComponentRepository, company model.CompanyRepository, rate model.BPJSRateRepository, contribution model.BPJSContributionRepository, overtime model.OvertimeRepository, payment model.PaymentRepository, loan model.LoanRepository, reimbursement model.ReimbursementRepository, leave model.LeaveRepository, attendance model.AttendanceRepository, exchangeRate model.ExchangeRateRepository, tax model.TaxCalculator) model.PayCalculator {
+	return &payCalculator{salaryRepo: salary, userSalaryRepo: userSalary, componentRepo: component, companyRepo: company, bpjsRateRepo: rate, contributionRepo: contribution, overtimeRepo: overtime, paymentRepo: payment, loanRepo: loan, reimbursementRepo: reimbursement, leaveRepo: leave, attendanceRepo: attendance, exchangeRateRepo: exchangeRate, taxCalculator: tax, now: time.Now}
+}
+
+func (c *payCalculator) Calculate(ctx context.Context, user *model.User, period model.PayrollPeriod) (*model.PayBreakdown, error) {
+	// salaries and components are monthly amounts, the breakdown is built
+	// for the whole month first and cut down to the period at the end.
+	// a salary change takes effect from the first period starting on or
+	// after its effective date
+	salary, err := baseSalary(ctx, c.salaryRepo, c.userSalaryRepo, user, period.Start)
+	if err != nil {
+		return nil, err
+	}
+
+	components, err := c.componentRepo.FetchByPositionID(ctx, user.PositionID)
+	if err != nil {
+		return nil, err
+	}
+
+	company, err := c.companyRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the pay is worked out in the currency the employee is paid in.
+	// Amounts kept in the other of the two currencies, such as BPJS wage
+	// caps, loans and claims, are converted at the rate in effect at the
+	// start of the period, whenever the period is paid.
+	currency := user.PaidIn(company.Balance.Currency)
+	inPay := func(amount money.Money) (money.Money, error) {
+		converted, _, err := convertMoney(ctx, c.exchangeRateRepo, amount, currency, company.Balance.Currency, period.Start)
+		return converted, err
+	}
+	inBalance := func(amount money.Money) (money.Money, error) {
+		converted, _, err := convertMoney(ctx, c.exchangeRateRepo, amount, company.Balance.Currency, company.Balance.Currency, period.Start)
+		return converted, err
+	}
+	monthly := model.NewPayBreakdown(user.ID, period.Code, currency)
+
+	if salary, err = inPay(salary); err != nil {
+		return nil, err
+	}
+
+	// someone who joined or left during the period is paid the salary and
+	// components of the days they were employed
+	proration := period.Proration(company.ProrationMethod, user.StartDate, user.EndDate)
+
+	prorated, err := proration.Apply(salary)
+	if err != nil {
+		return nil, err
+	}
+
+	err = monthly.Add(model.PayLine{
+		Code:    model.PayCodeBaseSalary,
+		Name:    "Base salary",
+		Kind:    model.PayLineEarning,
+		Amount:  prorated,
+		Taxable: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, component := range components {
+		amount, err := inPay(component.Amount)
+		if err != nil {
+			return nil, err
+		}
+		if amount, err = proration.Apply(amount); err != nil {
+			return nil, err
+		}
+
+		line := model.PayLine{
+			Code:       model.PayCodeAllowance,
+			Name:       component.Name,
+			Kind:       model.PayLineEarning,
+			Amount:     amount,
+			Taxable:    component.Taxable,
+			SourceType: "position_component",
+			SourceID:   &component.ID,
+		}
+		if component.Type == model.PositionComponentDeduction {
+			line.Code = model.PayCodeDeduction
+			line.Kind = model.PayLineDeduction
+		}
+
+		if err := monthly.Add(line); err != nil {
+			return nil, err
+		}
+	}
+
+	// BPJS is worked out in the balance currency its wage caps are in
+	wage, err := inBalance(monthly.Gross)
+	if err != nil {
+		return nil, err
+	}
+
+	contributions, err := c.contributions(ctx, company, user, period, wage)
+	if err != nil {
+		return nil, err
+	}
+
+	// overtime and one-off payments are not part of the BPJS wage, they
+	// are added after. Those a withdrawal of the period paid already stay
+	// in, the period is settled again as a whole.
+	paidOvertime, err := c.overtimeRepo.FetchPaidIn(ctx, user.ID, period.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	overtime, err := c.overtimeRepo.FetchPayable(ctx, user.ID, period.End)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, entry := range append(paidOvertime, overtime...) {
+		amount, err := inPay(entry.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		err = monthly.Add(model.PayLine{
+			Code:       model.PayCodeOvertime,
+			Name:       "Overtime " + entry.Date.Format("2 Jan 2006"),
+			Kind:       model.PayLineEarning,
+			Amount:     amount,
+			Taxable:    true,
+			SourceType: overtimeSource,
+			SourceID:   &entry.ID,
+			Settled:    i < len(paidOvertime),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	paidPayments, err := c.paymentRepo.FetchPaidIn(ctx, user.ID, period.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := c.paymentRepo.FetchPayable(ctx, user.ID, period.End)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, payment := range append(paidPayments, payments...) {
+		amount, err := inPay(payment.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		err = monthly.Add(model.PayLine{
+			Code:       model.PayCodePayment,
+			Name:       payment.Reason,
+			Kind:       model.PayLineEarning,
+			Amount:     amount,
+			Taxable:    payment.Taxable,
+			SourceType: paymentSource,
+			SourceID:   &payment.ID,
+			Settled:    i < len(paidPayments),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// days not worked are taken off the pay of the period, and that pay is
+	// not taxed either
+	unpaidLines, err := c.unpaidDays(ctx, company, user, period, proration, salary)
+	if err != nil {
+		return nil, err
+	}
+
+	unpaid := money.New(0, currency)
+	for _, line := range unpaidLines {
+		if err := monthly.Add(line); err != nil {
+			return nil, err
+		}
+		if unpaid, err = unpaid.Add(line.Amount); err != nil {
+			return nil, err
+		}
+	}
+
+	// employee JHT and JP are taken off gross for PPh 21, employer JKK,
+	// JKM and health premiums are a taxable benefit
+	deductible := money.New(0, company.Balance.Currency)
+	for _, contribution := range contributions {
+		name := "BPJS " + strings.ToUpper(contribution.Program)
+		employerTaxable := false
+
+		switch contribution.Program {
+		case model.BPJSProgramJHT, model.BPJSProgramJP:
+			if deductible, err = deductible.Add(contribution.EmployeeAmount); err != nil {
+				return nil, err
+			}
+		default:
+			employerTaxable = true
+		}
+
+		if contribution.EmployeeAmount.Amount > 0 {
+			amount, err := inPay(contribution.EmployeeAmount)
+			if err != nil {
+				return nil, err
+			}
+
+			err = monthly.Add(model.PayLine{
+				Code:       model.PayCodeBPJS + contribution.Program,
+				Name:       name + " employee contribution",
+				Kind:       model.PayLineDeduction,
+				Amount:     amount,
+				SourceType: "bpjs_rate",
+				SourceID:   contribution.rateID,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		if contribution.EmployerAmount.Amount > 0 {
+			amount, err := inPay(contribution.EmployerAmount)
+			if err != nil {
+				return nil, err
+			}
+
+			err = monthly.Add(model.PayLine{
+				Code:       model.PayCodeBPJS + contribution.Program,
+				Name:       name + " employer contribution",
+				Kind:       model.PayLineEmployer,
+				Amount:     amount,
+				Taxable:    employerTaxable,
+				SourceType: "bpjs_rate",
+				SourceID:   contribution.rateID,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// the period's own pay is taxed on top of a month of recurring pay
+	taxable, err := monthly.TaxableGross()
+	if err != nil {
+		return nil, err
+	}
+	if taxable, err = taxable.Sub(unpaid); err != nil {
+		return nil, err
+	}
+
+	own, err := money.New(0, currency).Sub(unpaid)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range monthly.Lines {
+		if line.Taxable && line.Kind == model.PayLineEarning && ownPay(line) {
+			if own, err = own.Add(line.Amount); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// PPh 21 is worked out in the balance currency too
+	if taxable, err = inBalance(taxable); err != nil {
+		return nil, err
+	}
+	if own, err = inBalance(own); err != nil {
+		return nil, err
+	}
+
+	tax, err := c.taxCalculator.Withholding(period, user.TaxStatus, taxable, deductible)
+	if err != nil {
+		return nil, err
+	}
+
+	// half a month withholds half the tax of the recurring pay, and all of
+	// what the period's own pay adds to it
+	withholding := tax.Withholding
+	if period.Halved() && !own.IsZero() {
+		recurringGross, err := taxable.Sub(own)
+		if err != nil {
+			return nil, err
+		}
+
+		recurring, err := c.taxCalculator.Withholding(period, user.TaxStatus, recurringGross, deductible)
+		if err != nil {
+			return nil, err
+		}
+
+		extra, err := tax.Withholding.Sub(recurring.Withholding)
+		if err != nil {
+			return nil, err
+		}
+		if withholding, err = period.Share(recurring.Withholding).Add(extra); err != nil {
+			return nil, err
+		}
+		if withholding.IsNegative() {
+			withholding = money.New(0, company.Balance.Currency)
+		}
+	} else {
+		withholding = period.Share(withholding)
+	}
+	if withholding, err = inPay(withholding); err != nil {
+		return nil, err
+	}
+
+	breakdown := model.NewPayBreakdown(user.ID, period.Code, currency)
+	breakdown.Tax, breakdown.Proration = tax, proration
+	for _, line := range monthly.Lines {
+		// overtime, one-off payments and days not worked belong to the
+		// period itself, they are not shared out whatever the cycle
+		if !ownPay(line) {
+			line.Amount = period.Share(line.Amount)
+		}
+		if err := breakdown.Add(line); err != nil {
+			return nil, err
+		}
+	}
+
+	if withholding.Amount > 0 {
+		err := breakdown.Add(model.PayLine{
+			Code:   model.PayCodePPh21,
+			Name:   "PPh 21 withholding",
+			Kind:   model.PayLineDeduction,
+			Amount: withholding,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, contribution := range contributions {
+		breakdown.Contributions = append(breakdown.Contributions, &model.BPJSContribution{
+			UserID:         user.ID,
+			Period:         period.Code,
+			Program:        contribution.Program,
+			Wage:           period.Share(contribution.Wage),
+			EmployeeAmount: period.Share(contribution.EmployeeAmount),
+			EmployerAmount: period.Share(contribution.EmployerAmount),
+		})
+	}
+
+	// loan installments come off the net pay after tax, as much of the
+	// installment as the pay allows. Installments taken by a withdrawal
+	// of the period already are kept as they were.
+	installments, err := c.loanRepo.FetchInstallmentsIn(ctx, user.ID, period.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, repayment := range installments {
+		amount, err := inPay(repayment.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		err = breakdown.Add(model.PayLine{
+			Code:       model.PayCodeLoanInstallment,
+			Name:       "Loan installment",
+			Kind:       model.PayLineDeduction,
+			Amount:     amount,
+			SourceType: loanSource,
+			SourceID:   &repayment.LoanID,
+			Settled:    true,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	loans, err := c.loanRepo.FetchDue(ctx, user.ID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, loan := range loans {
+		due := loan.InstallmentDue()
+		amount, err := inPay(due)
+		if err != nil {
+			return nil, err
+		}
+		if amount.Amount > breakdown.Net.Amount {
+			amount.Amount = breakdown.Net.Amount
+			if due, err = inBalance(amount); err != nil {
+				return nil, err
+			}
+		}
+		if amount.Amount <= 0 {
+			continue
+		}
+
+		line := model.PayLine{
+			Code:       model.PayCodeLoanInstallment,
+			Name:       "Loan installment",
+			Kind:       model.PayLineDeduction,
+			Amount:     amount,
+			SourceType: loanSource,
+			SourceID:   &loan.ID,
+		}
+		// the loan is repaid in its own currency
+		if !due.SameCurrency(amount) {
+			line.SourceAmount = due
+		}
+
+		err = breakdown.Add(line)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// expense claims are paid back in full on top of the net pay, they are
+	// neither taxed nor there for an installment to take
+	paidClaims, err := c.reimbursementRepo.FetchPaidIn(ctx, user.ID, period.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := c.reimbursementRepo.FetchPayable(ctx, user.ID, period.End)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, claim := range append(paidClaims, claims...) {
+		amount, err := inPay(claim.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		err = breakdown.Add(model.PayLine{
+			Code:       model.PayCodeReimbursement,
+			Name:       claim.Description,
+			Kind:       model.PayLineEarning,
+			Amount:     amount,
+			SourceType: reimbursementSource,
+			SourceID:   &claim.ID,
+			Settled:    i < len(paidClaims),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return breakdown, nil
+}
+
+// ownPay reports a line that belongs to the period itself rather than
+// being a share of the monthly pay.
+func ownPay(line model.PayLine) bool {
+	switch line.SourceType {
+	case overtimeSource, paymentSource, leaveSource, absenceSource:
+		return true
+	}
+	return false
+}
+
+func (c *payCalculator) Settle(ctx context.Context, breakdown *model.PayBreakdown, trx *model.Transaction) error {
+	var overtime, payments, claims []int
+	for _, line := range breakdown.Lines {
+		// paid by an earlier withdrawal of the period
+		if line.SourceID == nil || line.Settled {
+			continue
+		}
+
+		switch line.SourceType {
+		case overtimeSource:
+			overtime = append(overtime, *line.SourceID)
+		case paymentSource:
+			payments = append(payments, *line.SourceID)
+		case reimbursementSource:
+			claims = append(claims, *line.SourceID)
+		case loanSource:
+			amount := line.Amount
+			if !line.SourceAmount.IsZero() {
+				amount = line.SourceAmount
+			}
+			if err := c.repayLoan(ctx, *line.SourceID, amount, breakdown.Period, trx); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := c.overtimeRepo.MarkPaid(ctx, overtime, trx.ID); err != nil {
+		return err
+	}
+
+	if err := c.paymentRepo.MarkPaid(ctx, payments, trx.ID); err != nil {
+		return err
+	}
+
+	if err := c.reimbursementRepo.MarkPaid(ctx, claims, trx.ID); err != nil {
+		return err
+	}
+
+	contributions := make([]*model.BPJSContribution, 0, len(breakdown.Contributions))
+	for _, contribution := range breakdown.Contributions {
+		if contribution.EmployeeAmount.IsZero() && contribution.EmployerAmount.IsZero() {
+			continue
+		}
+
+		contribution.TransactionID = &trx.ID
+		contributions = append(contributions, contribution)
+	}
+
+	return c.contributionRepo.CreateMany(ctx, contributions)
+}
+
+// repayLoan books an installment taken off the withdrawal trx.
+func (c *payCalculator) repayLoan(ctx context.Context, id int, amount money.Money, period string, trx *model.Transaction) error {
+	loan, err := c.loanRepo.Lock(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := loan.Repay(amount, trx.CreatedAt); err != nil {
+		return err
+	}
+	if _, err := c.loanRepo.UpdateByID(ctx, id, loan); err != nil {
+		return err
+	}
+
+	_, err = c.loanRepo.CreateRepayment(ctx, &model.LoanRepayment{
+		LoanID:        id,
+		Kind:          model.LoanRepaymentInstallment,
+		Period:        period,
+		Amount:        amount,
+		TransactionID: trx.ID,
+	})
+	return err
+}
+
+// unpaidDays deducts a day's salary for every working day of unpaid leave
+// in the period and, when the company tracks attendance, for every past
+// working day the employee neither clocked in nor was on leave. The day's
+// salary is the monthly salary over the working days of the month, and the
+// deductions never take more than the salary of the period.
+func (c *payCalculator) unpaidDays(ctx context.Context, company *model.Company, user *model.User, period model.PayrollPeriod, proration *model.Proration, salary money.Money) ([]model.PayLine, error) {
+	leaves, err := c.leaveRepo.FetchBetween(ctx, user.ID, period.Start, period.End, model.LeaveStatusApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := period.Employed(user.StartDate, user.EndDate)
+	if !from.Before(to) {
+		return nil, nil
+	}
+
+	daily := money.New(0, salary.Currency)
+	if days := model.PayrollPeriodOf(model.PayrollCycleMonthly, period.Start).WorkingDays(); days > 0 {
+		if daily, err = salary.Prorate(1, int64(days)); err != nil {
+			return nil, err
+		}
+	}
+
+	var lines []model.PayLine
+	for _, leave := range leaves {
+		if leave.Type != model.LeaveUnpaid {
+			continue
+		}
+
+		days := leave.DaysBetween(from, to)
+		if days == 0 {
+			continue
+		}
+
+		amount, err := daily.Mul(int64(days))
+		if err != nil {
+			return nil, err
+		}
+
+		lines = append(lines, model.PayLine{
+			Code:       model.PayCodeUnpaidLeave,
+			Name:       fmt.Sprintf("Unpaid leave %s to %s, %d days", leave.StartDate.Format("2 Jan"), leave.EndDate.Format("2 Jan 2006"), days),
+			Kind:       model.PayLineDeduction,
+			Amount:     amount,
+			SourceType: leaveSource,
+			SourceID:   &leave.ID,
+		})
+	}
+
+	if company.TracksAttendance() {
+		// today is left out, the employee may not have clocked in yet
+		now := c.now().In(period.Start.Location())
+		until := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		if until.After(to) {
+			until = to
+		}
+
+		if from.Before(until) {
+			attendance, err := c.attendanceRepo.FetchByUserID(ctx, user.ID, from, until)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, day := range model.Absences(from, until, attendance, leaves) {
+				lines = append(lines, model.PayLine{
+					Code:       model.PayCodeAbsence,
+					Name:       "Absence " + day.Format("2 Jan 2006"),
+					Kind:       model.PayLineDeduction,
+					Amount:     daily,
+					SourceType: absenceSource,
+				})
+			}
+		}
+	}
+
+	left, err := proration.Apply(salary)
+	if err != nil {
+		return nil, err
+	}
+	left = period.Share(left)
+
+	deductions := lines[:0]
+	for _, line := range lines {
+		if line.Amount.Amount > left.Amount {
+			line.Amount = left
+		}
+		if line.Amount.Amount <= 0 {
+			continue
+		}
+
+		if left, err = left.Sub(line.Amount); err != nil {
+			return nil, err
+		}
+		deductions = append(deductions, line)
+	}
+
+	return deductions, nil
+}
+
+type monthlyContribution struct {
+	model.BPJSContribution
+	rateID *int
+}
+
+// contributions works out the monthly BPJS contributions on wage, which is
+// base salary plus fixed allowances.
+func (c *payCalculator) contributions(ctx context.Context, company *model.Company, user *model.User, period model.PayrollPeriod, wage money.Money) ([]monthlyContribution, error) {
+	rates, err := bpjsRatesOf(ctx, c.bpjsRateRepo, company)
+	if err != nil {
+		return nil, err
+	}
+
+	contributions := make([]monthlyContribution, 0, len(rates))
+	for _, rate := range rates {
+		base := wage
+		if !rate.WageCap.IsZero() {
+			if !rate.WageCap.SameCurrency(base) {
+				return nil, fmt.Errorf("%w: the %s wage cap is in %s, the wage in %s", money.ErrCurrencyMismatch, rate.Program, rate.WageCap.Currency, base.Currency)
+			}
+			if base.Amount > rate.WageCap.Amount {
+				base = rate.WageCap
+			}
+		}
+
+		employee, err := base.Prorate(int64(rate.EmployeeRateBps), 10000)
+		if err != nil {
+			return nil, err
+		}
+		employer, err := base.Prorate(int64(rate.EmployerRateBps), 10000)
+		if err != nil {
+			return nil, err
+		}
+
+		contribution := monthlyContribution{
+			BPJSContribution: model.BPJSContribution{
+				UserID:         user.ID,
+				Period:         period.Code,
+				Program:        rate.Program,
+				Wage:           base,
+				EmployeeAmount: employee,
+				EmployerAmount: employer,
+			},
+		}
+		if rate.ID != 0 {
+			contribution.rateID = &rate.ID
+		}
+
+		contributions = append(contributions, contribution)
+	}
+
+	return contributions, nil
+}
+
+// companyBPJSRates returns the rates saved for the company, or the statutory
+// defaults when none have been saved yet.
+func companyBPJSRates(ctx context.Context, companyRepo model.CompanyRepository, rateRepo model.BPJSRateRepository) ([]*model.BPJSRate, error) {
+	company, err := companyRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return bpjsRatesOf(ctx, rateRepo, company)
+}
+
+func bpjsRatesOf(ctx context.Context, rateRepo model.BPJSRateRepository, company *model.Company) ([]*model.BPJSRate, error) {
+	rates, err := rateRepo.FetchByCompanyID(ctx, company.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rates) == 0 {
+		rates = model.DefaultBPJSRates(company.ID)
+	}
+
+	return rates, nil
+}
+
+// baseSalary is the negotiated salary of the employee in effect on t, or the
+// salary of their position on t when they have none.
+func baseSalary(ctx context.Context, positionSalaries model.PositionSalaryRepository, userSalaries model.UserSalaryRepository, user *model.User, t time.Time) (money.Money, error) {
+	overrides, err := userSalaries.FetchByUserID(ctx, user.ID)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	if salary := model.OverrideOn(overrides, t); salary != nil {
+		return *salary, nil
+	}
+
+	history, err := positionSalaries.FetchByPositionID(ctx, user.PositionID)
+	if err != nil {
+		return money.Money{}, err
+	}
+
+	return model.SalaryOn(history, t, user.Position.Salary), nil
+}
@@ -0,0 +1,583 @@
+package usecase_test
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"self-payrol/money"
+	"self-payrol/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_payCalculator_Calculate(t *testing.T) {
+	user := &model.User{
+		ID:         1,
+		Name:       "test",
+		TaxStatus:  "K/1",
+		PositionID: 1,
+		Position:   &model.Position{ID: 1, Name: "CEO", Salary: money.New(5001, "IDR")},
+	}
+	october := model.PayrollPeriodOf(model.PayrollCycleMonthly, time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC))
+	secondHalf := model.PayrollPeriodOf(model.PayrollCycleSemiMonthly, time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC))
+	transportID, mealID, unionID := 1, 2, 3
+	components := []*model.PositionComponent{
+		{ID: transportID, PositionID: 1, Name: "Transport", Type: model.PositionComponentAllowance, Amount: money.New(500, "IDR"), Taxable: true},
+		{ID: mealID, PositionID: 1, Name: "Meal", Type: model.PositionComponentAllowance, Amount: money.New(300, "IDR"), Taxable: false},
+		{ID: unionID, PositionID: 1, Name: "Union fee", Type: model.PositionComponentDeduction, Amount: money.New(100, "IDR")},
+	}
+
+	noBPJS := []*model.BPJSRate{{ID: 1, CompanyID: 1, Program: model.BPJSProgramJHT}}
+	jhtID, jpID, jkmID, healthID := 1, 2, 3, 4
+	overtimeID, paymentID, lateOvertimeID := 5, 6, 10
+	loanIDs := []int{7, 8, 9}
+	claimID, leaveID := 11, 13
+	negotiated := money.New(6001, "IDR")
+	hired := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	left := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC)
+	rates := []*model.BPJSRate{
+		{ID: jhtID, CompanyID: 1, Program: model.BPJSProgramJHT, EmployeeRateBps: 200, EmployerRateBps: 370},
+		{ID: jpID, CompanyID: 1, Program: model.BPJSProgramJP, EmployeeRateBps: 100, EmployerRateBps: 200, WageCap: money.New(5000, "IDR")},
+		{ID: jkmID, CompanyID: 1, Program: model.BPJSProgramJKM, EmployerRateBps: 30},
+		{ID: healthID, CompanyID: 1, Program: model.BPJSProgramKesehatan, EmployeeRateBps: 100, EmployerRateBps: 400, WageCap: money.New(4000, "IDR")},
+	}
+
+	tests := []struct {
+		name               string
+		period             model.PayrollPeriod
+		salaries           []*model.PositionSalary
+		overrides          []*model.UserSalary
+		startDate          *time.Time
+		endDate            *time.Time
+		proration          string
+		repoComponents     []*model.PositionComponent
+		repoErr            error
+		rates              []*model.BPJSRate
+		companyErr         error
+		overtime           []*model.Overtime
+		paidOvertime       []*model.Overtime
+		payments           []*model.Payment
+		loans              []*model.Loan
+		installments       []*model.LoanRepayment
+		claims             []*model.Reimbursement
+		leaves             []*model.Leave
+		taxGross           int64
+		taxDeductible      int64
+		taxResult          *model.TaxCalculation
+		taxErr             error
+		recurringGross     int64
+		recurringTax       *model.TaxCalculation
+		expectedLines      []model.PayLine
+		expectedGross      int64
+		expectedDeductions int64
+		expectedNet        int64
+		expectedEmployer   int64
+		expectedProration  *model.Proration
+		expectedErr        error
+	}{
+		{
+			name:      "Base salary only, below PTKP",
+			period:    october,
+			rates:     noBPJS,
+			taxGross:  5001,
+			taxResult: &model.TaxCalculation{TaxStatus: "K/1", Gross: money.New(5001, "IDR")},
+			expectedLines: []model.PayLine{
+				{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(5001, "IDR"), Taxable: true},
+			},
+			expectedGross:      5001,
+			expectedDeductions: 0,
+			expectedNet:        5001,
+		},
+		{
+			name:   "Loan installments capped at what the pay leaves",
+			period: october,
+			rates:  noBPJS,
+			loans: []*model.Loan{
+				{ID: 7, UserID: 1, Principal: money.New(3000, "IDR"), Installments: 3, Installment: money.New(1000, "IDR"), Outstanding: money.New(3000, "IDR")},
+				{ID: 8, UserID: 1, Principal: money.New(5000, "IDR"), Installments: 2, Installment: money.New(2500, "IDR"), Outstanding: money.New(400, "IDR")},
+				{ID: 9, UserID: 1, Principal: money.New(5000, "IDR"), Installments: 1, Installment: money.New(5000, "IDR"), Outstanding: money.New(5000, "IDR")},
+			},
+			taxGross:  5001,
+			taxResult: &model.TaxCalculation{TaxStatus: "K/1", Gross: money.New(5001, "IDR")},
+			expectedLines: []model.PayLine{
+				{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(5001, "IDR"), Taxable: true},
+				{Code: model.PayCodeLoanInstallment, Name: "Loan installment", Kind: model.PayLineDeduction, Amount: money.New(1000, "IDR"), SourceType: "loan", SourceID: &loanIDs[0]},
+				{Code: model.PayCodeLoanInstallment, Name: "Loan installment", Kind: model.PayLineDeduction, Amount: money.New(400, "IDR"), SourceType: "loan", SourceID: &loanIDs[1]},
+				{Code: model.PayCodeLoanInstallment, Name: "Loan installment", Kind: model.PayLineDeduction, Amount: money.New(3601, "IDR"), SourceType: "loan", SourceID: &loanIDs[2]},
+			},
+			expectedGross:      5001,
+			expectedDeductions: 5001,
+			expectedNet:        0,
+		},
+		{
+			name:   "A period settled again keeps what its earlier withdrawal paid",
+			period: october,
+			rates:  noBPJS,
+			paidOvertime: []*model.Overtime{
+				{ID: overtimeID, UserID: 1, Date: time.Date(2026, time.October, 10, 0, 0, 0, 0, time.UTC), Minutes: 120, Amount: money.New(400, "IDR"), Status: model.OvertimeStatusApproved},
+			},
+			overtime: []*model.Overtime{
+				{ID: 10, UserID: 1, Date: time.Date(2026, time.October, 24, 0, 0, 0, 0, time.UTC), Minutes: 60, Amount: money.New(150, "IDR"), Status: model.OvertimeStatusApproved},
+			},
+			installments: []*model.LoanRepayment{{ID: 1, LoanID: 7, Kind: model.LoanRepaymentInstallment, Period: "2026-10", Amount: money.New(1000, "IDR")}},
+			taxGross:     5551,
+			taxResult:    &model.TaxCalculation{TaxStatus: "K/1", Gross: money.New(5551, "IDR")},
+			expectedLines: []model.PayLine{
+				{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(5001, "IDR"), Taxable: true},
+				{Code: model.PayCodeOvertime, Name: "Overtime 10 Oct 2026", Kind: model.PayLineEarning, Amount: money.New(400, "IDR"), Taxable: true, SourceType: "overtime", SourceID: &overtimeID, Settled: true},
+				{Code: model.PayCodeOvertime, Name: "Overtime 24 Oct 2026", Kind: model.PayLineEarning, Amount: money.New(150, "IDR"), Taxable: true, SourceType: "overtime", SourceID: &lateOvertimeID},
+				{Code: model.PayCodeLoanInstallment, Name: "Loan installment", Kind: model.PayLineDeduction, Amount: money.New(1000, "IDR"), SourceType: "loan", SourceID: &loanIDs[0], Settled: true},
+			},
+			expectedGross:      5551,
+			expectedDeductions: 1000,
+			expectedNet:        4551,
+		},
+		{
+			name:   "Unpaid leave deducted at a day's salary and left untaxed",
+			period: october,
+			rates:  noBPJS,
+			leaves: []*model.Leave{
+				{ID: 12, UserID: 1, Type: model.LeaveSick, StartDate: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, time.October, 2, 0, 0, 0, 0, time.UTC), Days: 2},
+				{ID: 13, UserID: 1, Type: model.LeaveUnpaid, StartDate: time.Date(2026, time.October, 5, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, time.October, 7, 0, 0, 0, 0, time.UTC), Days: 3},
+			},
+			taxGross:  4320,
+			taxResult: &model.TaxCalculation{TaxStatus: "K/1", Gross: money.New(4320, "IDR")},
+			expectedLines: []model.PayLine{
+				{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(5001, "IDR"), Taxable: true},
+				{Code: model.PayCodeUnpaidLeave, Name: "Unpaid leave 5 Oct to 7 Oct 2026, 3 days", Kind: model.PayLineDeduction, Amount: money.New(681, "IDR"), SourceType: "leave", SourceID: &leaveID},
+			},
+			expectedGross:      5001,
+			expectedDeductions: 681,
+			expectedNet:        4320,
+		},
+		{
+			name:   "Expense claims paid untaxed on top of installments",
+			period: october,
+			rates:  noBPJS,
+			loans: []*model.Loan{
+				{ID: 7, UserID: 1, Principal: money.New(6000, "IDR"), Installments: 1, Installment: money.New(6000, "IDR"), Outstanding: money.New(6000, "IDR")},
+			},
+			claims: []*model.Reimbursement{
+				{ID: 11, UserID: 1, Amount: money.New(750, "IDR"), Description: "Taxi to client"},
+			},
+			taxGross:  5001,
+			taxResult: &model.TaxCalculation{TaxStatus: "K/1", Gross: money.New(5001, "IDR")},
+			expectedLines: []model.PayLine{
+				{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(5001, "IDR"), Taxable: true},
+				{Code: model.PayCodeLoanInstallment, Name: "Loan installment", Kind: model.PayLineDeduction, Amount: money.New(5001, "IDR"), SourceType: "loan", SourceID: &loanIDs[0]},
+				{Code: model.PayCodeReimbursement, Name: "Taxi to client", Kind: model.PayLineEarning, Amount: money.New(750, "IDR"), SourceType: "reimbursement", SourceID: &claimID},
+			},
+			expectedGross:      5751,
+			expectedDeductions: 5001,
+			expectedNet:        750,
+		},
+		{
+			name:   "Salary in effect when the period starts",
+			period: october,
+			salaries: []*model.PositionSalary{
+				{ID: 3, PositionID: 1, Salary: money.New(4001, "IDR"), EffectiveFrom: time.Date(2026, time.October, 20, 0, 0, 0, 0, time.UTC)},
+				{ID: 2, PositionID: 1, Salary: money.New(3001, "IDR"), EffectiveFrom: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)},
+				{ID: 1, PositionID: 1, Salary: money.New(2001, "IDR"), EffectiveFrom: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			rates:     noBPJS,
+			taxGross:  3001,
+			taxResult: &model.TaxCalculation{TaxStatus: "K/1", Gross: money.New(3001, "IDR")},
+			expectedLines: []model.PayLine{
+				{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(3001, "IDR"), Taxable: true},
+			},
+			expectedGross:      3001,
+			expectedDeductions: 0,
+			expectedNet:        3001,
+		},
+		{
+			name:           "Hired mid-month, prorated by working days",
+			period:         october,
+			startDate:      &hired,
+			repoComponents: components,
+			rates:          noBPJS,
+			taxGross:       2500,
+			taxResult:      &model.TaxCalculation{TaxStatus: "K/1", Gross: money.New(2500, "IDR")},
+			expectedLines: []model.PayLine{
+				{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(2273, "IDR"), Taxable: true},
+				{Code: model.PayCodeAllowance, Name: "Transport", Kind: model.PayLineEarning, Amount: money.New(227, "IDR"), Taxable: true, SourceType: "position_component", SourceID: &transportID},
+				{Code: model.PayCodeAllowance, Name: "Meal", Kind: model.PayLineEarning, Amount: money.New(136, "IDR"), SourceType: "position_component", SourceID: &mealID},
+				{Code: model.PayCodeDeduction, Name: "Union fee", Kind: model.PayLineDeduction, Amount: money.New(45, "IDR"), SourceType: "position_component", SourceID: &unionID},
+			},
+			expectedGross:      2636,
+			expectedDeductions: 45,
+			expectedNet:        2591,
+			expectedProration:  &model.Proration{Method: model.ProrationWorkingDays, Days: 10, PeriodDays: 22},
+		},
+		{
+			name:      "Left mid-month, prorated by calendar days",
+			period:    october,
+			endDate:   &left,
+			proration: model.ProrationCalendarDays,
+			rates:     noBPJS,
+			taxGross:  1935,
+			taxResult: &model.TaxCalculation{TaxStatus: "K/1", Gross: money.New(1935, "IDR")},
+			expectedLines: []model.PayLine{
+				{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(1935, "IDR"), Taxable: true},
+			},
+			expectedGross:      1935,
+			expectedDeductions: 0,
+			expectedNet:        1935,
+			expectedProration:  &model.Proration{Method: model.ProrationCalendarDays, Days: 12, PeriodDays: 31},
+		},
+		{
+			name:   "Negotiated salary takes precedence over the position salary",
+			period: october,
+			overrides: []*model.UserSalary{
+				{ID: 1, UserID: 1, Salary: &negotiated, EffectiveFrom: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			rates:     noBPJS,
+			taxGross:  6001,
+			taxResult: &model.TaxCalculation{TaxStatus: "K/1", Gross: money.New(6001, "IDR")},
+			expectedLines: []model.PayLine{
+				{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(6001, "IDR"), Taxable: true},
+			},
+			expectedGross:      6001,
+			expectedDeductions: 0,
+			expectedNet:        6001,
+		},
+		{
+			name:   "Ended negotiated salary falls back to the position salary",
+			period: october,
+			overrides: []*model.UserSalary{
+				{ID: 2, UserID: 1, EffectiveFrom: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)},
+				{ID: 1, UserID: 1, Salary: &negotiated, EffectiveFrom: time.Date(2026, time.September, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			rates:     noBPJS,
+			taxGross:  5001,
+			taxResult: &model.TaxCalculation{TaxStatus: "K/1", Gross: money.New(5001, "IDR")},
+			expectedLines: []model.PayLine{
+				{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(5001, "IDR"), Taxable: true},
+			},
+			expectedGross:      5001,
+			expectedDeductions: 0,
+			expectedNet:        5001,
+		},
+		{
+			name:           "Allowances, deductions and tax",
+			period:         october,
+			repoComponents: components,
+			rates:          noBPJS,
+			taxGross:       5501,
+			taxResult:      &model.TaxCalculation{TaxStatus: "K/1", Gross: money.New(5501, "IDR"), Withholding: money.New(51, "IDR")},
+			expectedLines: []model.PayLine{
+				{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(5001, "IDR"), Taxable: true},
+				{Code: model.PayCodeAllowance, Name: "Transport", Kind: model.PayLineEarning, Amount: money.New(500, "IDR"), Taxable: true, SourceType: "position_component", SourceID: &transportID},
+				{Code: model.PayCodeAllowance, Name: "Meal", Kind: model.PayLineEarning, Amount: money.New(300, "IDR"), SourceType: "position_component", SourceID: &mealID},
+				{Code: model.PayCodeDeduction, Name: "Union fee", Kind: model.PayLineDeduction, Amount: money.New(100, "IDR"), SourceType: "position_component", SourceID: &unionID},
+				{Code: model.PayCodePPh21, Name: "PPh 21 withholding", Kind: model.PayLineDeduction, Amount: money.New(51, "IDR")},
+			},
+			expectedGross:      5801,
+			expectedDeductions: 151,
+			expectedNet:        5650,
+		},
+		{
+			name:           "Second half of a semimonthly month",
+			period:         secondHalf,
+			repoComponents: components,
+			rates:          noBPJS,
+			taxGross:       5501,
+			taxResult:      &model.TaxCalculation{TaxStatus: "K/1", Gross: money.New(5501, "IDR"), Withholding: money.New(51, "IDR")},
+			expectedLines: []model.PayLine{
+				{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(2501, "IDR"), Taxable: true},
+				{Code: model.PayCodeAllowance, Name: "Transport", Kind: model.PayLineEarning, Amount: money.New(250, "IDR"), Taxable: true, SourceType: "position_component", SourceID: &transportID},
+				{Code: model.PayCodeAllowance, Name: "Meal", Kind: model.PayLineEarning, Amount: money.New(150, "IDR"), SourceType: "position_component", SourceID: &mealID},
+				{Code: model.PayCodeDeduction, Name: "Union fee", Kind: model.PayLineDeduction, Amount: money.New(50, "IDR"), SourceType: "position_component", SourceID: &unionID},
+				{Code: model.PayCodePPh21, Name: "PPh 21 withholding", Kind: model.PayLineDeduction, Amount: money.New(26, "IDR")},
+			},
+			expectedGross:      2901,
+			expectedDeductions: 76,
+			expectedNet:        2825,
+		},
+		{
+			name:           "BPJS contributions",
+			period:         october,
+			repoComponents: components,
+			rates:          rates,
+			taxGross:       5678,
+			taxDeductible:  166,
+			taxResult:      &model.TaxCalculation{TaxStatus: "K/1", Gross: money.New(5678, "IDR"), Deductible: money.New(166, "IDR"), Withholding: money.New(20, "IDR")},
+			expectedLines: []model.PayLine{
+				{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(5001, "IDR"), Taxable: true},
+				{Code: model.PayCodeAllowance, Name: "Transport", Kind: model.PayLineEarning, Amount: money.New(500, "IDR"), Taxable: true, SourceType: "position_component", SourceID: &transportID},
+				{Code: model.PayCodeAllowance, Name: "Meal", Kind: model.PayLineEarning, Amount: money.New(300, "IDR"), SourceType: "position_component", SourceID: &mealID},
+				{Code: model.PayCodeDeduction, Name: "Union fee", Kind: model.PayLineDeduction, Amount: money.New(100, "IDR"), SourceType: "position_component", SourceID: &unionID},
+				{Code: "bpjs_jht", Name: "BPJS JHT employee contribution", Kind: model.PayLineDeduction, Amount: money.New(116, "IDR"), SourceType: "bpjs_rate", SourceID: &jhtID},
+				{Code: "bpjs_jht", Name: "BPJS JHT employer contribution", Kind: model.PayLineEmployer, Amount: money.New(214, "IDR"), SourceType: "bpjs_rate", SourceID: &jhtID},
+				{Code: "bpjs_jp", Name: "BPJS JP employee contribution", Kind: model.PayLineDeduction, Amount: money.New(50, "IDR"), SourceType: "bpjs_rate", SourceID: &jpID},
+				{Code: "bpjs_jp", Name: "BPJS JP employer contribution", Kind: model.PayLineEmployer, Amount: money.New(100, "IDR"), SourceType: "bpjs_rate", SourceID: &jpID},
+				{Code: "bpjs_jkm", Name: "BPJS JKM employer contribution", Kind: model.PayLineEmployer, Amount: money.New(17, "IDR"), Taxable: true, SourceType: "bpjs_rate", SourceID: &jkmID},
+				{Code: "bpjs_kesehatan", Name: "BPJS KESEHATAN employee contribution", Kind: model.PayLineDeduction, Amount: money.New(40, "IDR"), SourceType: "bpjs_rate", SourceID: &healthID},
+				{Code: "bpjs_kesehatan", Name: "BPJS KESEHATAN employer contribution", Kind: model.PayLineEmployer, Amount: money.New(160, "IDR"), Taxable: true, SourceType: "bpjs_rate", SourceID: &healthID},
+				{Code: model.PayCodePPh21, Name: "PPh 21 withholding", Kind: model.PayLineDeduction, Amount: money.New(20, "IDR")},
+			},
+			expectedGross:      5801,
+			expectedDeductions: 326,
+			expectedNet:        5475,
+			expectedEmployer:   491,
+		},
+		{
+			name:           "Overtime and payments are paid in full in a semimonthly period",
+			period:         secondHalf,
+			repoComponents: components,
+			rates:          noBPJS,
+			overtime: []*model.Overtime{
+				{ID: overtimeID, UserID: 1, Date: time.Date(2026, time.October, 10, 0, 0, 0, 0, time.UTC), Minutes: 120, Amount: money.New(400, "IDR"), Status: model.OvertimeStatusApproved},
+			},
+			payments: []*model.Payment{
+				{ID: paymentID, UserID: 1, Amount: money.New(1000, "IDR"), Reason: "Q3 bonus", Period: "2026-10", Taxable: true, Status: model.PaymentStatusApproved},
+			},
+			// half of the 51 on the recurring pay and the 9 the overtime
+			// and bonus add
+			taxGross:       6901,
+			taxResult:      &model.TaxCalculation{TaxStatus: "K/1", Gross: money.New(6901, "IDR"), Withholding: money.New(60, "IDR")},
+			recurringGross: 5501,
+			recurringTax:   &model.TaxCalculation{TaxStatus: "K/1", Gross: money.New(5501, "IDR"), Withholding: money.New(51, "IDR")},
+			expectedLines: []model.PayLine{
+				{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(2501, "IDR"), Taxable: true},
+				{Code: model.PayCodeAllowance, Name: "Transport", Kind: model.PayLineEarning, Amount: money.New(250, "IDR"), Taxable: true, SourceType: "position_component", SourceID: &transportID},
+				{Code: model.PayCodeAllowance, Name: "Meal", Kind: model.PayLineEarning, Amount: money.New(150, "IDR"), SourceType: "position_component", SourceID: &mealID},
+				{Code: model.PayCodeDeduction, Name: "Union fee", Kind: model.PayLineDeduction, Amount: money.New(50, "IDR"), SourceType: "position_component", SourceID: &unionID},
+				{Code: model.PayCodeOvertime, Name: "Overtime 10 Oct 2026", Kind: model.PayLineEarning, Amount: money.New(400, "IDR"), Taxable: true, SourceType: "overtime", SourceID: &overtimeID},
+				{Code: model.PayCodePayment, Name: "Q3 bonus", Kind: model.PayLineEarning, Amount: money.New(1000, "IDR"), Taxable: true, SourceType: "payment", SourceID: &paymentID},
+				{Code: model.PayCodePPh21, Name: "PPh 21 withholding", Kind: model.PayLineDeduction, Amount: money.New(35, "IDR")},
+			},
+			expectedGross:      4301,
+			expectedDeductions: 85,
+			expectedNet:        4216,
+		},
+		{
+			name:        "Failed to fetch components",
+			period:      october,
+			repoErr:     assert.AnError,
+			expectedErr: assert.AnError,
+		},
+		{
+			name:        "Failed to get company",
+			period:      october,
+			companyErr:  assert.AnError,
+			expectedErr: assert.AnError,
+		},
+		{
+			name:        "Failed to calculate tax",
+			period:      october,
+			rates:       noBPJS,
+			taxGross:    5001,
+			taxErr:      assert.AnError,
+			expectedErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := *user
+			user.StartDate, user.EndDate = tt.startDate, tt.endDate
+
+			mockSalaryRepository := new(mocks.PositionSalaryRepository)
+			mockUserSalaryRepository := new(mocks.UserSalaryRepository)
+			mockComponentRepository := new(mocks.PositionComponentRepository)
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockRateRepository := new(mocks.BPJSRateRepository)
+			mockOvertimeRepository := new(mocks.OvertimeRepository)
+			mockPaymentRepository := new(mocks.PaymentRepository)
+			mockLoanRepository := new(mocks.LoanRepository)
+			mockReimbursementRepository := new(mocks.ReimbursementRepository)
+			mockLeaveRepository := new(mocks.LeaveRepository)
+			mockTaxCalculator := new(mocks.TaxCalculator)
+
+			mockUserSalaryRepository.On("FetchByUserID", mock.Anything, user.ID).Return(tt.overrides, nil)
+			if model.OverrideOn(tt.overrides, tt.period.Start) == nil {
+				mockSalaryRepository.On("FetchByPositionID", mock.Anything, user.PositionID).Return(tt.salaries, nil)
+			}
+			mockComponentRepository.On("FetchByPositionID", mock.Anything, user.PositionID).
+				Return(tt.repoComponents, tt.repoErr)
+
+			if tt.repoErr == nil {
+				if tt.companyErr != nil {
+					mockCompanyRepository.On("Get", mock.Anything).Return(nil, tt.companyErr)
+				} else {
+					mockCompanyRepository.On("Get", mock.Anything).Return(&model.Company{ID: 1, Balance: money.New(100000000, "IDR"), ProrationMethod: tt.proration}, nil)
+					mockRateRepository.On("FetchByCompanyID", mock.Anything, 1).Return(tt.rates, nil)
+					mockOvertimeRepository.On("FetchPaidIn", mock.Anything, user.ID, tt.period.Code).Return(tt.paidOvertime, nil)
+					mockOvertimeRepository.On("FetchPayable", mock.Anything, user.ID, tt.period.End).Return(tt.overtime, nil)
+					mockPaymentRepository.On("FetchPaidIn", mock.Anything, user.ID, tt.period.Code).Return(nil, nil)
+					mockPaymentRepository.On("FetchPayable", mock.Anything, user.ID, tt.period.End).Return(tt.payments, nil)
+					mockLeaveRepository.On("FetchBetween", mock.Anything, user.ID, tt.period.Start, tt.period.End, model.LeaveStatusApproved).Return(tt.leaves, nil)
+					mockTaxCalculator.On("Withholding", tt.period, user.TaxStatus, money.New(tt.taxGross, "IDR"), money.New(tt.taxDeductible, "IDR")).
+						Return(tt.taxResult, tt.taxErr)
+					if tt.recurringTax != nil {
+						mockTaxCalculator.On("Withholding", tt.period, user.TaxStatus, money.New(tt.recurringGross, "IDR"), money.New(tt.taxDeductible, "IDR")).
+							Return(tt.recurringTax, nil)
+					}
+					if tt.taxErr == nil {
+						mockLoanRepository.On("FetchInstallmentsIn", mock.Anything, user.ID, tt.period.Code).Return(tt.installments, nil)
+						mockLoanRepository.On("FetchDue", mock.Anything, user.ID, tt.period).Return(tt.loans, nil)
+						mockReimbursementRepository.On("FetchPaidIn", mock.Anything, user.ID, tt.period.Code).Return(nil, nil)
+						mockReimbursementRepository.On("FetchPayable", mock.Anything, user.ID, tt.period.End).Return(tt.claims, nil)
+					}
+				}
+			}
+
+			c := usecase.NewPayCalculator(mockSalaryRepository, mockUserSalaryRepository, mockComponentRepository, mockCompanyRepository, mockRateRepository, new(mocks.BPJSContributionRepository), mockOvertimeRepository, mockPaymentRepository, mockLoanRepository, mockReimbursementRepository, mockLeaveRepository, new(mocks.AttendanceRepository), new(mocks.ExchangeRateRepository), mockTaxCalculator)
+
+			breakdown, err := c.Calculate(context.TODO(), &user, tt.period)
+
+			assert.Equal(t, tt.expectedErr, err)
+			if err == nil {
+				assert.Equal(t, tt.period.Code, breakdown.Period)
+				assert.Equal(t, tt.expectedLines, breakdown.Lines)
+				assert.Equal(t, money.New(tt.expectedGross, "IDR"), breakdown.Gross)
+				assert.Equal(t, money.New(tt.expectedDeductions, "IDR"), breakdown.Deductions)
+				assert.Equal(t, money.New(tt.expectedNet, "IDR"), breakdown.Net)
+				assert.Equal(t, money.New(tt.expectedEmployer, "IDR"), breakdown.EmployerCost)
+				assert.Equal(t, tt.taxResult, breakdown.Tax)
+				assert.Equal(t, tt.expectedProration, breakdown.Proration)
+			}
+
+			mockSalaryRepository.AssertExpectations(t)
+			mockUserSalaryRepository.AssertExpectations(t)
+			mockComponentRepository.AssertExpectations(t)
+			mockCompanyRepository.AssertExpectations(t)
+			mockRateRepository.AssertExpectations(t)
+			mockOvertimeRepository.AssertExpectations(t)
+			mockPaymentRepository.AssertExpectations(t)
+			mockLoanRepository.AssertExpectations(t)
+			mockReimbursementRepository.AssertExpectations(t)
+			mockLeaveRepository.AssertExpectations(t)
+			mockTaxCalculator.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_payCalculator_Calculate_PayCurrency(t *testing.T) {
+	user := &model.User{
+		ID:          1,
+		Name:        "test",
+		TaxStatus:   "TK/0",
+		PositionID:  1,
+		PayCurrency: "USD",
+		Position:    &model.Position{ID: 1, Name: "CTO", Salary: money.New(500000, "USD"), PayCurrency: "USD"},
+	}
+	october := model.PayrollPeriodOf(model.PayrollCycleMonthly, time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC))
+	transportID := 1
+	components := []*model.PositionComponent{
+		{ID: transportID, PositionID: 1, Name: "Transport", Type: model.PositionComponentAllowance, Amount: money.New(1500000, "IDR"), Taxable: true},
+	}
+	noBPJS := []*model.BPJSRate{{ID: 1, CompanyID: 1, Program: model.BPJSProgramJHT}}
+	tax := &model.TaxCalculation{TaxStatus: "TK/0", Gross: money.New(76500000, "IDR"), Withholding: money.New(3000000, "IDR")}
+
+	mockSalaryRepository := new(mocks.PositionSalaryRepository)
+	mockUserSalaryRepository := new(mocks.UserSalaryRepository)
+	mockComponentRepository := new(mocks.PositionComponentRepository)
+	mockCompanyRepository := new(mocks.CompanyRepository)
+	mockRateRepository := new(mocks.BPJSRateRepository)
+	mockOvertimeRepository := new(mocks.OvertimeRepository)
+	mockPaymentRepository := new(mocks.PaymentRepository)
+	mockLoanRepository := new(mocks.LoanRepository)
+	mockReimbursementRepository := new(mocks.ReimbursementRepository)
+	mockLeaveRepository := new(mocks.LeaveRepository)
+	mockExchangeRateRepository := new(mocks.ExchangeRateRepository)
+	mockTaxCalculator := new(mocks.TaxCalculator)
+
+	mockUserSalaryRepository.On("FetchByUserID", mock.Anything, user.ID).Return(nil, nil)
+	mockSalaryRepository.On("FetchByPositionID", mock.Anything, user.PositionID).Return(nil, nil)
+	mockComponentRepository.On("FetchByPositionID", mock.Anything, user.PositionID).Return(components, nil)
+	mockCompanyRepository.On("Get", mock.Anything).Return(&model.Company{ID: 1, Balance: money.New(100000000, "IDR")}, nil)
+	mockExchangeRateRepository.On("FindEffective", mock.Anything, "USD", "IDR", october.Start).
+		Return(&model.ExchangeRate{Currency: "USD", QuoteCurrency: "IDR", Rate: "15000"}, nil)
+	mockRateRepository.On("FetchByCompanyID", mock.Anything, 1).Return(noBPJS, nil)
+	mockOvertimeRepository.On("FetchPaidIn", mock.Anything, user.ID, october.Code).Return(nil, nil)
+	mockOvertimeRepository.On("FetchPayable", mock.Anything, user.ID, october.End).Return(nil, nil)
+	mockPaymentRepository.On("FetchPaidIn", mock.Anything, user.ID, october.Code).Return(nil, nil)
+	mockPaymentRepository.On("FetchPayable", mock.Anything, user.ID, october.End).Return(nil, nil)
+	mockLeaveRepository.On("FetchBetween", mock.Anything, user.ID, october.Start, october.End, model.LeaveStatusApproved).Return(nil, nil)
+	// PPh 21 is worked out on the gross in the balance currency
+	mockTaxCalculator.On("Withholding", october, user.TaxStatus, money.New(76500000, "IDR"), money.New(0, "IDR")).Return(tax, nil)
+	mockLoanRepository.On("FetchInstallmentsIn", mock.Anything, user.ID, october.Code).Return(nil, nil)
+	mockLoanRepository.On("FetchDue", mock.Anything, user.ID, october).Return(nil, nil)
+	mockReimbursementRepository.On("FetchPaidIn", mock.Anything, user.ID, october.Code).Return(nil, nil)
+	mockReimbursementRepository.On("FetchPayable", mock.Anything, user.ID, october.End).Return(nil, nil)
+
+	c := usecase.NewPayCalculator(mockSalaryRepository, mockUserSalaryRepository, mockComponentRepository, mockCompanyRepository, mockRateRepository, new(mocks.BPJSContributionRepository), mockOvertimeRepository, mockPaymentRepository, mockLoanRepository, mockReimbursementRepository, mockLeaveRepository, new(mocks.AttendanceRepository), mockExchangeRateRepository, mockTaxCalculator)
+
+	breakdown, err := c.Calculate(context.TODO(), user, october)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []model.PayLine{
+		{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(500000, "USD"), Taxable: true},
+		{Code: model.PayCodeAllowance, Name: "Transport", Kind: model.PayLineEarning, Amount: money.New(10000, "USD"), Taxable: true, SourceType: "position_component", SourceID: &transportID},
+		{Code: model.PayCodePPh21, Name: "PPh 21 withholding", Kind: model.PayLineDeduction, Amount: money.New(20000, "USD")},
+	}, breakdown.Lines)
+	assert.Equal(t, money.New(510000, "USD"), breakdown.Gross)
+	assert.Equal(t, money.New(490000, "USD"), breakdown.Net)
+	assert.Equal(t, tax, breakdown.Tax)
+
+	mockExchangeRateRepository.AssertExpectations(t)
+	mockTaxCalculator.AssertExpectations(t)
+}
+
+func Test_payCalculator_Settle(t *testing.T) {
+	overtimeID, paymentID, loanID, claimID := 5, 6, 7, 8
+	// paid by an earlier withdrawal of the period
+	settledOvertimeID, settledLoanID := 3, 4
+	breakdown := &model.PayBreakdown{
+		UserID: 1,
+		Period: "2026-10",
+		Lines: []model.PayLine{
+			{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(5000, "IDR"), Taxable: true},
+			{Code: model.PayCodeOvertime, Name: "Overtime 2 Oct 2026", Kind: model.PayLineEarning, Amount: money.New(200, "IDR"), Taxable: true, SourceType: "overtime", SourceID: &settledOvertimeID, Settled: true},
+			{Code: model.PayCodeLoanInstallment, Name: "Loan installment", Kind: model.PayLineDeduction, Amount: money.New(500, "IDR"), SourceType: "loan", SourceID: &settledLoanID, Settled: true},
+			{Code: model.PayCodeOvertime, Name: "Overtime 10 Oct 2026", Kind: model.PayLineEarning, Amount: money.New(400, "IDR"), Taxable: true, SourceType: "overtime", SourceID: &overtimeID},
+			{Code: model.PayCodePayment, Name: "Q3 bonus", Kind: model.PayLineEarning, Amount: money.New(1000, "IDR"), Taxable: true, SourceType: "payment", SourceID: &paymentID},
+			{Code: model.PayCodeLoanInstallment, Name: "Loan installment", Kind: model.PayLineDeduction, Amount: money.New(400, "IDR"), SourceType: "loan", SourceID: &loanID},
+			{Code: model.PayCodeReimbursement, Name: "Printer ink", Kind: model.PayLineEarning, Amount: money.New(120, "IDR"), SourceType: "reimbursement", SourceID: &claimID},
+		},
+		Contributions: []*model.BPJSContribution{
+			{UserID: 1, Period: "2026-10", Program: model.BPJSProgramJHT, Wage: money.New(5000, "IDR"), EmployeeAmount: money.New(100, "IDR"), EmployerAmount: money.New(185, "IDR")},
+			{UserID: 1, Period: "2026-10", Program: model.BPJSProgramJKK, Wage: money.New(5000, "IDR")},
+		},
+	}
+	paidAt := time.Date(2026, time.October, 25, 9, 0, 0, 0, time.UTC)
+	trx := &model.Transaction{ID: 9, CreatedAt: paidAt}
+
+	mockOvertimeRepository := new(mocks.OvertimeRepository)
+	mockOvertimeRepository.On("MarkPaid", mock.Anything, []int{overtimeID}, trx.ID).Return(nil)
+
+	mockPaymentRepository := new(mocks.PaymentRepository)
+	mockPaymentRepository.On("MarkPaid", mock.Anything, []int{paymentID}, trx.ID).Return(nil)
+
+	mockReimbursementRepository := new(mocks.ReimbursementRepository)
+	mockReimbursementRepository.On("MarkPaid", mock.Anything, []int{claimID}, trx.ID).Return(nil)
+
+	mockContributionRepository := new(mocks.BPJSContributionRepository)
+	mockContributionRepository.On("CreateMany", mock.Anything, []*model.BPJSContribution{
+		{UserID: 1, Period: "2026-10", Program: model.BPJSProgramJHT, Wage: money.New(5000, "IDR"), EmployeeAmount: money.New(100, "IDR"), EmployerAmount: money.New(185, "IDR"), TransactionID: &trx.ID},
+	}).Return(nil)
+
+	// the last installment closes the loan
+	mockLoanRepository := new(mocks.LoanRepository)
+	mockLoanRepository.On("Lock", mock.Anything, loanID).
+		Return(&model.Loan{ID: loanID, Installment: money.New(1000, "IDR"), Outstanding: money.New(400, "IDR"), Status: model.LoanStatusActive}, nil)
+	mockLoanRepository.On("UpdateByID", mock.Anything, loanID, &model.Loan{
+		ID: loanID, Installment: money.New(1000, "IDR"), Outstanding: money.New(0, "IDR"), Status: model.LoanStatusRepaid, RepaidAt: &paidAt,
+	}).Return(nil, nil)
+	mockLoanRepository.On("CreateRepayment", mock.Anything, &model.LoanRepayment{
+		LoanID: loanID, Kind: model.LoanRepaymentInstallment, Period: "2026-10", Amount: money.New(400, "IDR"), TransactionID: trx.ID,
+	}).Return(nil, nil)
+
+	c := usecase.NewPayCalculator(new(mocks.PositionSalaryRepository), new(mocks.UserSalaryRepository), new(mocks.PositionComponentRepository), new(mocks.CompanyRepository), new(mocks.BPJSRateRepository), mockContributionRepository, mockOvertimeRepository, mockPaymentRepository, mockLoanRepository, mockReimbursementRepository, new(mocks.LeaveRepository), new(mocks.AttendanceRepository), new(mocks.ExchangeRateRepository), new(mocks.TaxCalculator))
+
+	assert.NoError(t, c.Settle(context.TODO(), breakdown, trx))
+
+	mockOvertimeRepository.AssertExpectations(t)
+	mockPaymentRepository.AssertExpectations(t)
+	mockLoanRepository.AssertExpectations(t)
+	mockReimbursementRepository.AssertExpectations(t)
+	mockContributionRepository.AssertExpectations(t)
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/request"
+	"time"
+)
+
+type paymentUsecase struct {
+	userRepository    model.UserRepository
+	paymentRepository model.PaymentRepository
+	companyRepository model.CompanyRepository
+	txManager         model.TxManager
+	now               func() time.Time
+}
+
+func NewPaymentUsecase(user model.UserRepository, payment model.PaymentRepository, company model.CompanyRepository, tx model.TxManager) model.PaymentUsecase {
+	return &paymentUsecase{userRepository: user, paymentRepository: payment, companyRepository: company, txManager: tx, now: time.Now}
+}
+
+func (p *paymentUsecase) GetByID(ctx context.Context, userID, id int) (*model.Payment, error) {
+	payment, err := p.paymentRepository.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+func (p *paymentUsecase) FetchPayment(ctx context.Context, userID int) ([]*model.Payment, error) {
+	_, err := p.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := p.paymentRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
+
+func (p *paymentUsecase) StorePayment(ctx context.Context, userID int, req *request.PaymentRequest) (*model.Payment, error) {
+	_, err := p.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	period, err := model.ParsePayrollPeriod(req.Period, p.now().Location())
+	if err != nil {
+		return nil, err
+	}
+
+	taxable := true
+	if req.Taxable != nil {
+		taxable = *req.Taxable
+	}
+
+	disbursement := req.Disbursement
+	if disbursement == "" {
+		disbursement = model.PaymentWithSalary
+	}
+
+	payment, err := p.paymentRepository.Create(ctx, &model.Payment{
+		UserID:       userID,
+		Amount:       req.Amount,
+		Reason:       req.Reason,
+		Period:       period.Code,
+		DueFrom:      period.Start,
+		Taxable:      taxable,
+		Disbursement: disbursement,
+		Status:       model.PaymentStatusPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+func (p *paymentUsecase) ApprovePayment(ctx context.Context, userID, id int) (*model.Payment, error) {
+	return p.review(ctx, userID, id, model.PaymentStatusApproved)
+}
+
+func (p *paymentUsecase) RejectPayment(ctx context.Context, userID, id int) (*model.Payment, error) {
+	return p.review(ctx, userID, id, model.PaymentStatusRejected)
+}
+
+func (p *paymentUsecase) review(ctx context.Context, userID, id int, status string) (*model.Payment, error) {
+	payment, err := p.paymentRepository.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.Status != model.PaymentStatusPending {
+		return nil, model.ErrPaymentNotPending
+	}
+
+	now := p.now()
+	payment, err = p.paymentRepository.UpdateByID(ctx, id, &model.Payment{
+		Status:     status,
+		ReviewedAt: &now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// DisbursePayment pays an approved payment on its own, out of the company
+// balance, instead of waiting for the next salary withdrawal.
+func (p *paymentUsecase) DisbursePayment(ctx context.Context, userID, id int) (*model.Transaction, error) {
+	user, err := p.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var trx *model.Transaction
+	err = p.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		payment, err := p.paymentRepository.Lock(ctx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case payment.PaidTransactionID != nil:
+			return model.ErrPaymentPaid
+		case payment.Status != model.PaymentStatusApproved:
+			return model.ErrPaymentNotApproved
+		case payment.Disbursement != model.PaymentSeparate:
+			return model.ErrPaymentPaidWithSalary
+		}
+
+		trx, err = p.companyRepository.DebitBalance(ctx, &model.Transaction{
+			Amount:     payment.Amount,
+			Note:       payment.Reason,
+			Category:   model.TransactionCategoryPayment,
+			UserID:     &user.ID,
+			PositionID: &user.PositionID,
+			Period:     &payment.Period,
+			Lines: []model.TransactionLine{{
+				Code:       model.PayCodePayment,
+				Name:       payment.Reason,
+				Kind:       model.PayLineEarning,
+				Amount:     payment.Amount,
+				Taxable:    payment.Taxable,
+				SourceType: paymentSource,
+				SourceID:   &payment.ID,
+			}},
+		})
+		if err != nil {
+			return err
+		}
+
+		return p.paymentRepository.MarkPaid(ctx, []int{payment.ID}, trx.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return trx, nil
+}
+
+func (p *paymentUsecase) DestroyPayment(ctx context.Context, userID, id int) error {
+	payment, err := p.paymentRepository.FindByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if payment.PaidTransactionID != nil {
+		return model.ErrPaymentPaid
+	}
+
+	err = p.paymentRepository.Delete(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package usecase_test
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"self-payrol/money"
+	"self-payrol/request"
+	"self-payrol/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func Test_paymentUsecase_StorePayment(t *testing.T) {
+	user := &model.User{ID: 1, PositionID: 1}
+	untaxed := false
+
+	tests := []struct {
+		name        string
+		req         *request.PaymentRequest
+		repoUserErr error
+		expected    *model.Payment
+		expectedErr error
+	}{
+		{
+			name: "Taxable bonus paid with salary by default",
+			req:  &request.PaymentRequest{Amount: money.New(1000000, "IDR"), Reason: "Q3 bonus", Period: "2026-10"},
+			expected: &model.Payment{
+				UserID: 1, Amount: money.New(1000000, "IDR"), Reason: "Q3 bonus", Period: "2026-10",
+				DueFrom: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.Local), Taxable: true,
+				Disbursement: model.PaymentWithSalary, Status: model.PaymentStatusPending,
+			},
+		},
+		{
+			name: "Untaxed payment disbursed separately in a semimonthly period",
+			req:  &request.PaymentRequest{Amount: money.New(250000, "IDR"), Reason: "Referral", Period: "2026-10-2", Taxable: &untaxed, Disbursement: model.PaymentSeparate},
+			expected: &model.Payment{
+				UserID: 1, Amount: money.New(250000, "IDR"), Reason: "Referral", Period: "2026-10-2",
+				DueFrom:      time.Date(2026, time.October, 16, 0, 0, 0, 0, time.Local),
+				Disbursement: model.PaymentSeparate, Status: model.PaymentStatusPending,
+			},
+		},
+		{
+			name:        "Invalid period",
+			req:         &request.PaymentRequest{Amount: money.New(1000, "IDR"), Reason: "Bonus", Period: "2026-13"},
+			expectedErr: model.ErrInvalidPayrollPeriod,
+		},
+		{
+			name:        "Employee not found",
+			req:         &request.PaymentRequest{Amount: money.New(1000, "IDR"), Reason: "Bonus", Period: "2026-10"},
+			repoUserErr: gorm.ErrRecordNotFound,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(mocks.UserRepository)
+			mockPaymentRepository := new(mocks.PaymentRepository)
+
+			if tt.repoUserErr != nil {
+				mockUserRepository.On("FindByID", mock.Anything, 1).Return(nil, tt.repoUserErr)
+			} else {
+				mockUserRepository.On("FindByID", mock.Anything, 1).Return(user, nil)
+			}
+
+			if tt.expected != nil {
+				mockPaymentRepository.On("Create", mock.Anything, tt.expected).Return(tt.expected, nil)
+			}
+
+			p := usecase.NewPaymentUsecase(mockUserRepository, mockPaymentRepository, new(mocks.CompanyRepository), new(mocks.TxManager))
+
+			payment, err := p.StorePayment(context.TODO(), 1, tt.req)
+
+			assert.Equal(t, tt.expected, payment)
+			assert.Equal(t, tt.expectedErr, err)
+
+			mockUserRepository.AssertExpectations(t)
+			mockPaymentRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_paymentUsecase_DisbursePayment(t *testing.T) {
+	user := &model.User{ID: 1, PositionID: 2}
+	period := "2026-10"
+	paidID := 9
+
+	tests := []struct {
+		name        string
+		payment     *model.Payment
+		errDebit    error
+		expectedTrx *model.Transaction
+		expectedErr error
+	}{
+		{
+			name: "Approved separate payment is disbursed",
+			payment: &model.Payment{ID: 3, UserID: 1, Amount: money.New(500, "IDR"), Reason: "Referral", Period: period,
+				Taxable: true, Disbursement: model.PaymentSeparate, Status: model.PaymentStatusApproved},
+			expectedTrx: &model.Transaction{ID: 7, Amount: money.New(500, "IDR")},
+		},
+		{
+			name: "Pending payment",
+			payment: &model.Payment{ID: 3, UserID: 1, Amount: money.New(500, "IDR"), Reason: "Referral", Period: period,
+				Disbursement: model.PaymentSeparate, Status: model.PaymentStatusPending},
+			expectedErr: model.ErrPaymentNotApproved,
+		},
+		{
+			name: "Payment waits for the salary withdrawal",
+			payment: &model.Payment{ID: 3, UserID: 1, Amount: money.New(500, "IDR"), Reason: "Referral", Period: period,
+				Disbursement: model.PaymentWithSalary, Status: model.PaymentStatusApproved},
+			expectedErr: model.ErrPaymentPaidWithSalary,
+		},
+		{
+			name: "Payment already paid",
+			payment: &model.Payment{ID: 3, UserID: 1, Amount: money.New(500, "IDR"), Reason: "Referral", Period: period,
+				Disbursement: model.PaymentSeparate, Status: model.PaymentStatusPaid, PaidTransactionID: &paidID},
+			expectedErr: model.ErrPaymentPaid,
+		},
+		{
+			name: "Company balance too low",
+			payment: &model.Payment{ID: 3, UserID: 1, Amount: money.New(500, "IDR"), Reason: "Referral", Period: period,
+				Disbursement: model.PaymentSeparate, Status: model.PaymentStatusApproved},
+			errDebit:    model.ErrInsufficientBalance,
+			expectedErr: model.ErrInsufficientBalance,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(mocks.UserRepository)
+			mockPaymentRepository := new(mocks.PaymentRepository)
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockTxManager := new(mocks.TxManager)
+
+			mockUserRepository.On("FindByID", mock.Anything, 1).Return(user, nil)
+			mockTxManager.On("WithinTransaction", mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+			mockPaymentRepository.On("Lock", mock.Anything, 1, 3).Return(tt.payment, nil)
+
+			if tt.expectedTrx != nil || tt.errDebit != nil {
+				mockCompanyRepository.On("DebitBalance", mock.Anything, &model.Transaction{
+					Amount:     money.New(500, "IDR"),
+					Note:       "Referral",
+					Category:   model.TransactionCategoryPayment,
+					UserID:     &user.ID,
+					PositionID: &user.PositionID,
+					Period:     &period,
+					Lines: []model.TransactionLine{{
+						Code: model.PayCodePayment, Name: "Referral", Kind: model.PayLineEarning, Amount: money.New(500, "IDR"),
+						Taxable: tt.payment.Taxable, SourceType: "payment", SourceID: &tt.payment.ID,
+					}},
+				}).Return(tt.expectedTrx, tt.errDebit)
+			}
+
+			if tt.expectedTrx != nil {
+				mockPaymentRepository.On("MarkPaid", mock.Anything, []int{3}, tt.expectedTrx.ID).Return(nil)
+			}
+
+			p := usecase.NewPaymentUsecase(mockUserRepository, mockPaymentRepository, mockCompanyRepository, mockTxManager)
+
+			trx, err := p.DisbursePayment(context.TODO(), 1, 3)
+
+			assert.Equal(t, tt.expectedTrx, trx)
+			assert.Equal(t, tt.expectedErr, err)
+
+			mockPaymentRepository.AssertExpectations(t)
+			mockCompanyRepository.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/money"
+	"time"
+)
+
+type payrollUsecase struct {
+	userRepository   model.UserRepository
+	positionRepo     model.PositionRepository
+	companyRepo      model.CompanyRepository
+	withdrawalRepo   model.WithdrawalRepository
+	exchangeRateRepo model.ExchangeRateRepository
+	payCalculator    model.PayCalculator
+	now              func() time.Time
+}
+
+func NewPayrollUsecase(user model.UserRepository, position model.PositionRepository, company model.CompanyRepository, withdrawal model.WithdrawalRepository, exchangeRate model.ExchangeRateRepository, calculator model.PayCalculator) model.PayrollUsecase {
+	return &payrollUsecase{userRepository: user, positionRepo: position, companyRepo: company, withdrawalRepo: withdrawal, exchangeRateRepo: exchangeRate, payCalculator: calculator, now: time.Now}
+}
+
+// Preview only reads, it calculates pay the way a withdrawal would but
+// leaves the ledger, balance and withdrawals alone. Only employees payable
+// for the period count, the others cannot be paid.
+func (p *payrollUsecase) Preview(ctx context.Context, periodCode string) (*model.PayrollPreview, error) {
+	company, err := p.companyRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	period := model.PayrollPeriodOf(company.PayrollCycle, p.now())
+	if periodCode != "" {
+		period, err = model.ParsePayrollPeriod(periodCode, p.now().Location())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	positions, err := p.positionRepo.Fetch(ctx, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := p.userRepository.Fetch(ctx, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawals, err := p.withdrawalRepo.FetchByPeriod(ctx, period.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawn := make(map[int]money.Money, len(withdrawals))
+	for _, withdrawal := range withdrawals {
+		withdrawn[withdrawal.UserID] = withdrawal.Amount
+	}
+
+	zero := money.New(0, company.Balance.Currency)
+	preview := &model.PayrollPreview{
+		Period:       period.Code,
+		Gross:        zero,
+		Net:          zero,
+		EmployerCost: zero,
+		Withdrawn:    zero,
+		Liability:    zero,
+		Balance:      company.Balance,
+		Shortfall:    zero,
+		Positions:    make([]*model.PositionLiability, 0, len(positions)),
+	}
+
+	byPosition := make(map[int]*model.PositionLiability, len(positions))
+	for _, position := range positions {
+		liability := &model.PositionLiability{
+			PositionID:   position.ID,
+			Name:         position.Name,
+			Gross:        zero,
+			Net:          zero,
+			EmployerCost: zero,
+			Withdrawn:    zero,
+			Liability:    zero,
+		}
+		byPosition[position.ID] = liability
+		preview.Positions = append(preview.Positions, liability)
+	}
+
+	for _, user := range users {
+		if user.Payable(period) != nil {
+			continue
+		}
+
+		breakdown, err := p.payCalculator.Calculate(ctx, user, period)
+		if err != nil {
+			return nil, err
+		}
+
+		breakdown, paid, err := p.inBalance(ctx, company.Balance.Currency, breakdown, withdrawn[user.ID])
+		if err != nil {
+			return nil, err
+		}
+
+		// the pay of an employee whose position was removed is still owed
+		liability, ok := byPosition[user.PositionID]
+		if !ok {
+			if liability, ok = byPosition[0]; !ok {
+				liability = &model.PositionLiability{
+					Name:         model.UnassignedPosition,
+					Gross:        zero,
+					Net:          zero,
+					EmployerCost: zero,
+					Withdrawn:    zero,
+					Liability:    zero,
+				}
+				byPosition[0] = liability
+				preview.Positions = append(preview.Positions, liability)
+			}
+		}
+		if err := liability.Add(breakdown, paid); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, liability := range preview.Positions {
+		preview.Headcount += liability.Headcount
+		if preview.Gross, err = preview.Gross.Add(liability.Gross); err != nil {
+			return nil, err
+		}
+		if preview.Net, err = preview.Net.Add(liability.Net); err != nil {
+			return nil, err
+		}
+		if preview.EmployerCost, err = preview.EmployerCost.Add(liability.EmployerCost); err != nil {
+			return nil, err
+		}
+		if preview.Withdrawn, err = preview.Withdrawn.Add(liability.Withdrawn); err != nil {
+			return nil, err
+		}
+		if preview.Liability, err = preview.Liability.Add(liability.Liability); err != nil {
+			return nil, err
+		}
+	}
+
+	if preview.Liability.Amount > preview.Balance.Amount {
+		if preview.Shortfall, err = preview.Liability.Sub(preview.Balance); err != nil {
+			return nil, err
+		}
+	}
+
+	return preview, nil
+}
+
+// inBalance converts the pay and withdrawals of an employee paid in another
+// currency than the balance at today's rate, so they add up with everyone
+// else's.
+func (p *payrollUsecase) inBalance(ctx context.Context, currency string, breakdown *model.PayBreakdown, withdrawn money.Money) (*model.PayBreakdown, money.Money, error) {
+	if breakdown.Net.Currency == currency {
+		return breakdown, withdrawn, nil
+	}
+
+	converted := *breakdown
+	for _, amount := range []*money.Money{&converted.Gross, &converted.Net, &converted.EmployerCost, &withdrawn} {
+		var err error
+		if *amount, _, err = convertMoney(ctx, p.exchangeRateRepo, *amount, currency, currency, p.now()); err != nil {
+			return nil, money.Money{}, err
+		}
+	}
+
+	return &converted, withdrawn, nil
+}
@@ -0,0 +1,474 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"self-payrol/model"
+	"self-payrol/money"
+	"self-payrol/request"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type payrollRunUsecase struct {
+	userRepository   model.UserRepository
+	companyRepo      model.CompanyRepository
+	withdrawalRepo   model.WithdrawalRepository
+	payslipRepo      model.PayslipRepository
+	runRepo          model.PayrollRunRepository
+	exchangeRateRepo model.ExchangeRateRepository
+	payCalculator    model.PayCalculator
+	txManager        model.TxManager
+	notifier         model.Notifier
+	exporter         model.BankFileExporter
+	now              func() time.Time
+}
+
+func NewPayrollRunUsecase(user model.UserRepository, company model.CompanyRepository, withdrawal model.WithdrawalRepository, payslip model.PayslipRepository, run model.PayrollRunRepository, exchangeRate model.ExchangeRateRepository, calculator model.PayCalculator, tx model.TxManager, notifier model.Notifier, exporter model.BankFileExporter) model.PayrollRunUsecase {
+	return &payrollRunUsecase{userRepository: user, companyRepo: company, withdrawalRepo: withdrawal, payslipRepo: payslip, runRepo: run, exchangeRateRepo: exchangeRate, payCalculator: calculator, txManager: tx, notifier: notifier, exporter: exporter, now: time.Now}
+}
+
+func (p *payrollRunUsecase) Draft(ctx context.Context, trigger string, req *request.PayrollRunActionRequest) (*model.PayrollRun, error) {
+	company, err := p.companyRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := p.now()
+	period := model.PayrollPeriodOf(company.PayrollCycle, now)
+
+	users, err := p.userRepository.FetchPayable(ctx, period.Start)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawals, err := p.withdrawalRepo.FetchByPeriod(ctx, period.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawn := make(map[int]money.Money, len(withdrawals))
+	for _, withdrawal := range withdrawals {
+		withdrawn[withdrawal.UserID] = withdrawal.Amount
+	}
+
+	run := &model.PayrollRun{
+		Period:    period.Code,
+		Trigger:   trigger,
+		Status:    model.PayrollRunDraft,
+		CreatedBy: req.Actor,
+		Total:     money.New(0, company.Balance.Currency),
+		Items:     make([]*model.PayrollRunItem, 0, len(users)),
+	}
+
+	for _, user := range users {
+		item := &model.PayrollRunItem{UserID: user.ID, Status: model.PayrollItemSkipped}
+		run.Items = append(run.Items, item)
+
+		// employees who drew on the period withdraw the rest themselves
+		if withdrawn[user.ID].Amount > 0 {
+			item.Reason = model.ErrSalaryAlreadyWithdrawn.Error()
+			run.Skipped++
+			continue
+		}
+
+		breakdown, err := p.payCalculator.Calculate(ctx, user, period)
+		switch {
+		case err != nil:
+			item.Reason = err.Error()
+		case breakdown.Net.Amount <= 0:
+			item.Reason = model.ErrNoSalaryToWithdraw.Error()
+		default:
+			debit, err := p.inBalance(ctx, company, breakdown.Net, now)
+			if err == nil {
+				run.Total, err = run.Total.Add(debit)
+			}
+			if err != nil {
+				item.Reason = err.Error()
+				break
+			}
+			item.Status = model.PayrollItemPending
+			item.Salary = breakdown.Net
+			item.Amount = breakdown.Net
+			continue
+		}
+		run.Skipped++
+	}
+
+	err = p.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		run, err = p.runRepo.Create(ctx, run)
+		if err != nil {
+			return err
+		}
+
+		_, err = p.runRepo.CreateEvent(ctx, &model.PayrollRunEvent{
+			PayrollRunID: run.ID,
+			To:           model.PayrollRunDraft,
+			Actor:        req.Actor,
+			Note:         req.Note,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (p *payrollRunUsecase) GetByID(ctx context.Context, id int) (*model.PayrollRun, error) {
+	run, err := p.runRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (p *payrollRunUsecase) FetchPayrollRuns(ctx context.Context, limit, offset int) ([]*model.PayrollRun, error) {
+	runs, err := p.runRepo.Fetch(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+func (p *payrollRunUsecase) EditItem(ctx context.Context, id, itemID int, req *request.PayrollRunItemRequest) (*model.PayrollRun, error) {
+	company, err := p.companyRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		run, err := p.runRepo.Lock(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if run.Status != model.PayrollRunDraft {
+			return model.ErrPayrollRunNotDraft
+		}
+
+		var item *model.PayrollRunItem
+		for _, candidate := range run.Items {
+			if candidate.ID == itemID {
+				item = candidate
+			}
+		}
+
+		switch {
+		case item == nil:
+			return gorm.ErrRecordNotFound
+		case item.Status == model.PayrollItemSkipped:
+			return model.ErrPayrollItemNotPayable
+		case !req.Amount.SameCurrency(item.Salary):
+			return fmt.Errorf("%w: %s and %s", money.ErrCurrencyMismatch, req.Amount.Currency, item.Salary.Currency)
+		case req.Amount.Amount > item.Salary.Amount:
+			return model.ErrPayrollItemExceedsSalary
+		}
+
+		item.Amount = *req.Amount
+		item.Status = model.PayrollItemPending
+		item.Reason = ""
+		if item.Amount.IsZero() {
+			item.Status = model.PayrollItemExcluded
+			item.Reason = "excluded by " + req.Actor
+		}
+
+		if _, err := p.runRepo.UpdateItem(ctx, item); err != nil {
+			return err
+		}
+
+		run.Total = money.New(0, company.Balance.Currency)
+		for _, item := range run.Items {
+			if item.Status != model.PayrollItemPending {
+				continue
+			}
+
+			debit, err := p.inBalance(ctx, company, item.Amount, p.now())
+			if err != nil {
+				return err
+			}
+			if run.Total, err = run.Total.Add(debit); err != nil {
+				return err
+			}
+		}
+
+		_, err = p.runRepo.UpdateByID(ctx, id, run)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetByID(ctx, id)
+}
+
+func (p *payrollRunUsecase) Submit(ctx context.Context, id int, req *request.PayrollRunActionRequest) (*model.PayrollRun, error) {
+	return p.transition(ctx, id, model.PayrollRunSubmitted, req, func(ctx context.Context, run *model.PayrollRun) error {
+		run.SubmittedBy = req.Actor
+		return nil
+	})
+}
+
+// Approve takes a second admin, whoever drafted or submitted the run
+// cannot approve it.
+func (p *payrollRunUsecase) Approve(ctx context.Context, id int, req *request.PayrollRunActionRequest) (*model.PayrollRun, error) {
+	return p.transition(ctx, id, model.PayrollRunApproved, req, func(ctx context.Context, run *model.PayrollRun) error {
+		if run.PreparedBy(req.Actor) {
+			return model.ErrPayrollRunSelfApproval
+		}
+		run.ApprovedBy = req.Actor
+		return nil
+	})
+}
+
+func (p *payrollRunUsecase) Reject(ctx context.Context, id int, req *request.PayrollRunActionRequest) (*model.PayrollRun, error) {
+	return p.transition(ctx, id, model.PayrollRunRejected, req, func(ctx context.Context, run *model.PayrollRun) error {
+		return nil
+	})
+}
+
+// Disburse pays the pending items in one transaction, so a failure for any
+// employee, such as the company balance running out, pays nobody. Pay is
+// worked out again on the day, an item pays what the employee is due then
+// rather than what was drafted. An employee who withdrew, stopped being
+// active or has nothing left to be paid since the run was drafted is
+// skipped.
+func (p *payrollRunUsecase) Disburse(ctx context.Context, id int, req *request.PayrollRunActionRequest) (*model.PayrollRun, error) {
+	type paid struct {
+		user    *model.User
+		payslip *model.Payslip
+	}
+	var payouts []paid
+
+	run, err := p.transition(ctx, id, model.PayrollRunDisbursed, req, func(ctx context.Context, run *model.PayrollRun) error {
+		company, err := p.companyRepo.Get(ctx)
+		if err != nil {
+			return err
+		}
+
+		now := p.now()
+		period, err := model.ParsePayrollPeriod(run.Period, now.Location())
+		if err != nil {
+			return err
+		}
+
+		payer := salaryPayer{
+			companyRepo:      p.companyRepo,
+			withdrawalRepo:   p.withdrawalRepo,
+			payslipRepo:      p.payslipRepo,
+			exchangeRateRepo: p.exchangeRateRepo,
+			payCalculator:    p.payCalculator,
+			upToSalary:       true,
+		}
+
+		run.Total = money.New(0, company.Balance.Currency)
+		for _, item := range run.Items {
+			if item.Status != model.PayrollItemPending {
+				continue
+			}
+
+			user, payslip, err := p.payItem(ctx, payer, company, period, item, now)
+			if err != nil {
+				return fmt.Errorf("employee %d: %w", item.UserID, err)
+			}
+
+			if _, err := p.runRepo.UpdateItem(ctx, item); err != nil {
+				return err
+			}
+
+			if item.Status == model.PayrollItemPaid {
+				run.Paid++
+				debit := item.Amount
+				if item.Conversion != nil {
+					debit = item.Conversion.Debited
+				}
+				if run.Total, err = run.Total.Add(debit); err != nil {
+					return err
+				}
+				payouts = append(payouts, paid{user: user, payslip: payslip})
+			} else {
+				run.Skipped++
+			}
+		}
+
+		run.DisbursedAt = &now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the money has moved, a failed email is not a reason to report otherwise
+	for _, payout := range payouts {
+		p.notifier.SalaryWithdrawn(ctx, payout.user, payout.payslip)
+	}
+
+	return run, nil
+}
+
+func (p *payrollRunUsecase) BankFile(ctx context.Context, id int, format, currency string) (*model.BankFile, error) {
+	run, err := p.runRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if run.Status != model.PayrollRunDisbursed {
+		return nil, fmt.Errorf("%w: run is %s", model.ErrPayrollRunNotDisbursed, run.Status)
+	}
+
+	company, err := p.companyRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if currency == "" {
+		currency = company.Balance.Currency
+	}
+
+	batch := &model.BankTransferBatch{
+		Reference: fmt.Sprintf("PAYROLL-%d", run.ID),
+		Date:      *run.DisbursedAt,
+		Currency:  currency,
+	}
+
+	for _, item := range run.Items {
+		if item.Status != model.PayrollItemPaid {
+			continue
+		}
+
+		// items are paid in the currency of the employee, a file only
+		// carries the transfers of its own currency
+		amount := item.Amount
+		if amount.Currency != currency {
+			continue
+		}
+
+		if item.BankCode == "" || item.BankAccountNumber == "" {
+			return nil, fmt.Errorf("%w: employee %d was paid without one", model.ErrNoBankAccount, item.UserID)
+		}
+
+		batch.Transfers = append(batch.Transfers, model.BankTransfer{
+			BankCode:      item.BankCode,
+			AccountNumber: item.BankAccountNumber,
+			AccountName:   item.BankAccountName,
+			Amount:        amount,
+			Reference:     item.Payslip,
+			Description:   "Salary " + run.Period,
+		})
+	}
+
+	return p.exporter.Export(batch, format)
+}
+
+// payItem pays item inside the disbursement transaction, or marks it
+// skipped when the employee is no longer due it.
+func (p *payrollRunUsecase) payItem(ctx context.Context, payer salaryPayer, company *model.Company, period model.PayrollPeriod, item *model.PayrollRunItem, now time.Time) (*model.User, *model.Payslip, error) {
+	user, err := p.userRepository.FindByID(ctx, item.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		item.Status = model.PayrollItemSkipped
+		item.Reason = err.Error()
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := user.Payable(period); err != nil {
+		item.Status = model.PayrollItemSkipped
+		item.Reason = err.Error()
+		return nil, nil, nil
+	}
+
+	withdrawal, err := p.withdrawalRepo.Lock(ctx, user.ID, period.Code, user.PaidIn(company.Balance.Currency))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if withdrawal.Amount.Amount > 0 {
+		item.Status = model.PayrollItemSkipped
+		item.Reason = model.ErrSalaryAlreadyWithdrawn.Error()
+		return nil, nil, nil
+	}
+
+	// an amount edited down stays a partial payment, capped at the pay due
+	// now, any other item pays the whole pay due now
+	amount := item.Amount
+	if amount == item.Salary {
+		amount = money.Money{}
+	}
+
+	summary, payslip, err := payer.pay(ctx, company, user, period, withdrawal, now, amount)
+	if errors.Is(err, model.ErrNoSalaryToWithdraw) {
+		item.Status = model.PayrollItemSkipped
+		item.Reason = err.Error()
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	item.Status = model.PayrollItemPaid
+	item.Salary = summary.Salary
+	item.Amount = summary.Amount
+	item.Payslip = summary.Payslip
+	item.TransactionID = &payslip.TransactionID
+	item.Conversion = summary.Conversion
+	if user.BankCode != "" && user.BankAccountNumber != "" {
+		item.BankCode, item.BankAccountNumber, item.BankAccountName = user.BankCode, user.BankAccountNumber, user.BankAccountName
+		if item.BankAccountName == "" {
+			item.BankAccountName = user.Name
+		}
+	}
+	return user, payslip, nil
+}
+
+// inBalance is what paying amount takes out of the balance, at the rate in
+// effect on t for employees paid in another currency.
+func (p *payrollRunUsecase) inBalance(ctx context.Context, company *model.Company, amount money.Money, t time.Time) (money.Money, error) {
+	debit, _, err := convertMoney(ctx, p.exchangeRateRepo, amount, company.Balance.Currency, company.Balance.Currency, t)
+	return debit, err
+}
+
+// transition moves the run to status under a row lock and records who did
+// it. apply checks the move and updates the run before it is saved.
+func (p *payrollRunUsecase) transition(ctx context.Context, id int, status string, req *request.PayrollRunActionRequest, apply func(ctx context.Context, run *model.PayrollRun) error) (*model.PayrollRun, error) {
+	err := p.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		run, err := p.runRepo.Lock(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		from := run.Status
+		if !model.CanMovePayrollRun(from, status) {
+			return fmt.Errorf("%w: %s to %s", model.ErrInvalidPayrollRunTransition, from, status)
+		}
+
+		if err := apply(ctx, run); err != nil {
+			return err
+		}
+
+		run.Status = status
+		if _, err := p.runRepo.UpdateByID(ctx, id, run); err != nil {
+			return err
+		}
+
+		_, err = p.runRepo.CreateEvent(ctx, &model.PayrollRunEvent{
+			PayrollRunID: id,
+			From:         from,
+			To:           status,
+			Actor:        req.Actor,
+			Note:         req.Note,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetByID(ctx, id)
+}
@@ -0,0 +1,488 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"self-payrol/money"
+	"self-payrol/request"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_payrollRunUsecase_Draft(t *testing.T) {
+	now := time.Date(2026, time.October, 25, 9, 0, 0, 0, time.UTC)
+	company := &model.Company{ID: 1, Balance: money.New(6000, "IDR"), PayrollCycle: model.PayrollCycleMonthly}
+	period := model.PayrollPeriodOf(model.PayrollCycleMonthly, now)
+
+	siti := &model.User{ID: 1, Name: "Siti", PositionID: 1, Status: model.EmploymentActive}
+	budi := &model.User{ID: 2, Name: "Budi", PositionID: 2, Status: model.EmploymentActive}
+	agus := &model.User{ID: 3, Name: "Agus", PositionID: 2, Status: model.EmploymentActive}
+
+	breakdown := &model.PayBreakdown{UserID: 1, Period: period.Code}
+	breakdown.Add(model.PayLine{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(5000, "IDR"), Taxable: true})
+
+	mockUserRepository := new(mocks.UserRepository)
+	mockCompanyRepository := new(mocks.CompanyRepository)
+	mockWithdrawalRepository := new(mocks.WithdrawalRepository)
+	mockRunRepository := new(mocks.PayrollRunRepository)
+	mockPayCalculator := new(mocks.PayCalculator)
+	mockTxManager := new(mocks.TxManager)
+
+	mockTxManager.On("WithinTransaction", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+	mockCompanyRepository.On("Get", mock.Anything).Return(company, nil)
+	mockUserRepository.On("FetchPayable", mock.Anything, period.Start).Return([]*model.User{siti, budi, agus}, nil)
+	// Budi already drew an advance this period
+	mockWithdrawalRepository.On("FetchByPeriod", mock.Anything, period.Code).
+		Return([]*model.Withdrawal{{ID: 12, UserID: 2, Period: period.Code, Amount: money.New(1000, "IDR")}}, nil)
+	mockPayCalculator.On("Calculate", mock.Anything, siti, period).Return(breakdown, nil)
+	// Agus joins after the period
+	mockPayCalculator.On("Calculate", mock.Anything, agus, period).Return(&model.PayBreakdown{UserID: 3, Period: period.Code}, nil)
+
+	expected := &model.PayrollRun{
+		Period:    period.Code,
+		Trigger:   model.PayrollRunScheduled,
+		Status:    model.PayrollRunDraft,
+		CreatedBy: model.PayrollRunScheduler,
+		Total:     money.New(5000, "IDR"),
+		Skipped:   2,
+		Items: []*model.PayrollRunItem{
+			{UserID: 1, Status: model.PayrollItemPending, Salary: money.New(5000, "IDR"), Amount: money.New(5000, "IDR")},
+			{UserID: 2, Status: model.PayrollItemSkipped, Reason: model.ErrSalaryAlreadyWithdrawn.Error()},
+			{UserID: 3, Status: model.PayrollItemSkipped, Reason: model.ErrNoSalaryToWithdraw.Error()},
+		},
+	}
+	mockRunRepository.On("Create", mock.Anything, expected).Return(func(ctx context.Context, run *model.PayrollRun) *model.PayrollRun {
+		run.ID = 9
+		return run
+	}, nil)
+	mockRunRepository.On("CreateEvent", mock.Anything, &model.PayrollRunEvent{
+		PayrollRunID: 9, To: model.PayrollRunDraft, Actor: model.PayrollRunScheduler,
+	}).Return(&model.PayrollRunEvent{ID: 1}, nil)
+
+	p := &payrollRunUsecase{
+		userRepository: mockUserRepository,
+		companyRepo:    mockCompanyRepository,
+		withdrawalRepo: mockWithdrawalRepository,
+		runRepo:        mockRunRepository,
+		payCalculator:  mockPayCalculator,
+		txManager:      mockTxManager,
+		now:            func() time.Time { return now },
+	}
+
+	run, err := p.Draft(context.TODO(), model.PayrollRunScheduled, &request.PayrollRunActionRequest{Actor: model.PayrollRunScheduler})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 9, run.ID)
+	assert.Equal(t, expected.Items, run.Items)
+
+	mockUserRepository.AssertExpectations(t)
+	mockWithdrawalRepository.AssertExpectations(t)
+	mockRunRepository.AssertExpectations(t)
+	mockPayCalculator.AssertExpectations(t)
+}
+
+func Test_payrollRunUsecase_Approve(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     *model.PayrollRun
+		actor       string
+		expectedErr error
+	}{
+		{
+			name:    "Approved by a second admin",
+			current: &model.PayrollRun{ID: 9, Status: model.PayrollRunSubmitted, CreatedBy: model.PayrollRunScheduler, SubmittedBy: "dewi"},
+			actor:   "rudi",
+		},
+		{
+			name:        "Submitter cannot approve",
+			current:     &model.PayrollRun{ID: 9, Status: model.PayrollRunSubmitted, CreatedBy: "dewi", SubmittedBy: "dewi"},
+			actor:       "dewi",
+			expectedErr: model.ErrPayrollRunSelfApproval,
+		},
+		{
+			name:        "Draft must be submitted first",
+			current:     &model.PayrollRun{ID: 9, Status: model.PayrollRunDraft, CreatedBy: "dewi"},
+			actor:       "rudi",
+			expectedErr: fmt.Errorf("%w: draft to approved", model.ErrInvalidPayrollRunTransition),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRunRepository := new(mocks.PayrollRunRepository)
+			mockTxManager := new(mocks.TxManager)
+
+			mockTxManager.On("WithinTransaction", mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+			mockRunRepository.On("Lock", mock.Anything, 9).Return(tt.current, nil)
+
+			approved := &model.PayrollRun{ID: 9, Status: model.PayrollRunApproved, ApprovedBy: tt.actor}
+			if tt.expectedErr == nil {
+				mockRunRepository.On("UpdateByID", mock.Anything, 9, mock.MatchedBy(func(run *model.PayrollRun) bool {
+					return run.Status == model.PayrollRunApproved && run.ApprovedBy == tt.actor
+				})).Return(approved, nil)
+				mockRunRepository.On("CreateEvent", mock.Anything, &model.PayrollRunEvent{
+					PayrollRunID: 9, From: model.PayrollRunSubmitted, To: model.PayrollRunApproved, Actor: tt.actor, Note: "checked",
+				}).Return(&model.PayrollRunEvent{ID: 2}, nil)
+				mockRunRepository.On("FindByID", mock.Anything, 9).Return(approved, nil)
+			}
+
+			p := &payrollRunUsecase{runRepo: mockRunRepository, txManager: mockTxManager, now: time.Now}
+
+			run, err := p.Approve(context.TODO(), 9, &request.PayrollRunActionRequest{Actor: tt.actor, Note: "checked"})
+
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, approved, run)
+			} else {
+				assert.Nil(t, run)
+			}
+
+			mockRunRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_payrollRunUsecase_Disburse(t *testing.T) {
+	now := time.Date(2026, time.October, 25, 9, 0, 0, 0, time.UTC)
+	company := &model.Company{ID: 1, Balance: money.New(6000, "IDR"), PayrollCycle: model.PayrollCycleMonthly}
+	period := model.PayrollPeriodOf(model.PayrollCycleMonthly, now)
+	code := period.Code
+
+	siti := &model.User{ID: 1, Name: "Siti", PositionID: 1, Position: &model.Position{ID: 1, Name: "CEO"}, Status: model.EmploymentActive, BankCode: "014", BankAccountNumber: "1234567890"}
+	budi := &model.User{ID: 2, Name: "Budi", PositionID: 2, Position: &model.Position{ID: 2, Name: "CTO"}, Status: model.EmploymentActive}
+
+	breakdown := &model.PayBreakdown{UserID: 1, Period: code}
+	breakdown.Add(model.PayLine{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(5000, "IDR"), Taxable: true})
+	lines := []model.TransactionLine{{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(5000, "IDR"), Taxable: true}}
+
+	tests := []struct {
+		name        string
+		errDebit    error
+		expectedErr error
+	}{
+		{name: "Pays the pending items and skips who withdrew since"},
+		{
+			name:        "Nobody is paid when the balance runs out",
+			errDebit:    model.ErrInsufficientBalance,
+			expectedErr: fmt.Errorf("employee 1: %w", model.ErrInsufficientBalance),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(mocks.UserRepository)
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockWithdrawalRepository := new(mocks.WithdrawalRepository)
+			mockPayslipRepository := new(mocks.PayslipRepository)
+			mockRunRepository := new(mocks.PayrollRunRepository)
+			mockPayCalculator := new(mocks.PayCalculator)
+			mockTxManager := new(mocks.TxManager)
+			mockNotifier := new(mocks.Notifier)
+
+			mockTxManager.On("WithinTransaction", mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+			mockRunRepository.On("Lock", mock.Anything, 9).Return(&model.PayrollRun{
+				ID: 9, Period: code, Status: model.PayrollRunApproved, Total: money.New(8000, "IDR"),
+				Items: []*model.PayrollRunItem{
+					{ID: 1, PayrollRunID: 9, UserID: 1, Salary: money.New(5000, "IDR"), Amount: money.New(5000, "IDR"), Status: model.PayrollItemPending},
+					{ID: 2, PayrollRunID: 9, UserID: 2, Salary: money.New(3000, "IDR"), Amount: money.New(3000, "IDR"), Status: model.PayrollItemPending},
+					{ID: 3, PayrollRunID: 9, UserID: 3, Status: model.PayrollItemExcluded},
+				},
+			}, nil)
+			mockCompanyRepository.On("Get", mock.Anything).Return(company, nil)
+
+			mockUserRepository.On("FindByID", mock.Anything, 1).Return(siti, nil)
+			mockWithdrawalRepository.On("Lock", mock.Anything, 1, code, "IDR").Return(&model.Withdrawal{ID: 11, UserID: 1, Period: code}, nil)
+			mockPayCalculator.On("Calculate", mock.Anything, siti, period).Return(breakdown, nil)
+			mockCompanyRepository.On("DebitBalance", mock.Anything, &model.Transaction{
+				Amount: money.New(5000, "IDR"), Note: "Siti withdraw salary ", Category: model.TransactionCategorySalary, UserID: &siti.ID, PositionID: &siti.PositionID, Period: &code, Lines: lines,
+			}).Return(&model.Transaction{ID: 21, Amount: money.New(5000, "IDR")}, tt.errDebit)
+
+			payslip := &model.Payslip{ID: 31, TransactionID: 21, Number: "PS/2026-10/1/21"}
+			disbursed := &model.PayrollRun{ID: 9, Status: model.PayrollRunDisbursed, Total: money.New(5000, "IDR"), Paid: 1, Skipped: 1}
+			if tt.errDebit == nil {
+				mockPayCalculator.On("Settle", mock.Anything, breakdown, &model.Transaction{ID: 21, Amount: money.New(5000, "IDR")}).Return(nil)
+				mockWithdrawalRepository.On("UpdateByID", mock.Anything, 11, &model.Withdrawal{Amount: money.New(5000, "IDR")}).Return(nil, nil)
+				mockPayslipRepository.On("Create", mock.Anything, mock.AnythingOfType("*model.Payslip")).Return(payslip, nil)
+
+				transactionID := 21
+				mockRunRepository.On("UpdateItem", mock.Anything, &model.PayrollRunItem{
+					ID: 1, PayrollRunID: 9, UserID: 1, Salary: money.New(5000, "IDR"), Amount: money.New(5000, "IDR"), Status: model.PayrollItemPaid, TransactionID: &transactionID, Payslip: "PS/2026-10/1/21",
+					BankCode: "014", BankAccountNumber: "1234567890", BankAccountName: "Siti",
+				}).Return(nil, nil)
+
+				// Budi withdrew after the run was approved
+				mockUserRepository.On("FindByID", mock.Anything, 2).Return(budi, nil)
+				mockWithdrawalRepository.On("Lock", mock.Anything, 2, code, "IDR").Return(&model.Withdrawal{ID: 12, UserID: 2, Period: code, Amount: money.New(3000, "IDR")}, nil)
+				mockRunRepository.On("UpdateItem", mock.Anything, &model.PayrollRunItem{
+					ID: 2, PayrollRunID: 9, UserID: 2, Salary: money.New(3000, "IDR"), Amount: money.New(3000, "IDR"), Status: model.PayrollItemSkipped, Reason: model.ErrSalaryAlreadyWithdrawn.Error(),
+				}).Return(nil, nil)
+
+				mockRunRepository.On("UpdateByID", mock.Anything, 9, mock.MatchedBy(func(run *model.PayrollRun) bool {
+					return run.Status == model.PayrollRunDisbursed && run.Total == money.New(5000, "IDR") && run.Paid == 1 && run.Skipped == 1 &&
+						run.DisbursedAt.Equal(now)
+				})).Return(disbursed, nil)
+				mockRunRepository.On("CreateEvent", mock.Anything, &model.PayrollRunEvent{
+					PayrollRunID: 9, From: model.PayrollRunApproved, To: model.PayrollRunDisbursed, Actor: "rudi",
+				}).Return(&model.PayrollRunEvent{ID: 3}, nil)
+				mockRunRepository.On("FindByID", mock.Anything, 9).Return(disbursed, nil)
+				mockNotifier.On("SalaryWithdrawn", mock.Anything, siti, payslip).Return()
+			}
+
+			p := &payrollRunUsecase{
+				userRepository: mockUserRepository,
+				companyRepo:    mockCompanyRepository,
+				withdrawalRepo: mockWithdrawalRepository,
+				payslipRepo:    mockPayslipRepository,
+				runRepo:        mockRunRepository,
+				payCalculator:  mockPayCalculator,
+				txManager:      mockTxManager,
+				notifier:       mockNotifier,
+				now:            func() time.Time { return now },
+			}
+
+			run, err := p.Disburse(context.TODO(), 9, &request.PayrollRunActionRequest{Actor: "rudi"})
+
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, disbursed, run)
+			} else {
+				assert.Nil(t, run)
+			}
+
+			mockUserRepository.AssertExpectations(t)
+			mockCompanyRepository.AssertExpectations(t)
+			mockWithdrawalRepository.AssertExpectations(t)
+			mockPayslipRepository.AssertExpectations(t)
+			mockRunRepository.AssertExpectations(t)
+			mockPayCalculator.AssertExpectations(t)
+			mockNotifier.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_payrollRunUsecase_Disburse_PayDue(t *testing.T) {
+	now := time.Date(2026, time.October, 25, 9, 0, 0, 0, time.UTC)
+	company := &model.Company{ID: 1, Balance: money.New(10000, "IDR"), PayrollCycle: model.PayrollCycleMonthly}
+	period := model.PayrollPeriodOf(model.PayrollCycleMonthly, now)
+	code := period.Code
+	siti := &model.User{ID: 1, Name: "Siti", PositionID: 1, Position: &model.Position{ID: 1, Name: "CEO"}, Status: model.EmploymentActive}
+
+	tests := []struct {
+		name     string
+		drafted  money.Money
+		net      int64
+		expected *model.PayrollRunItem
+		settled  bool
+	}{
+		{
+			// an unpaid leave was approved after the run was drafted
+			name:     "Pays the lower pay due on the day",
+			drafted:  money.New(5000, "IDR"),
+			net:      4000,
+			expected: &model.PayrollRunItem{Salary: money.New(4000, "IDR"), Amount: money.New(4000, "IDR"), Status: model.PayrollItemPaid},
+			settled:  true,
+		},
+		{
+			// overtime was approved after the run was drafted
+			name:     "Pays the whole higher pay due and settles the period",
+			drafted:  money.New(5000, "IDR"),
+			net:      6000,
+			expected: &model.PayrollRunItem{Salary: money.New(6000, "IDR"), Amount: money.New(6000, "IDR"), Status: model.PayrollItemPaid},
+			settled:  true,
+		},
+		{
+			name:     "An amount edited down stays a partial payment",
+			drafted:  money.New(2000, "IDR"),
+			net:      6000,
+			expected: &model.PayrollRunItem{Salary: money.New(6000, "IDR"), Amount: money.New(2000, "IDR"), Status: model.PayrollItemPaid},
+		},
+		{
+			name:     "An amount edited down is capped at the pay due",
+			drafted:  money.New(4500, "IDR"),
+			net:      3000,
+			expected: &model.PayrollRunItem{Salary: money.New(3000, "IDR"), Amount: money.New(3000, "IDR"), Status: model.PayrollItemPaid},
+			settled:  true,
+		},
+		{
+			// a loan installment took the whole pay
+			name:     "Skips who has nothing left to be paid",
+			drafted:  money.New(5000, "IDR"),
+			expected: &model.PayrollRunItem{Salary: money.New(5000, "IDR"), Amount: money.New(5000, "IDR"), Status: model.PayrollItemSkipped, Reason: model.ErrNoSalaryToWithdraw.Error()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(mocks.UserRepository)
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockWithdrawalRepository := new(mocks.WithdrawalRepository)
+			mockPayslipRepository := new(mocks.PayslipRepository)
+			mockRunRepository := new(mocks.PayrollRunRepository)
+			mockPayCalculator := new(mocks.PayCalculator)
+			mockTxManager := new(mocks.TxManager)
+			mockNotifier := new(mocks.Notifier)
+
+			breakdown := &model.PayBreakdown{UserID: 1, Period: code}
+			if tt.net > 0 {
+				breakdown.Add(model.PayLine{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(tt.net, "IDR"), Taxable: true})
+			} else {
+				breakdown.Gross, breakdown.Net = money.New(0, "IDR"), money.New(0, "IDR")
+			}
+
+			mockTxManager.On("WithinTransaction", mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+			mockRunRepository.On("Lock", mock.Anything, 9).Return(&model.PayrollRun{
+				ID: 9, Period: code, Status: model.PayrollRunApproved, Total: tt.drafted,
+				Items: []*model.PayrollRunItem{
+					{ID: 1, PayrollRunID: 9, UserID: 1, Salary: money.New(5000, "IDR"), Amount: tt.drafted, Status: model.PayrollItemPending},
+				},
+			}, nil)
+			mockCompanyRepository.On("Get", mock.Anything).Return(company, nil)
+			mockUserRepository.On("FindByID", mock.Anything, 1).Return(siti, nil)
+			mockWithdrawalRepository.On("Lock", mock.Anything, 1, code, "IDR").Return(&model.Withdrawal{ID: 11, UserID: 1, Period: code}, nil)
+			mockPayCalculator.On("Calculate", mock.Anything, siti, period).Return(breakdown, nil)
+
+			transactionID := 21
+			expected := *tt.expected
+			expected.ID, expected.PayrollRunID, expected.UserID = 1, 9, 1
+			payslip := &model.Payslip{ID: 31, TransactionID: transactionID, Number: "PS/2026-10/1/21"}
+			if tt.expected.Status == model.PayrollItemPaid {
+				trx := &model.Transaction{ID: transactionID, Amount: tt.expected.Amount}
+				mockCompanyRepository.On("DebitBalance", mock.Anything, mock.MatchedBy(func(trx *model.Transaction) bool {
+					return trx.Amount == tt.expected.Amount
+				})).Return(trx, nil)
+				if tt.settled {
+					mockPayCalculator.On("Settle", mock.Anything, breakdown, trx).Return(nil)
+				}
+				mockWithdrawalRepository.On("UpdateByID", mock.Anything, 11, &model.Withdrawal{Amount: tt.expected.Amount}).Return(nil, nil)
+				mockPayslipRepository.On("Create", mock.Anything, mock.AnythingOfType("*model.Payslip")).Return(payslip, nil)
+				mockNotifier.On("SalaryWithdrawn", mock.Anything, siti, payslip).Return()
+				expected.TransactionID, expected.Payslip = &transactionID, payslip.Number
+			}
+			mockRunRepository.On("UpdateItem", mock.Anything, &expected).Return(nil, nil)
+
+			total := money.New(0, "IDR")
+			if tt.expected.Status == model.PayrollItemPaid {
+				total = tt.expected.Amount
+			}
+			disbursed := &model.PayrollRun{ID: 9, Status: model.PayrollRunDisbursed, Total: total}
+			mockRunRepository.On("UpdateByID", mock.Anything, 9, mock.MatchedBy(func(run *model.PayrollRun) bool {
+				return run.Status == model.PayrollRunDisbursed && run.Total == total
+			})).Return(disbursed, nil)
+			mockRunRepository.On("CreateEvent", mock.Anything, mock.Anything).Return(&model.PayrollRunEvent{ID: 3}, nil)
+			mockRunRepository.On("FindByID", mock.Anything, 9).Return(disbursed, nil)
+
+			p := &payrollRunUsecase{
+				userRepository: mockUserRepository,
+				companyRepo:    mockCompanyRepository,
+				withdrawalRepo: mockWithdrawalRepository,
+				payslipRepo:    mockPayslipRepository,
+				runRepo:        mockRunRepository,
+				payCalculator:  mockPayCalculator,
+				txManager:      mockTxManager,
+				notifier:       mockNotifier,
+				now:            func() time.Time { return now },
+			}
+
+			run, err := p.Disburse(context.TODO(), 9, &request.PayrollRunActionRequest{Actor: "rudi"})
+
+			assert.NoError(t, err)
+			assert.Equal(t, disbursed, run)
+
+			mockCompanyRepository.AssertExpectations(t)
+			mockWithdrawalRepository.AssertExpectations(t)
+			mockRunRepository.AssertExpectations(t)
+			mockPayCalculator.AssertExpectations(t)
+			mockNotifier.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_payrollRunUsecase_BankFile(t *testing.T) {
+	disbursedAt := time.Date(2026, time.October, 25, 9, 0, 0, 0, time.UTC)
+	company := &model.Company{ID: 1, Balance: money.New(100000000, "IDR"), PayrollCycle: model.PayrollCycleMonthly}
+
+	// the accounts are the ones the employees had when the run was paid,
+	// whatever they have changed to since
+	run := &model.PayrollRun{
+		ID:          7,
+		Period:      "2026-10",
+		Status:      model.PayrollRunDisbursed,
+		DisbursedAt: &disbursedAt,
+		Items: []*model.PayrollRunItem{
+			{UserID: 1, Status: model.PayrollItemPaid, Amount: money.New(8500000, "IDR"), Payslip: "PS-1", BankCode: "014", BankAccountNumber: "1234567890", BankAccountName: "Siti"},
+			// Budi is paid in USD
+			{
+				UserID: 2, Status: model.PayrollItemPaid, Amount: money.New(100000, "USD"), Payslip: "PS-2", BankCode: "008", BankAccountNumber: "9876543210", BankAccountName: "Budi Santoso",
+				Conversion: &model.Conversion{Paid: money.New(100000, "USD"), Debited: money.New(15825500, "IDR"), Rate: "15825.5"},
+			},
+			{UserID: 3, Status: model.PayrollItemSkipped, Reason: model.ErrSalaryAlreadyWithdrawn.Error()},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		run         *model.PayrollRun
+		currency    string
+		expected    *model.BankTransferBatch
+		expectedErr error
+	}{
+		{
+			name: "Pays out the balance currency by default",
+			run:  run,
+			expected: &model.BankTransferBatch{Reference: "PAYROLL-7", Date: disbursedAt, Currency: "IDR", Transfers: []model.BankTransfer{
+				{BankCode: "014", AccountNumber: "1234567890", AccountName: "Siti", Amount: money.New(8500000, "IDR"), Reference: "PS-1", Description: "Salary 2026-10"},
+			}},
+		},
+		{
+			name:     "Pays out what employees received in another currency",
+			run:      run,
+			currency: "USD",
+			expected: &model.BankTransferBatch{Reference: "PAYROLL-7", Date: disbursedAt, Currency: "USD", Transfers: []model.BankTransfer{
+				{BankCode: "008", AccountNumber: "9876543210", AccountName: "Budi Santoso", Amount: money.New(100000, "USD"), Reference: "PS-2", Description: "Salary 2026-10"},
+			}},
+		},
+		{
+			name: "Employees need a bank account",
+			run: &model.PayrollRun{ID: 7, Period: "2026-10", Status: model.PayrollRunDisbursed, DisbursedAt: &disbursedAt, Items: []*model.PayrollRunItem{
+				{UserID: 3, Status: model.PayrollItemPaid, Amount: money.New(5000000, "IDR")},
+			}},
+			expectedErr: fmt.Errorf("%w: employee 3 was paid without one", model.ErrNoBankAccount),
+		},
+		{
+			name:        "Only disbursed runs",
+			run:         &model.PayrollRun{ID: 7, Status: model.PayrollRunApproved},
+			expectedErr: fmt.Errorf("%w: run is %s", model.ErrPayrollRunNotDisbursed, model.PayrollRunApproved),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockRunRepository := new(mocks.PayrollRunRepository)
+			mockExporter := new(mocks.BankFileExporter)
+
+			mockRunRepository.On("FindByID", mock.Anything, 7).Return(tt.run, nil)
+			mockCompanyRepository.On("Get", mock.Anything).Return(company, nil)
+			file := &model.BankFile{Name: "payroll-7.csv"}
+			if tt.expected != nil {
+				mockExporter.On("Export", tt.expected, "csv").Return(file, nil)
+			}
+
+			p := &payrollRunUsecase{userRepository: new(mocks.UserRepository), companyRepo: mockCompanyRepository, runRepo: mockRunRepository, exporter: mockExporter, now: time.Now}
+
+			got, err := p.BankFile(context.TODO(), 7, "csv", tt.currency)
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, file, got)
+			mockExporter.AssertExpectations(t)
+		})
+	}
+}
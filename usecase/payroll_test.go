@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"self-payrol/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_payrollUsecase_Preview(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+	october, _ := model.ParsePayrollPeriod("2026-10", time.UTC)
+
+	siti := &model.User{ID: 1, PositionID: 1, Status: model.EmploymentActive}
+	budi := &model.User{ID: 2, PositionID: 2, Status: model.EmploymentActive}
+	agus := &model.User{ID: 3, PositionID: 2, Status: model.EmploymentSuspended}
+	// the position of Dewi was removed
+	dewi := &model.User{ID: 4, PositionID: 9, Status: model.EmploymentActive}
+
+	pay := func(userID int, gross, deductions, employer int64) *model.PayBreakdown {
+		breakdown := model.NewPayBreakdown(userID, "2026-10", "IDR")
+		breakdown.Add(model.PayLine{Code: model.PayCodeBaseSalary, Kind: model.PayLineEarning, Amount: money.New(gross, "IDR")})
+		breakdown.Add(model.PayLine{Code: "pph21", Kind: model.PayLineDeduction, Amount: money.New(deductions, "IDR")})
+		breakdown.Add(model.PayLine{Code: "bpjs_jht", Kind: model.PayLineEmployer, Amount: money.New(employer, "IDR")})
+		return breakdown
+	}
+
+	zero := money.New(0, "IDR")
+	intern := &model.PositionLiability{PositionID: 3, Name: "Intern", Gross: zero, Net: zero, EmployerCost: zero, Withdrawn: zero, Liability: zero}
+	positions := []*model.PositionLiability{
+		{PositionID: 1, Name: "CEO", Headcount: 1, Gross: money.New(5500, "IDR"), Net: money.New(5000, "IDR"), EmployerCost: money.New(200, "IDR"), Withdrawn: money.New(1000, "IDR"), Liability: money.New(4200, "IDR")},
+		{PositionID: 2, Name: "CTO", Headcount: 1, Gross: money.New(3000, "IDR"), Net: money.New(3000, "IDR"), EmployerCost: money.New(100, "IDR"), Withdrawn: zero, Liability: money.New(3100, "IDR")},
+		intern,
+		{Name: model.UnassignedPosition, Headcount: 1, Gross: money.New(1000, "IDR"), Net: money.New(1000, "IDR"), EmployerCost: money.New(50, "IDR"), Withdrawn: zero, Liability: money.New(1050, "IDR")},
+	}
+
+	tests := []struct {
+		name            string
+		period          string
+		balance         int64
+		expectedPreview *model.PayrollPreview
+		expectedErr     error
+	}{
+		{
+			name:    "Balance short of what is left to pay",
+			period:  "2026-10",
+			balance: 6000,
+			expectedPreview: &model.PayrollPreview{
+				Period: "2026-10", Headcount: 3, Gross: money.New(9500, "IDR"), Net: money.New(9000, "IDR"), EmployerCost: money.New(350, "IDR"),
+				Withdrawn: money.New(1000, "IDR"), Liability: money.New(8350, "IDR"), Balance: money.New(6000, "IDR"), Shortfall: money.New(2350, "IDR"),
+				Positions: positions,
+			},
+		},
+		{
+			name:    "Current period covered by the balance",
+			balance: 9000,
+			expectedPreview: &model.PayrollPreview{
+				Period: "2026-10", Headcount: 3, Gross: money.New(9500, "IDR"), Net: money.New(9000, "IDR"), EmployerCost: money.New(350, "IDR"),
+				Withdrawn: money.New(1000, "IDR"), Liability: money.New(8350, "IDR"), Balance: money.New(9000, "IDR"), Shortfall: zero,
+				Positions: positions,
+			},
+		},
+		{
+			name:        "Invalid period",
+			period:      "October",
+			balance:     6000,
+			expectedErr: model.ErrInvalidPayrollPeriod,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(mocks.UserRepository)
+			mockPositionRepository := new(mocks.PositionRepository)
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockWithdrawalRepository := new(mocks.WithdrawalRepository)
+			mockPayCalculator := new(mocks.PayCalculator)
+
+			mockCompanyRepository.On("Get", mock.Anything).
+				Return(&model.Company{ID: 1, Balance: money.New(tt.balance, "IDR"), PayrollCycle: model.PayrollCycleMonthly}, nil)
+
+			if tt.expectedErr == nil {
+				mockPositionRepository.On("Fetch", mock.Anything, 0, 0).Return([]*model.Position{
+					{ID: 1, Name: "CEO"}, {ID: 2, Name: "CTO"}, {ID: 3, Name: "Intern"},
+				}, nil)
+				mockUserRepository.On("Fetch", mock.Anything, 0, 0).Return([]*model.User{siti, budi, agus, dewi}, nil)
+				mockWithdrawalRepository.On("FetchByPeriod", mock.Anything, "2026-10").
+					Return([]*model.Withdrawal{{ID: 7, UserID: 1, Period: "2026-10", Amount: money.New(1000, "IDR")}}, nil)
+				mockPayCalculator.On("Calculate", mock.Anything, siti, october).Return(pay(1, 5500, 500, 200), nil)
+				mockPayCalculator.On("Calculate", mock.Anything, budi, october).Return(pay(2, 3000, 0, 100), nil)
+				mockPayCalculator.On("Calculate", mock.Anything, dewi, october).Return(pay(4, 1000, 0, 50), nil)
+			}
+
+			p := &payrollUsecase{
+				userRepository: mockUserRepository,
+				positionRepo:   mockPositionRepository,
+				companyRepo:    mockCompanyRepository,
+				withdrawalRepo: mockWithdrawalRepository,
+				payCalculator:  mockPayCalculator,
+				now:            func() time.Time { return now },
+			}
+
+			preview, err := p.Preview(context.TODO(), tt.period)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedPreview, preview)
+
+			mockUserRepository.AssertExpectations(t)
+			mockPositionRepository.AssertExpectations(t)
+			mockCompanyRepository.AssertExpectations(t)
+			mockWithdrawalRepository.AssertExpectations(t)
+			mockPayCalculator.AssertExpectations(t)
+		})
+	}
+}
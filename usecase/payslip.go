@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"self-payrol/model"
+	"self-payrol/money"
+	"time"
+)
+
+type payslipUsecase struct {
+	userRepository    model.UserRepository
+	payslipRepository model.PayslipRepository
+	renderer          model.PayslipRenderer
+}
+
+func NewPayslipUsecase(user model.UserRepository, payslip model.PayslipRepository, renderer model.PayslipRenderer) model.PayslipUsecase {
+	return &payslipUsecase{userRepository: user, payslipRepository: payslip, renderer: renderer}
+}
+
+func (p *payslipUsecase) FetchPayslips(ctx context.Context, userID int) ([]*model.Payslip, error) {
+	_, err := p.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	payslips, err := p.payslipRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return payslips, nil
+}
+
+// RenderPayslip renders the latest payslip of the period from its stored
+// snapshot.
+func (p *payslipUsecase) RenderPayslip(ctx context.Context, userID int, period string) (*model.Payslip, []byte, error) {
+	payslip, err := p.payslipRepository.FindLatest(ctx, userID, period)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pdf, err := p.renderer.Render(payslip)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return payslip, pdf, nil
+}
+
+// newPayslip snapshots what the payslip of trx shows. amount is what trx
+// paid the employee, withdrawn the period total with it and remaining what
+// is left of the net pay after it.
+func newPayslip(company *model.Company, user *model.User, period model.PayrollPeriod, breakdown *model.PayBreakdown, trx *model.Transaction, issuedAt time.Time, amount, withdrawn, remaining money.Money) *model.Payslip {
+	data := model.PayslipData{
+		CompanyName:    company.Name,
+		CompanyAddress: company.Address,
+		EmployeeID:     user.ID,
+		EmployeeName:   user.Name,
+		EmployeeEmail:  user.Email,
+		Period:         period.Code,
+		PeriodStart:    period.Start,
+		PeriodEnd:      period.End,
+		IssuedAt:       issuedAt,
+		Lines:          breakdown.Lines,
+		Gross:          breakdown.Gross,
+		Deductions:     breakdown.Deductions,
+		Net:            breakdown.Net,
+		EmployerCost:   breakdown.EmployerCost,
+		Amount:         amount,
+		Withdrawn:      withdrawn,
+		Remaining:      remaining,
+		Conversion:     trx.Conversion,
+	}
+	if user.Position != nil {
+		data.PositionName = user.Position.Name
+	}
+
+	return &model.Payslip{
+		UserID:        user.ID,
+		Period:        period.Code,
+		TransactionID: trx.ID,
+		Number:        fmt.Sprintf("PS/%s/%d/%d", period.Code, user.ID, trx.ID),
+		Data:          data,
+	}
+}
@@ -0,0 +1,69 @@
+package usecase_test
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"self-payrol/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func Test_payslipUsecase_RenderPayslip(t *testing.T) {
+	payslip := &model.Payslip{ID: 3, UserID: 1, Period: "2026-10", TransactionID: 1, Number: "PS/2026-10/1/1"}
+
+	tests := []struct {
+		name            string
+		repoPayslip     *model.Payslip
+		repoErr         error
+		renderErr       error
+		expectedPayslip *model.Payslip
+		expectedPDF     []byte
+		expectedErr     error
+	}{
+		{
+			name:            "Renders the latest payslip of the period",
+			repoPayslip:     payslip,
+			expectedPayslip: payslip,
+			expectedPDF:     []byte("%PDF-1.4"),
+		},
+		{
+			name:        "No payslip for the period",
+			repoErr:     gorm.ErrRecordNotFound,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name:        "Failed to render",
+			repoPayslip: payslip,
+			renderErr:   assert.AnError,
+			expectedErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPayslipRepository := new(mocks.PayslipRepository)
+			mockRenderer := new(mocks.PayslipRenderer)
+
+			mockPayslipRepository.On("FindLatest", mock.Anything, 1, "2026-10").
+				Return(tt.repoPayslip, tt.repoErr)
+
+			if tt.repoPayslip != nil {
+				mockRenderer.On("Render", tt.repoPayslip).Return(tt.expectedPDF, tt.renderErr)
+			}
+
+			p := usecase.NewPayslipUsecase(new(mocks.UserRepository), mockPayslipRepository, mockRenderer)
+
+			payslip, pdf, err := p.RenderPayslip(context.TODO(), 1, "2026-10")
+
+			assert.Equal(t, tt.expectedPayslip, payslip)
+			assert.Equal(t, tt.expectedPDF, pdf)
+			assert.Equal(t, tt.expectedErr, err)
+
+			mockPayslipRepository.AssertExpectations(t)
+			mockRenderer.AssertExpectations(t)
+		})
+	}
+}
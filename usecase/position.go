@@ -2,16 +2,29 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"self-payrol/model"
+	"self-payrol/money"
 	"self-payrol/request"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 type positionUsecase struct {
-	positionRepository model.PositionRepository
+	positionRepository   model.PositionRepository
+	salaryRepository     model.PositionSalaryRepository
+	userSalaryRepository model.UserSalaryRepository
+	companyRepository    model.CompanyRepository
+	txManager            model.TxManager
+	now                  func() time.Time
 }
 
-func NewPositionUsecase(position model.PositionRepository) model.PositionUsecase {
-	return &positionUsecase{positionRepository: position}
+func NewPositionUsecase(position model.PositionRepository, salary model.PositionSalaryRepository, userSalary model.UserSalaryRepository, company model.CompanyRepository, tx model.TxManager) model.PositionUsecase {
+	return &positionUsecase{positionRepository: position, salaryRepository: salary, userSalaryRepository: userSalary, companyRepository: company, txManager: tx, now: time.Now}
 }
 
 func (p *positionUsecase) GetByID(ctx context.Context, id int) (*model.Position, error) {
@@ -43,17 +56,75 @@ func (p *positionUsecase) DestroyPosition(ctx context.Context, id int) error {
 	return nil
 }
 
+// EditPosition records a salary change in the history from its effective
+// date instead of overwriting it, so periods already under way keep being
+// paid the salary they started with. A salary band is refused while the
+// salary overrides of employees on the position fall outside it.
 func (p *positionUsecase) EditPosition(ctx context.Context, id int, req *request.PositionRequest) (*model.Position, error) {
-	_, err := p.positionRepository.FindByID(ctx, id)
+	now := p.now()
+	if err := p.checkCurrency(ctx, req); err != nil {
+		return nil, err
+	}
+
+	effectiveFrom, err := p.effectiveFrom(req)
 	if err != nil {
 		return nil, err
 	}
 
-	position, err := p.positionRepository.UpdateByID(ctx, id, &model.Position{
-		Name:   req.Name,
-		Salary: req.Salary,
-	})
+	var position *model.Position
+	err = p.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := p.positionRepository.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := p.checkOverrides(ctx, id, req, now); err != nil {
+			return err
+		}
+
+		history, err := p.salaryRepository.FetchByPositionID(ctx, id)
+		if err != nil {
+			return err
+		}
 
+		if model.SalaryOn(history, effectiveFrom, current.Salary) != req.Salary {
+			// positions created before the history existed keep their
+			// salary for the periods before the change
+			if len(history) == 0 {
+				_, err = p.salaryRepository.Upsert(ctx, &model.PositionSalary{
+					PositionID:    id,
+					Salary:        current.Salary,
+					EffectiveFrom: dateOf(current.CreatedAt),
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			_, err = p.salaryRepository.Upsert(ctx, &model.PositionSalary{
+				PositionID:    id,
+				Salary:        req.Salary,
+				EffectiveFrom: effectiveFrom,
+			})
+			if err != nil {
+				return err
+			}
+
+			history, err = p.salaryRepository.FetchByPositionID(ctx, id)
+			if err != nil {
+				return err
+			}
+		}
+
+		position, err = p.positionRepository.UpdateByID(ctx, id, &model.Position{
+			Name:        req.Name,
+			Salary:      model.SalaryOn(history, now, req.Salary),
+			MinSalary:   req.MinSalary,
+			MaxSalary:   req.MaxSalary,
+			PayCurrency: req.PayCurrency,
+		})
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -62,16 +133,143 @@ func (p *positionUsecase) EditPosition(ctx context.Context, id int, req *request
 }
 
 func (p *positionUsecase) StorePosition(ctx context.Context, req *request.PositionRequest) (*model.Position, error) {
-	newPosition := &model.Position{
-		Name:   req.Name,
-		Salary: req.Salary,
+	if err := p.checkCurrency(ctx, req); err != nil {
+		return nil, err
+	}
+
+	effectiveFrom, err := p.effectiveFrom(req)
+	if err != nil {
+		return nil, err
 	}
 
-	position, err := p.positionRepository.Create(ctx, newPosition)
+	var position *model.Position
+	err = p.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		newPosition := &model.Position{
+			Name:        req.Name,
+			Salary:      req.Salary,
+			MinSalary:   req.MinSalary,
+			MaxSalary:   req.MaxSalary,
+			PayCurrency: req.PayCurrency,
+		}
+
+		position, err = p.positionRepository.Create(ctx, newPosition)
+		if err != nil {
+			return err
+		}
 
+		_, err = p.salaryRepository.Upsert(ctx, &model.PositionSalary{
+			PositionID:    position.ID,
+			Salary:        req.Salary,
+			EffectiveFrom: effectiveFrom,
+		})
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	return position, nil
 }
+
+func (p *positionUsecase) FetchSalaryHistory(ctx context.Context, id int) ([]*model.PositionSalary, error) {
+	_, err := p.positionRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := p.salaryRepository.FetchByPositionID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// checkOverrides refuses the salary band of req when the override of an
+// employee on the position falls outside it, the one in effect now or one
+// starting later, listing the employees whose override has to change
+// first.
+func (p *positionUsecase) checkOverrides(ctx context.Context, id int, req *request.PositionRequest, now time.Time) error {
+	if req.MinSalary == nil && req.MaxSalary == nil {
+		return nil
+	}
+
+	overrides, err := p.userSalaryRepository.FetchByPositionID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	var users []int
+	histories := make(map[int][]*model.UserSalary)
+	for _, override := range overrides {
+		if _, ok := histories[override.UserID]; !ok {
+			users = append(users, override.UserID)
+		}
+		histories[override.UserID] = append(histories[override.UserID], override)
+	}
+
+	band := &model.Position{MinSalary: req.MinSalary, MaxSalary: req.MaxSalary}
+	today := now.Format("2006-01-02")
+	var outside []string
+	for _, userID := range users {
+		history := histories[userID]
+		salaries := []*money.Money{model.OverrideOn(history, now)}
+		for _, override := range history {
+			if override.EffectiveFrom.Format("2006-01-02") > today {
+				salaries = append(salaries, override.Salary)
+			}
+		}
+
+		for _, salary := range salaries {
+			if salary != nil && !band.InBand(*salary) {
+				outside = append(outside, strconv.Itoa(userID))
+				break
+			}
+		}
+	}
+
+	if len(outside) > 0 {
+		return fmt.Errorf("%w: the overrides of employees %s", model.ErrSalaryOutsideBand, strings.Join(outside, ", "))
+	}
+	return nil
+}
+
+// checkCurrency refuses a salary or salary band in another currency than
+// the position pays in, its pay currency or else the one of the company
+// balance. Before the company is set up there is no balance to compare
+// against.
+func (p *positionUsecase) checkCurrency(ctx context.Context, req *request.PositionRequest) error {
+	currency := req.PayCurrency
+	if currency == "" {
+		company, err := p.companyRepository.Get(ctx)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		currency = company.Balance.Currency
+	}
+
+	for _, salary := range []*money.Money{&req.Salary, req.MinSalary, req.MaxSalary} {
+		if salary != nil && salary.Currency != currency {
+			return fmt.Errorf("%w: %s, the position pays in %s", model.ErrSalaryCurrency, salary.Currency, currency)
+		}
+	}
+
+	return nil
+}
+
+func (p *positionUsecase) effectiveFrom(req *request.PositionRequest) (time.Time, error) {
+	now := p.now()
+	if req.EffectiveFrom == "" {
+		return dateOf(now), nil
+	}
+
+	return time.ParseInLocation("2006-01-02", req.EffectiveFrom, now.Location())
+}
+
+// dateOf drops the time of day from t.
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
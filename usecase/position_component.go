@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/request"
+)
+
+type positionComponentUsecase struct {
+	componentRepository model.PositionComponentRepository
+	positionRepository  model.PositionRepository
+}
+
+func NewPositionComponentUsecase(component model.PositionComponentRepository, position model.PositionRepository) model.PositionComponentUsecase {
+	return &positionComponentUsecase{componentRepository: component, positionRepository: position}
+}
+
+func (p *positionComponentUsecase) GetByID(ctx context.Context, positionID, id int) (*model.PositionComponent, error) {
+	component, err := p.componentRepository.FindByID(ctx, positionID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return component, nil
+}
+
+func (p *positionComponentUsecase) FetchComponent(ctx context.Context, positionID int) ([]*model.PositionComponent, error) {
+	_, err := p.positionRepository.FindByID(ctx, positionID)
+	if err != nil {
+		return nil, err
+	}
+
+	components, err := p.componentRepository.FetchByPositionID(ctx, positionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
+
+func (p *positionComponentUsecase) DestroyComponent(ctx context.Context, positionID, id int) error {
+	err := p.componentRepository.Delete(ctx, positionID, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *positionComponentUsecase) EditComponent(ctx context.Context, positionID, id int, req *request.PositionComponentRequest) (*model.PositionComponent, error) {
+	_, err := p.componentRepository.FindByID(ctx, positionID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	component, err := p.componentRepository.UpdateByID(ctx, id, &model.PositionComponent{
+		Name:    req.Name,
+		Type:    req.Type,
+		Amount:  req.Amount,
+		Taxable: req.Taxable,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return component, nil
+}
+
+func (p *positionComponentUsecase) StoreComponent(ctx context.Context, positionID int, req *request.PositionComponentRequest) (*model.PositionComponent, error) {
+	_, err := p.positionRepository.FindByID(ctx, positionID)
+	if err != nil {
+		return nil, err
+	}
+
+	component, err := p.componentRepository.Create(ctx, &model.PositionComponent{
+		PositionID: positionID,
+		Name:       req.Name,
+		Type:       req.Type,
+		Amount:     req.Amount,
+		Taxable:    req.Taxable,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return component, nil
+}
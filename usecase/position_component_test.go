@@ -0,0 +1,216 @@
+package usecase_test
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"self-payrol/money"
+	"self-payrol/request"
+	"self-payrol/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func Test_positionComponentUsecase_FetchComponent(t *testing.T) {
+	tests := []struct {
+		name               string
+		positionID         int
+		repoPositionErr    error
+		repoComponents     []*model.PositionComponent
+		repoComponentErr   error
+		expectedComponents []*model.PositionComponent
+		expectedErr        error
+	}{
+		{
+			name:       "Successfully fetch components",
+			positionID: 1,
+			repoComponents: []*model.PositionComponent{
+				{ID: 1, PositionID: 1, Name: "Transport", Type: model.PositionComponentAllowance, Amount: money.New(500, "IDR"), Taxable: true},
+			},
+			expectedComponents: []*model.PositionComponent{
+				{ID: 1, PositionID: 1, Name: "Transport", Type: model.PositionComponentAllowance, Amount: money.New(500, "IDR"), Taxable: true},
+			},
+		},
+		{
+			name:            "Position not found",
+			positionID:      2,
+			repoPositionErr: gorm.ErrRecordNotFound,
+			expectedErr:     gorm.ErrRecordNotFound,
+		},
+		{
+			name:             "Failed to fetch components",
+			positionID:       1,
+			repoComponentErr: assert.AnError,
+			expectedErr:      assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockComponentRepository := new(mocks.PositionComponentRepository)
+			mockPositionRepository := new(mocks.PositionRepository)
+
+			mockPositionRepository.On("FindByID", mock.Anything, tt.positionID).
+				Return(&model.Position{ID: tt.positionID}, tt.repoPositionErr)
+
+			if tt.repoPositionErr == nil {
+				mockComponentRepository.On("FetchByPositionID", mock.Anything, tt.positionID).
+					Return(tt.repoComponents, tt.repoComponentErr)
+			}
+
+			p := usecase.NewPositionComponentUsecase(mockComponentRepository, mockPositionRepository)
+
+			components, err := p.FetchComponent(context.TODO(), tt.positionID)
+
+			assert.Equal(t, tt.expectedComponents, components)
+			assert.Equal(t, tt.expectedErr, err)
+
+			mockComponentRepository.AssertExpectations(t)
+			mockPositionRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_positionComponentUsecase_StoreComponent(t *testing.T) {
+	req := &request.PositionComponentRequest{Name: "Meal", Type: model.PositionComponentAllowance, Amount: money.New(300, "IDR")}
+	tests := []struct {
+		name              string
+		positionID        int
+		repoPositionErr   error
+		repoComponent     *model.PositionComponent
+		repoComponentErr  error
+		expectedComponent *model.PositionComponent
+		expectedErr       error
+	}{
+		{
+			name:              "Successfully store component",
+			positionID:        1,
+			repoComponent:     &model.PositionComponent{ID: 4, PositionID: 1, Name: "Meal", Type: model.PositionComponentAllowance, Amount: money.New(300, "IDR")},
+			expectedComponent: &model.PositionComponent{ID: 4, PositionID: 1, Name: "Meal", Type: model.PositionComponentAllowance, Amount: money.New(300, "IDR")},
+		},
+		{
+			name:            "Position not found",
+			positionID:      2,
+			repoPositionErr: gorm.ErrRecordNotFound,
+			expectedErr:     gorm.ErrRecordNotFound,
+		},
+		{
+			name:             "Failed to store component",
+			positionID:       1,
+			repoComponentErr: assert.AnError,
+			expectedErr:      assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockComponentRepository := new(mocks.PositionComponentRepository)
+			mockPositionRepository := new(mocks.PositionRepository)
+
+			mockPositionRepository.On("FindByID", mock.Anything, tt.positionID).
+				Return(&model.Position{ID: tt.positionID}, tt.repoPositionErr)
+
+			if tt.repoPositionErr == nil {
+				mockComponentRepository.On("Create", mock.Anything, &model.PositionComponent{
+					PositionID: tt.positionID,
+					Name:       req.Name,
+					Type:       req.Type,
+					Amount:     req.Amount,
+					Taxable:    req.Taxable,
+				}).Return(tt.repoComponent, tt.repoComponentErr)
+			}
+
+			p := usecase.NewPositionComponentUsecase(mockComponentRepository, mockPositionRepository)
+
+			component, err := p.StoreComponent(context.TODO(), tt.positionID, req)
+
+			assert.Equal(t, tt.expectedComponent, component)
+			assert.Equal(t, tt.expectedErr, err)
+
+			mockComponentRepository.AssertExpectations(t)
+			mockPositionRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_positionComponentUsecase_EditComponent(t *testing.T) {
+	req := &request.PositionComponentRequest{Name: "Meal", Type: model.PositionComponentAllowance, Amount: money.New(350, "IDR"), Taxable: true}
+	tests := []struct {
+		name              string
+		repoFindErr       error
+		repoComponent     *model.PositionComponent
+		repoUpdateErr     error
+		expectedComponent *model.PositionComponent
+		expectedErr       error
+	}{
+		{
+			name:              "Successfully edit component",
+			repoComponent:     &model.PositionComponent{ID: 4, PositionID: 1, Name: "Meal", Type: model.PositionComponentAllowance, Amount: money.New(350, "IDR"), Taxable: true},
+			expectedComponent: &model.PositionComponent{ID: 4, PositionID: 1, Name: "Meal", Type: model.PositionComponentAllowance, Amount: money.New(350, "IDR"), Taxable: true},
+		},
+		{
+			name:        "Component not found",
+			repoFindErr: gorm.ErrRecordNotFound,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name:          "Failed to update component",
+			repoUpdateErr: assert.AnError,
+			expectedErr:   assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockComponentRepository := new(mocks.PositionComponentRepository)
+			mockPositionRepository := new(mocks.PositionRepository)
+
+			mockComponentRepository.On("FindByID", mock.Anything, 1, 4).
+				Return(&model.PositionComponent{ID: 4, PositionID: 1}, tt.repoFindErr)
+
+			if tt.repoFindErr == nil {
+				mockComponentRepository.On("UpdateByID", mock.Anything, 4, &model.PositionComponent{
+					Name:    req.Name,
+					Type:    req.Type,
+					Amount:  req.Amount,
+					Taxable: req.Taxable,
+				}).Return(tt.repoComponent, tt.repoUpdateErr)
+			}
+
+			p := usecase.NewPositionComponentUsecase(mockComponentRepository, mockPositionRepository)
+
+			component, err := p.EditComponent(context.TODO(), 1, 4, req)
+
+			assert.Equal(t, tt.expectedComponent, component)
+			assert.Equal(t, tt.expectedErr, err)
+
+			mockComponentRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_positionComponentUsecase_DestroyComponent(t *testing.T) {
+	tests := []struct {
+		name        string
+		repoErr     error
+		expectedErr error
+	}{
+		{name: "Successfully destroy component"},
+		{name: "Failed to destroy component", repoErr: gorm.ErrRecordNotFound, expectedErr: gorm.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockComponentRepository := new(mocks.PositionComponentRepository)
+
+			mockComponentRepository.On("Delete", mock.Anything, 1, 4).Return(tt.repoErr)
+
+			p := usecase.NewPositionComponentUsecase(mockComponentRepository, new(mocks.PositionRepository))
+
+			err := p.DestroyComponent(context.TODO(), 1, 4)
+
+			assert.Equal(t, tt.expectedErr, err)
+
+			mockComponentRepository.AssertExpectations(t)
+		})
+	}
+}
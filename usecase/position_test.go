@@ -3,11 +3,14 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"self-payrol/model"
 	"self-payrol/model/mocks"
+	"self-payrol/money"
 	"self-payrol/request"
 	"self-payrol/usecase"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -37,13 +40,13 @@ func Test_positionUsecase_GetByID(t *testing.T) {
 			repoResponsePosition: &model.Position{
 				ID:     1,
 				Name:   "CEO",
-				Salary: 1000,
+				Salary: money.New(1000, "IDR"),
 			},
 			repoResponseErr: nil,
 			expectedPosition: &model.Position{
 				ID:     1,
 				Name:   "CEO",
-				Salary: 1000,
+				Salary: money.New(1000, "IDR"),
 			},
 			expectedErr: nil,
 		},
@@ -66,7 +69,7 @@ func Test_positionUsecase_GetByID(t *testing.T) {
 			mockPositionRepository.On("FindByID", mock.Anything, tt.args.id).
 				Return(tt.repoResponsePosition, tt.repoResponseErr)
 
-			p := usecase.NewPositionUsecase(mockPositionRepository)
+			p := usecase.NewPositionUsecase(mockPositionRepository, new(mocks.PositionSalaryRepository), new(mocks.UserSalaryRepository), new(mocks.CompanyRepository), new(mocks.TxManager))
 
 			position, err := p.GetByID(tt.args.ctx, tt.args.id)
 
@@ -104,17 +107,17 @@ func Test_positionUsecase_FetchPosition(t *testing.T) {
 				{
 					ID:     1,
 					Name:   "CEO",
-					Salary: 1000,
+					Salary: money.New(1000, "IDR"),
 				},
 				{
 					ID:     2,
 					Name:   "CPO",
-					Salary: 900,
+					Salary: money.New(900, "IDR"),
 				},
 				{
 					ID:     3,
 					Name:   "CTO",
-					Salary: 900,
+					Salary: money.New(900, "IDR"),
 				},
 			},
 			repoResponseErr: nil,
@@ -122,17 +125,17 @@ func Test_positionUsecase_FetchPosition(t *testing.T) {
 				{
 					ID:     1,
 					Name:   "CEO",
-					Salary: 1000,
+					Salary: money.New(1000, "IDR"),
 				},
 				{
 					ID:     2,
 					Name:   "CPO",
-					Salary: 900,
+					Salary: money.New(900, "IDR"),
 				},
 				{
 					ID:     3,
 					Name:   "CTO",
-					Salary: 900,
+					Salary: money.New(900, "IDR"),
 				},
 			},
 			expectedErr: nil,
@@ -157,7 +160,7 @@ func Test_positionUsecase_FetchPosition(t *testing.T) {
 			mockPositionRepository.On("Fetch", mock.Anything, tt.args.limit, tt.args.offset).
 				Return(tt.repoResponsePositions, tt.repoResponseErr)
 
-			p := usecase.NewPositionUsecase(mockPositionRepository)
+			p := usecase.NewPositionUsecase(mockPositionRepository, new(mocks.PositionSalaryRepository), new(mocks.UserSalaryRepository), new(mocks.CompanyRepository), new(mocks.TxManager))
 
 			positions, err := p.FetchPosition(tt.args.ctx, tt.args.limit, tt.args.offset)
 
@@ -207,7 +210,7 @@ func Test_positionUsecase_DestroyPosition(t *testing.T) {
 			mockPositionRepository.On("Delete", mock.Anything, tt.args.id).
 				Return(tt.repoResponseErr)
 
-			p := usecase.NewPositionUsecase(mockPositionRepository)
+			p := usecase.NewPositionUsecase(mockPositionRepository, new(mocks.PositionSalaryRepository), new(mocks.UserSalaryRepository), new(mocks.CompanyRepository), new(mocks.TxManager))
 
 			err := p.DestroyPosition(tt.args.ctx, tt.args.id)
 
@@ -219,6 +222,9 @@ func Test_positionUsecase_DestroyPosition(t *testing.T) {
 }
 
 func Test_positionUsecase_EditPosition(t *testing.T) {
+	minSalary, maxSalary := money.New(900, "IDR"), money.New(1100, "IDR")
+	inBand, tooHigh := money.New(1050, "IDR"), money.New(1500, "IDR")
+
 	type args struct {
 		ctx context.Context
 		id  int
@@ -227,10 +233,15 @@ func Test_positionUsecase_EditPosition(t *testing.T) {
 	tests := []struct {
 		name                 string
 		args                 args
+		repoCurrent          *model.Position
 		repoPosition         *model.Position
 		repoResponsePosition *model.Position
 		repoResponseErr1     error
 		repoResponseErr2     error
+		repoHistory          []*model.PositionSalary
+		repoSalaries         []*model.PositionSalary
+		repoUpdatedHistory   []*model.PositionSalary
+		repoOverrides        []*model.UserSalary
 		expectedPosition     *model.Position
 		expectedErr          error
 	}{
@@ -240,21 +251,42 @@ func Test_positionUsecase_EditPosition(t *testing.T) {
 			args: args{
 				ctx: context.TODO(),
 				id:  1,
-				req: &request.PositionRequest{Name: "CEO", Salary: 1000},
+				req: &request.PositionRequest{Name: "CEO", Salary: money.New(1000, "IDR")},
 			},
-			repoPosition:         &model.Position{Name: "CEO", Salary: 1000},
-			repoResponsePosition: &model.Position{ID: 1, Name: "CEO", Salary: 1000},
+			repoCurrent:          &model.Position{ID: 1, Name: "CEO", Salary: money.New(1000, "IDR")},
+			repoPosition:         &model.Position{Name: "CEO", Salary: money.New(1000, "IDR")},
+			repoResponsePosition: &model.Position{ID: 1, Name: "CEO", Salary: money.New(1000, "IDR")},
 			repoResponseErr1:     nil,
 			repoResponseErr2:     nil,
-			expectedPosition:     &model.Position{ID: 1, Name: "CEO", Salary: 1000},
+			expectedPosition:     &model.Position{ID: 1, Name: "CEO", Salary: money.New(1000, "IDR")},
 			expectedErr:          nil,
 		},
+		{
+			name: "Raise is recorded in the salary history",
+			args: args{
+				ctx: context.TODO(),
+				id:  1,
+				req: &request.PositionRequest{Name: "CEO", Salary: money.New(1200, "IDR"), EffectiveFrom: "2026-01-01"},
+			},
+			repoCurrent:          &model.Position{ID: 1, Name: "CEO", Salary: money.New(1000, "IDR"), CreatedAt: time.Date(2025, time.June, 3, 10, 0, 0, 0, time.Local)},
+			repoPosition:         &model.Position{Name: "CEO", Salary: money.New(1200, "IDR")},
+			repoResponsePosition: &model.Position{ID: 1, Name: "CEO", Salary: money.New(1200, "IDR")},
+			repoSalaries: []*model.PositionSalary{
+				{PositionID: 1, Salary: money.New(1000, "IDR"), EffectiveFrom: time.Date(2025, time.June, 3, 0, 0, 0, 0, time.Local)},
+				{PositionID: 1, Salary: money.New(1200, "IDR"), EffectiveFrom: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.Local)},
+			},
+			repoUpdatedHistory: []*model.PositionSalary{
+				{ID: 2, PositionID: 1, Salary: money.New(1200, "IDR"), EffectiveFrom: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.Local)},
+				{ID: 1, PositionID: 1, Salary: money.New(1000, "IDR"), EffectiveFrom: time.Date(2025, time.June, 3, 0, 0, 0, 0, time.Local)},
+			},
+			expectedPosition: &model.Position{ID: 1, Name: "CEO", Salary: money.New(1200, "IDR")},
+		},
 		{
 			name: "Failed to find position by id",
 			args: args{
 				ctx: context.TODO(),
 				id:  1,
-				req: &request.PositionRequest{Name: "CEO", Salary: 1000},
+				req: &request.PositionRequest{Name: "CEO", Salary: money.New(1000, "IDR")},
 			},
 			repoPosition:         nil,
 			repoResponsePosition: nil,
@@ -268,30 +300,86 @@ func Test_positionUsecase_EditPosition(t *testing.T) {
 			args: args{
 				ctx: context.TODO(),
 				id:  1,
-				req: &request.PositionRequest{Name: "CEO", Salary: 1000},
+				req: &request.PositionRequest{Name: "CEO", Salary: money.New(1000, "IDR")},
 			},
-			repoPosition:         &model.Position{Name: "CEO", Salary: 1000},
+			repoCurrent:          &model.Position{ID: 1, Name: "CEO", Salary: money.New(1000, "IDR")},
+			repoPosition:         &model.Position{Name: "CEO", Salary: money.New(1000, "IDR")},
 			repoResponsePosition: nil,
 			repoResponseErr1:     nil,
 			repoResponseErr2:     assert.AnError,
 			expectedPosition:     nil,
 			expectedErr:          assert.AnError,
 		},
+		{
+			name: "Salary band the overrides fit in",
+			args: args{
+				ctx: context.TODO(),
+				id:  1,
+				req: &request.PositionRequest{Name: "CEO", Salary: money.New(1000, "IDR"), MinSalary: &minSalary, MaxSalary: &maxSalary},
+			},
+			repoCurrent:  &model.Position{ID: 1, Name: "CEO", Salary: money.New(1000, "IDR")},
+			repoPosition: &model.Position{Name: "CEO", Salary: money.New(1000, "IDR"), MinSalary: &minSalary, MaxSalary: &maxSalary},
+			repoOverrides: []*model.UserSalary{
+				// an override outside the band that has already ended
+				{UserID: 3, EffectiveFrom: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)},
+				{UserID: 3, Salary: &tooHigh, EffectiveFrom: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)},
+				{UserID: 4, Salary: &inBand, EffectiveFrom: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			repoResponsePosition: &model.Position{ID: 1, Name: "CEO", Salary: money.New(1000, "IDR"), MinSalary: &minSalary, MaxSalary: &maxSalary},
+			expectedPosition:     &model.Position{ID: 1, Name: "CEO", Salary: money.New(1000, "IDR"), MinSalary: &minSalary, MaxSalary: &maxSalary},
+		},
+		{
+			name: "Salary band the overrides fall outside of",
+			args: args{
+				ctx: context.TODO(),
+				id:  1,
+				req: &request.PositionRequest{Name: "CEO", Salary: money.New(1000, "IDR"), MinSalary: &minSalary, MaxSalary: &maxSalary},
+			},
+			repoCurrent: &model.Position{ID: 1, Name: "CEO", Salary: money.New(1000, "IDR")},
+			repoOverrides: []*model.UserSalary{
+				{UserID: 3, Salary: &tooHigh, EffectiveFrom: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)},
+				{UserID: 4, Salary: &inBand, EffectiveFrom: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)},
+				// a raise agreed for later
+				{UserID: 5, Salary: &tooHigh, EffectiveFrom: time.Date(2999, time.January, 1, 0, 0, 0, 0, time.UTC)},
+				{UserID: 5, Salary: &inBand, EffectiveFrom: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			expectedErr: fmt.Errorf("%w: the overrides of employees 3, 5", model.ErrSalaryOutsideBand),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockPositionRepository := new(mocks.PositionRepository)
+			mockSalaryRepository := new(mocks.PositionSalaryRepository)
+			mockUserSalaryRepository := new(mocks.UserSalaryRepository)
+			mockTxManager := new(mocks.TxManager)
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockCompanyRepository.On("Get", mock.Anything).Return(&model.Company{Balance: money.New(0, "IDR")}, nil)
+
+			mockTxManager.On("WithinTransaction", mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
 
 			//? should we assert FindByID and UpdateByID error?
 			mockPositionRepository.On("FindByID", mock.Anything, tt.args.id).
-				Return(tt.repoResponsePosition, tt.repoResponseErr1)
+				Return(tt.repoCurrent, tt.repoResponseErr1)
+
+			if tt.args.req.MinSalary != nil || tt.args.req.MaxSalary != nil {
+				mockUserSalaryRepository.On("FetchByPositionID", mock.Anything, tt.args.id).Return(tt.repoOverrides, nil)
+			}
+
+			if tt.repoResponseErr1 == nil && tt.repoPosition != nil {
+				mockSalaryRepository.On("FetchByPositionID", mock.Anything, tt.args.id).Return(tt.repoHistory, nil).Once()
+				for _, salary := range tt.repoSalaries {
+					mockSalaryRepository.On("Upsert", mock.Anything, salary).Return(salary, nil).Once()
+				}
+				if tt.repoSalaries != nil {
+					mockSalaryRepository.On("FetchByPositionID", mock.Anything, tt.args.id).Return(tt.repoUpdatedHistory, nil).Once()
+				}
 
-			if tt.repoResponseErr1 == nil {
 				mockPositionRepository.On("UpdateByID", mock.Anything, tt.args.id, tt.repoPosition).
 					Return(tt.repoResponsePosition, tt.repoResponseErr2)
 			}
 
-			p := usecase.NewPositionUsecase(mockPositionRepository)
+			p := usecase.NewPositionUsecase(mockPositionRepository, mockSalaryRepository, mockUserSalaryRepository, mockCompanyRepository, mockTxManager)
 
 			position, err := p.EditPosition(tt.args.ctx, tt.args.id, tt.args.req)
 
@@ -299,6 +387,7 @@ func Test_positionUsecase_EditPosition(t *testing.T) {
 			assert.Equal(t, tt.expectedErr, err)
 
 			mockPositionRepository.AssertExpectations(t)
+			mockUserSalaryRepository.AssertExpectations(t)
 		})
 	}
 }
@@ -324,23 +413,23 @@ func Test_positionUsecase_StorePosition(t *testing.T) {
 				ctx: context.TODO(),
 				req: &request.PositionRequest{
 					Name:   "Software Engineer",
-					Salary: 10000000,
+					Salary: money.New(10000000, "IDR"),
 				},
 			},
 			repoPosition: &model.Position{
 				Name:   "Software Engineer",
-				Salary: 10000000,
+				Salary: money.New(10000000, "IDR"),
 			},
 			repoResponsePosition: &model.Position{
 				ID:     1,
 				Name:   "Software Engineer",
-				Salary: 10000000,
+				Salary: money.New(10000000, "IDR"),
 			},
 			repoResponseErr: nil,
 			expectedPosition: &model.Position{
 				ID:     1,
 				Name:   "Software Engineer",
-				Salary: 10000000,
+				Salary: money.New(10000000, "IDR"),
 			},
 			expectedErr: nil,
 		},
@@ -350,27 +439,83 @@ func Test_positionUsecase_StorePosition(t *testing.T) {
 				ctx: context.TODO(),
 				req: &request.PositionRequest{
 					Name:   "Data Analyst",
-					Salary: 8000000,
+					Salary: money.New(8000000, "IDR"),
 				},
 			},
 			repoPosition: &model.Position{
 				Name:   "Data Analyst",
-				Salary: 8000000,
+				Salary: money.New(8000000, "IDR"),
 			},
 			repoResponsePosition: nil,
 			repoResponseErr:      errors.New("error repository"),
 			expectedPosition:     nil,
 			expectedErr:          errors.New("error repository"),
 		},
+		{
+			name: "salary in another currency than the balance",
+			args: args{
+				ctx: context.TODO(),
+				req: &request.PositionRequest{
+					Name:   "Data Analyst",
+					Salary: money.New(1250, "USD"),
+				},
+			},
+			expectedPosition: nil,
+			expectedErr:      fmt.Errorf("%w: USD, the position pays in IDR", model.ErrSalaryCurrency),
+		},
+		{
+			name: "salary in the pay currency of the position",
+			args: args{
+				ctx: context.TODO(),
+				req: &request.PositionRequest{
+					Name:        "Expat Engineer",
+					Salary:      money.New(500000, "USD"),
+					PayCurrency: "USD",
+				},
+			},
+			repoPosition: &model.Position{
+				Name:        "Expat Engineer",
+				Salary:      money.New(500000, "USD"),
+				PayCurrency: "USD",
+			},
+			repoResponsePosition: &model.Position{
+				ID:          2,
+				Name:        "Expat Engineer",
+				Salary:      money.New(500000, "USD"),
+				PayCurrency: "USD",
+			},
+			expectedPosition: &model.Position{
+				ID:          2,
+				Name:        "Expat Engineer",
+				Salary:      money.New(500000, "USD"),
+				PayCurrency: "USD",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockPositionRepository := new(mocks.PositionRepository)
+			mockSalaryRepository := new(mocks.PositionSalaryRepository)
+			mockTxManager := new(mocks.TxManager)
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockCompanyRepository.On("Get", mock.Anything).Return(&model.Company{Balance: money.New(0, "IDR")}, nil)
 
-			mockPositionRepository.On("Create", mock.Anything, tt.repoPosition).
-				Return(tt.repoResponsePosition, tt.repoResponseErr)
+			mockTxManager.On("WithinTransaction", mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+
+			if tt.repoPosition != nil {
+				mockPositionRepository.On("Create", mock.Anything, tt.repoPosition).
+					Return(tt.repoResponsePosition, tt.repoResponseErr)
+			}
+
+			if tt.repoPosition != nil && tt.repoResponseErr == nil {
+				mockSalaryRepository.On("Upsert", mock.Anything, mock.MatchedBy(func(salary *model.PositionSalary) bool {
+					return salary.PositionID == tt.repoResponsePosition.ID && salary.Salary == tt.args.req.Salary &&
+						salary.EffectiveFrom.Hour() == 0 && !salary.EffectiveFrom.IsZero()
+				})).Return(&model.PositionSalary{}, nil)
+			}
 
-			p := usecase.NewPositionUsecase(mockPositionRepository)
+			p := usecase.NewPositionUsecase(mockPositionRepository, mockSalaryRepository, new(mocks.UserSalaryRepository), mockCompanyRepository, mockTxManager)
 
 			position, err := p.StorePosition(tt.args.ctx, tt.args.req)
 
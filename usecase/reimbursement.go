@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/request"
+	"time"
+)
+
+type reimbursementUsecase struct {
+	userRepository          model.UserRepository
+	reimbursementRepository model.ReimbursementRepository
+	companyRepository       model.CompanyRepository
+	txManager               model.TxManager
+	now                     func() time.Time
+}
+
+func NewReimbursementUsecase(user model.UserRepository, reimbursement model.ReimbursementRepository, company model.CompanyRepository, tx model.TxManager) model.ReimbursementUsecase {
+	return &reimbursementUsecase{userRepository: user, reimbursementRepository: reimbursement, companyRepository: company, txManager: tx, now: time.Now}
+}
+
+func (r *reimbursementUsecase) GetByID(ctx context.Context, userID, id int) (*model.Reimbursement, error) {
+	claim, err := r.reimbursementRepository.FindByID(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+func (r *reimbursementUsecase) GetReceipt(ctx context.Context, userID, id int) (*model.Reimbursement, error) {
+	claim, err := r.reimbursementRepository.FindReceipt(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+func (r *reimbursementUsecase) FetchReimbursement(ctx context.Context, userID int) ([]*model.Reimbursement, error) {
+	_, err := r.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := r.reimbursementRepository.FetchByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (r *reimbursementUsecase) StoreReimbursement(ctx context.Context, userID int, req *request.ReimbursementRequest) (*model.Reimbursement, error) {
+	_, err := r.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	claim, err := r.reimbursementRepository.Create(ctx, &model.Reimbursement{
+		UserID:      userID,
+		Amount:      req.Amount,
+		Category:    req.Category,
+		Description: req.Description,
+		ReceiptName: req.ReceiptName,
+		ReceiptType: req.ReceiptType,
+		Receipt:     req.Receipt,
+		Status:      model.ReimbursementStatusPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+func (r *reimbursementUsecase) ApproveReimbursement(ctx context.Context, userID, id int, req *request.ReimbursementReviewRequest) (*model.Reimbursement, error) {
+	user, err := r.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	disbursement := req.Disbursement
+	if disbursement == "" {
+		disbursement = model.ReimbursementWithSalary
+	}
+
+	var claim *model.Reimbursement
+	err = r.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		claim, err = r.review(ctx, userID, id, &model.Reimbursement{
+			Status:       model.ReimbursementStatusApproved,
+			Disbursement: disbursement,
+		})
+		if err != nil || disbursement != model.ReimbursementImmediate {
+			return err
+		}
+
+		trx, err := r.companyRepository.DebitBalance(ctx, &model.Transaction{
+			Amount:     claim.Amount,
+			Note:       user.Name + " reimbursement: " + claim.Description,
+			Category:   model.TransactionCategoryReimbursement,
+			UserID:     &user.ID,
+			PositionID: &user.PositionID,
+			Lines: []model.TransactionLine{{
+				Code:       model.PayCodeReimbursement,
+				Name:       claim.Description,
+				Kind:       model.PayLineEarning,
+				Amount:     claim.Amount,
+				SourceType: reimbursementSource,
+				SourceID:   &claim.ID,
+			}},
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := r.reimbursementRepository.MarkPaid(ctx, []int{claim.ID}, trx.ID); err != nil {
+			return err
+		}
+
+		claim, err = r.reimbursementRepository.FindByID(ctx, userID, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+func (r *reimbursementUsecase) RejectReimbursement(ctx context.Context, userID, id int) (*model.Reimbursement, error) {
+	var claim *model.Reimbursement
+	err := r.txManager.WithinTransaction(ctx, func(ctx context.Context) (err error) {
+		claim, err = r.review(ctx, userID, id, &model.Reimbursement{Status: model.ReimbursementStatusRejected})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+// review moves a pending claim on under a row lock, so it cannot be
+// approved twice and paid twice.
+func (r *reimbursementUsecase) review(ctx context.Context, userID, id int, update *model.Reimbursement) (*model.Reimbursement, error) {
+	claim, err := r.reimbursementRepository.Lock(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if claim.Status != model.ReimbursementStatusPending {
+		return nil, model.ErrReimbursementNotPending
+	}
+
+	now := r.now()
+	update.ReviewedAt = &now
+
+	return r.reimbursementRepository.UpdateByID(ctx, id, update)
+}
+
+func (r *reimbursementUsecase) DestroyReimbursement(ctx context.Context, userID, id int) error {
+	claim, err := r.reimbursementRepository.FindByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if claim.PaidTransactionID != nil {
+		return model.ErrReimbursementPaid
+	}
+
+	err = r.reimbursementRepository.Delete(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package usecase_test
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"self-payrol/money"
+	"self-payrol/request"
+	"self-payrol/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_reimbursementUsecase_ApproveReimbursement(t *testing.T) {
+	user := &model.User{ID: 1, Name: "Siti", PositionID: 2}
+	claimID, transactionID := 4, 30
+
+	tests := []struct {
+		name         string
+		req          *request.ReimbursementReviewRequest
+		status       string
+		errDebit     error
+		expectedPaid bool
+		expectedErr  error
+	}{
+		{
+			name:   "Left for the next salary withdrawal by default",
+			req:    &request.ReimbursementReviewRequest{},
+			status: model.ReimbursementStatusPending,
+		},
+		{
+			name:         "Paid immediately out of the company balance",
+			req:          &request.ReimbursementReviewRequest{Disbursement: model.ReimbursementImmediate},
+			status:       model.ReimbursementStatusPending,
+			expectedPaid: true,
+		},
+		{
+			name:        "Company balance too low to pay immediately",
+			req:         &request.ReimbursementReviewRequest{Disbursement: model.ReimbursementImmediate},
+			status:      model.ReimbursementStatusPending,
+			errDebit:    model.ErrInsufficientBalance,
+			expectedErr: model.ErrInsufficientBalance,
+		},
+		{
+			name:        "Already reviewed",
+			req:         &request.ReimbursementReviewRequest{},
+			status:      model.ReimbursementStatusRejected,
+			expectedErr: model.ErrReimbursementNotPending,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(mocks.UserRepository)
+			mockReimbursementRepository := new(mocks.ReimbursementRepository)
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockTxManager := new(mocks.TxManager)
+
+			mockTxManager.On("WithinTransaction", mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+			mockUserRepository.On("FindByID", mock.Anything, 1).Return(user, nil)
+			mockReimbursementRepository.On("Lock", mock.Anything, 1, claimID).
+				Return(&model.Reimbursement{ID: claimID, UserID: 1, Amount: money.New(350000, "IDR"), Description: "Train to Bandung", Status: tt.status}, nil)
+
+			disbursement := tt.req.Disbursement
+			if disbursement == "" {
+				disbursement = model.ReimbursementWithSalary
+			}
+			approved := &model.Reimbursement{ID: claimID, UserID: 1, Amount: money.New(350000, "IDR"), Description: "Train to Bandung", Disbursement: disbursement, Status: model.ReimbursementStatusApproved}
+			paid := &model.Reimbursement{ID: claimID, UserID: 1, Amount: money.New(350000, "IDR"), Description: "Train to Bandung", Disbursement: disbursement, Status: model.ReimbursementStatusPaid, PaidTransactionID: &transactionID}
+
+			if tt.status == model.ReimbursementStatusPending {
+				mockReimbursementRepository.On("UpdateByID", mock.Anything, claimID, mock.MatchedBy(func(claim *model.Reimbursement) bool {
+					return claim.Status == model.ReimbursementStatusApproved && claim.Disbursement == disbursement && claim.ReviewedAt != nil
+				})).Return(approved, nil)
+			}
+
+			if disbursement == model.ReimbursementImmediate {
+				mockCompanyRepository.On("DebitBalance", mock.Anything, &model.Transaction{
+					Amount: money.New(350000, "IDR"), Note: "Siti reimbursement: Train to Bandung", Category: model.TransactionCategoryReimbursement,
+					UserID: &user.ID, PositionID: &user.PositionID,
+					Lines: []model.TransactionLine{{
+						Code: model.PayCodeReimbursement, Name: "Train to Bandung", Kind: model.PayLineEarning, Amount: money.New(350000, "IDR"),
+						SourceType: "reimbursement", SourceID: &claimID,
+					}},
+				}).Return(&model.Transaction{ID: transactionID}, tt.errDebit)
+
+				if tt.errDebit == nil {
+					mockReimbursementRepository.On("MarkPaid", mock.Anything, []int{claimID}, transactionID).Return(nil)
+					mockReimbursementRepository.On("FindByID", mock.Anything, 1, claimID).Return(paid, nil)
+				}
+			}
+
+			r := usecase.NewReimbursementUsecase(mockUserRepository, mockReimbursementRepository, mockCompanyRepository, mockTxManager)
+
+			claim, err := r.ApproveReimbursement(context.TODO(), 1, claimID, tt.req)
+
+			assert.Equal(t, tt.expectedErr, err)
+			switch {
+			case tt.expectedErr != nil:
+				assert.Nil(t, claim)
+			case tt.expectedPaid:
+				assert.Equal(t, paid, claim)
+			default:
+				assert.Equal(t, approved, claim)
+			}
+
+			mockReimbursementRepository.AssertExpectations(t)
+			mockCompanyRepository.AssertExpectations(t)
+		})
+	}
+}
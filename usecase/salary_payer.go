@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"self-payrol/model"
+	"self-payrol/money"
+	"time"
+)
+
+// salaryPayer moves an employee's salary of a period from the company
+// balance to the employee. Withdrawals and payroll runs both pay through
+// it, so they share the withdrawal total of the period and never pay the
+// same salary twice.
+type salaryPayer struct {
+	companyRepo      model.CompanyRepository
+	withdrawalRepo   model.WithdrawalRepository
+	payslipRepo      model.PayslipRepository
+	exchangeRateRepo model.ExchangeRateRepository
+	payCalculator    model.PayCalculator
+	// earnedOnly caps partial payments at the salary accrued so far, as
+	// employees can only draw on what they have earned.
+	earnedOnly bool
+	// upToSalary pays at most the remaining salary rather than refusing a
+	// larger amount, as payroll runs pay what is due on the day they are
+	// disbursed whatever they were drafted with.
+	upToSalary bool
+}
+
+// pay runs inside the transaction holding the lock on withdrawal. An
+// amount of zero pays the whole remaining salary.
+func (s salaryPayer) pay(ctx context.Context, company *model.Company, user *model.User, period model.PayrollPeriod, withdrawal *model.Withdrawal, now time.Time, amount money.Money) (*model.WithdrawalSummary, *model.Payslip, error) {
+	breakdown, err := s.payCalculator.Calculate(ctx, user, period)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	salary := breakdown.Net
+	accrued := salary
+	if workingDays := period.WorkingDays(); workingDays > 0 {
+		if accrued, err = salary.Prorate(int64(period.WorkingDaysElapsed(now)), int64(workingDays)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	remaining, err := salary.Sub(withdrawal.Amount)
+	if err != nil {
+		return nil, nil, err
+	}
+	if remaining.Amount <= 0 {
+		if withdrawal.Amount.Amount > 0 {
+			return nil, nil, model.ErrSalaryAlreadyWithdrawn
+		}
+		return nil, nil, model.ErrNoSalaryToWithdraw
+	}
+
+	available, err := accrued.Sub(withdrawal.Amount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// a partial withdrawal is capped at what has been earned so far,
+	// leaving the amount out pays the whole remaining salary
+	switch {
+	case amount.Amount <= 0:
+		amount = remaining
+	case !amount.SameCurrency(salary):
+		return nil, nil, fmt.Errorf("%w: %s and %s", money.ErrCurrencyMismatch, amount.Currency, salary.Currency)
+	case s.earnedOnly && amount.Amount > available.Amount:
+		return nil, nil, model.ErrWithdrawAmountExceedsAccrued
+	case s.upToSalary && amount.Amount > remaining.Amount:
+		amount = remaining
+	case amount.Amount > remaining.Amount:
+		return nil, nil, model.ErrPayrollItemExceedsSalary
+	}
+
+	withdrawn, err := withdrawal.Amount.Add(amount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	debit, conversion, err := s.convert(ctx, company, amount, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	trx, err := s.companyRepo.DebitBalance(ctx, &model.Transaction{
+		Amount:     debit,
+		Note:       user.Name + " withdraw salary ",
+		Category:   model.TransactionCategorySalary,
+		UserID:     &user.ID,
+		PositionID: &user.PositionID,
+		Period:     &period.Code,
+		Proration:  breakdown.Proration,
+		Conversion: conversion,
+		Lines:      withdrawalLines(breakdown, withdrawal.Amount, amount),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// contributions and the like are owed once the period is settled
+	if withdrawn.Amount >= salary.Amount {
+		if err := s.payCalculator.Settle(ctx, breakdown, trx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	_, err = s.withdrawalRepo.UpdateByID(ctx, withdrawal.ID, &model.Withdrawal{
+		Amount: withdrawn,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	left, err := remaining.Sub(amount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payslip, err := s.payslipRepo.Create(ctx, newPayslip(company, user, period, breakdown, trx, now, amount, withdrawn, left))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	summary := &model.WithdrawalSummary{
+		Period:     period.Code,
+		Amount:     amount,
+		Salary:     salary,
+		Accrued:    accrued,
+		Withdrawn:  withdrawn,
+		Remaining:  left,
+		Available:  money.New(0, salary.Currency),
+		Payslip:    payslip.Number,
+		Conversion: conversion,
+	}
+	if accrued.Amount > withdrawn.Amount {
+		if summary.Available, err = accrued.Sub(withdrawn); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return summary, payslip, nil
+}
+
+// convert works out what paying amount, in the currency the employee is
+// paid in, debits from the company balance at the rate effective on the
+// payment date. The conversion is nil for employees paid in the balance
+// currency.
+func (s salaryPayer) convert(ctx context.Context, company *model.Company, amount money.Money, now time.Time) (money.Money, *model.Conversion, error) {
+	debit, rate, err := convertMoney(ctx, s.exchangeRateRepo, amount, company.Balance.Currency, company.Balance.Currency, now)
+	if err != nil || rate == nil {
+		return debit, nil, err
+	}
+
+	return debit, &model.Conversion{Paid: amount, Debited: debit, Rate: rate.Rate, RateDate: rate.EffectiveFrom}, nil
+}
+
+// withdrawalLines builds the ledger breakdown of a withdrawal. An early,
+// partial withdrawal is a single advance line. The withdrawal that settles
+// the period carries the full breakdown and recovers the advances paid
+// before it, so the earning and deduction lines of every transaction add
+// up to its amount. Employer lines are kept for the record only.
+func withdrawalLines(breakdown *model.PayBreakdown, withdrawn, amount money.Money) []model.TransactionLine {
+	// neither is more than the net pay, the sum cannot overflow
+	if withdrawn.Amount+amount.Amount < breakdown.Net.Amount {
+		return []model.TransactionLine{{
+			Code:   model.PayCodeAdvance,
+			Name:   "Earned wage advance",
+			Kind:   model.PayLineEarning,
+			Amount: amount,
+		}}
+	}
+
+	lines := make([]model.TransactionLine, 0, len(breakdown.Lines)+1)
+	for _, line := range breakdown.Lines {
+		lines = append(lines, model.TransactionLine{
+			Code:       line.Code,
+			Name:       line.Name,
+			Kind:       line.Kind,
+			Amount:     line.Amount,
+			Taxable:    line.Taxable,
+			SourceType: line.SourceType,
+			SourceID:   line.SourceID,
+		})
+	}
+
+	if withdrawn.Amount > 0 {
+		lines = append(lines, model.TransactionLine{
+			Code:   model.PayCodeAdvanceRecovery,
+			Name:   "Earned wage advances already paid",
+			Kind:   model.PayLineDeduction,
+			Amount: withdrawn,
+		})
+	}
+
+	return lines
+}
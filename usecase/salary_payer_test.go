@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"self-payrol/model"
+	"self-payrol/model/mocks"
+	"self-payrol/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func Test_salaryPayer_convert(t *testing.T) {
+	now := time.Date(2026, time.October, 25, 9, 0, 0, 0, time.UTC)
+	company := &model.Company{ID: 1, Balance: money.New(100000000, "IDR")}
+	rateDate := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name               string
+		amount             money.Money
+		rate               *model.ExchangeRate
+		errRate            error
+		expectedDebit      money.Money
+		expectedConversion *model.Conversion
+		expectedErr        error
+	}{
+		{
+			name:          "Paid in the balance currency",
+			amount:        money.New(15825500, "IDR"),
+			expectedDebit: money.New(15825500, "IDR"),
+		},
+		{
+			name:               "Paid in dollars",
+			amount:             money.New(100000, "USD"),
+			rate:               &model.ExchangeRate{Currency: "USD", QuoteCurrency: "IDR", Rate: "15825.5000000000", EffectiveFrom: rateDate},
+			expectedDebit:      money.New(15825500, "IDR"),
+			expectedConversion: &model.Conversion{Paid: money.New(100000, "USD"), Debited: money.New(15825500, "IDR"), Rate: "15825.5000000000", RateDate: rateDate},
+		},
+		{
+			name:               "Paid in Singapore dollars",
+			amount:             money.New(131879, "SGD"),
+			rate:               &model.ExchangeRate{Currency: "SGD", QuoteCurrency: "IDR", Rate: "12000", EffectiveFrom: rateDate},
+			expectedDebit:      money.New(15825480, "IDR"),
+			expectedConversion: &model.Conversion{Paid: money.New(131879, "SGD"), Debited: money.New(15825480, "IDR"), Rate: "12000", RateDate: rateDate},
+		},
+		{
+			name:        "No rate effective yet",
+			amount:      money.New(100000, "USD"),
+			errRate:     gorm.ErrRecordNotFound,
+			expectedErr: model.ErrNoExchangeRate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockExchangeRateRepository := new(mocks.ExchangeRateRepository)
+
+			if tt.rate != nil || tt.errRate != nil {
+				mockExchangeRateRepository.On("FindEffective", context.TODO(), tt.amount.Currency, "IDR", now).Return(tt.rate, tt.errRate)
+			}
+
+			s := salaryPayer{exchangeRateRepo: mockExchangeRateRepository}
+
+			debit, conversion, err := s.convert(context.TODO(), company, tt.amount, now)
+
+			assert.Equal(t, tt.expectedDebit, debit)
+			assert.Equal(t, tt.expectedConversion, conversion)
+			assert.Equal(t, tt.expectedErr, err)
+
+			mockExchangeRateRepository.AssertExpectations(t)
+		})
+	}
+}
+
+// withdrawal is one of the withdrawals of a period: what the repositories
+// hold when it is made and what it pays.
+type withdrawal struct {
+	paidOvertime []*model.Overtime
+	overtime     []*model.Overtime
+	installments []*model.LoanRepayment
+	loans        []*model.Loan
+	withdrawn    int64
+	expected     int64
+	expectedErr  error
+}
+
+func Test_salaryPayer_pay_SettledAgain(t *testing.T) {
+	now := time.Date(2026, time.October, 28, 9, 0, 0, 0, time.UTC)
+	period := model.PayrollPeriodOf(model.PayrollCycleMonthly, now)
+	company := &model.Company{ID: 1, Balance: money.New(100000000, "IDR")}
+	user := &model.User{ID: 1, Name: "Siti", PositionID: 1, Position: &model.Position{ID: 1, Salary: money.New(5000, "IDR")}}
+
+	early := &model.Overtime{ID: 5, UserID: 1, Date: time.Date(2026, time.October, 10, 0, 0, 0, 0, time.UTC), Amount: money.New(400, "IDR"), Status: model.OvertimeStatusApproved}
+	late := &model.Overtime{ID: 6, UserID: 1, Date: time.Date(2026, time.October, 24, 0, 0, 0, 0, time.UTC), Amount: money.New(150, "IDR"), Status: model.OvertimeStatusApproved}
+	short := &model.Overtime{ID: 6, UserID: 1, Date: time.Date(2026, time.October, 24, 0, 0, 0, 0, time.UTC), Amount: money.New(100, "IDR"), Status: model.OvertimeStatusApproved}
+	loan := &model.Loan{ID: 7, UserID: 1, Principal: money.New(3000, "IDR"), Installments: 3, Installment: money.New(1000, "IDR"), Outstanding: money.New(3000, "IDR"), Status: model.LoanStatusActive}
+	installment := &model.LoanRepayment{ID: 1, LoanID: 7, Kind: model.LoanRepaymentInstallment, Period: period.Code, Amount: money.New(1000, "IDR")}
+
+	tests := []struct {
+		name        string
+		withdrawals []withdrawal
+	}{
+		{
+			name: "Overtime approved after the period was settled is paid on its own",
+			withdrawals: []withdrawal{
+				{overtime: []*model.Overtime{early}, expected: 5400},
+				{paidOvertime: []*model.Overtime{early}, overtime: []*model.Overtime{late}, withdrawn: 5400, expected: 150},
+			},
+		},
+		{
+			name: "Late overtime below what was paid before is not held back",
+			withdrawals: []withdrawal{
+				{overtime: []*model.Overtime{early}, expected: 5400},
+				{paidOvertime: []*model.Overtime{early}, overtime: []*model.Overtime{short}, withdrawn: 5400, expected: 100},
+				{paidOvertime: []*model.Overtime{early, short}, withdrawn: 5500, expectedErr: model.ErrSalaryAlreadyWithdrawn},
+			},
+		},
+		{
+			name: "An installment taken already is not paid back",
+			withdrawals: []withdrawal{
+				{loans: []*model.Loan{loan}, expected: 4000},
+				{installments: []*model.LoanRepayment{installment}, withdrawn: 4000, expectedErr: model.ErrSalaryAlreadyWithdrawn},
+				{installments: []*model.LoanRepayment{installment}, overtime: []*model.Overtime{late}, withdrawn: 4000, expected: 150},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, w := range tt.withdrawals {
+				mockCompanyRepository := new(mocks.CompanyRepository)
+				mockOvertimeRepository := new(mocks.OvertimeRepository)
+				mockPaymentRepository := new(mocks.PaymentRepository)
+				mockLoanRepository := new(mocks.LoanRepository)
+				mockReimbursementRepository := new(mocks.ReimbursementRepository)
+				mockRateRepository := new(mocks.BPJSRateRepository)
+				mockContributionRepository := new(mocks.BPJSContributionRepository)
+				mockLeaveRepository := new(mocks.LeaveRepository)
+				mockUserSalaryRepository := new(mocks.UserSalaryRepository)
+				mockSalaryRepository := new(mocks.PositionSalaryRepository)
+				mockComponentRepository := new(mocks.PositionComponentRepository)
+				mockTaxCalculator := new(mocks.TaxCalculator)
+				mockWithdrawalRepository := new(mocks.WithdrawalRepository)
+				mockPayslipRepository := new(mocks.PayslipRepository)
+
+				mockCompanyRepository.On("Get", mock.Anything).Return(company, nil)
+				mockUserSalaryRepository.On("FetchByUserID", mock.Anything, user.ID).Return(nil, nil)
+				mockSalaryRepository.On("FetchByPositionID", mock.Anything, user.PositionID).Return(nil, nil)
+				mockComponentRepository.On("FetchByPositionID", mock.Anything, user.PositionID).Return(nil, nil)
+				mockRateRepository.On("FetchByCompanyID", mock.Anything, company.ID).Return([]*model.BPJSRate{{ID: 1, CompanyID: 1, Program: model.BPJSProgramJHT}}, nil)
+				mockLeaveRepository.On("FetchBetween", mock.Anything, user.ID, period.Start, period.End, model.LeaveStatusApproved).Return(nil, nil)
+				mockTaxCalculator.On("Withholding", period, user.TaxStatus, mock.Anything, money.New(0, "IDR")).Return(&model.TaxCalculation{}, nil)
+				mockOvertimeRepository.On("FetchPaidIn", mock.Anything, user.ID, period.Code).Return(w.paidOvertime, nil)
+				mockOvertimeRepository.On("FetchPayable", mock.Anything, user.ID, period.End).Return(w.overtime, nil)
+				mockPaymentRepository.On("FetchPaidIn", mock.Anything, user.ID, period.Code).Return(nil, nil)
+				mockPaymentRepository.On("FetchPayable", mock.Anything, user.ID, period.End).Return(nil, nil)
+				mockLoanRepository.On("FetchInstallmentsIn", mock.Anything, user.ID, period.Code).Return(w.installments, nil)
+				mockLoanRepository.On("FetchDue", mock.Anything, user.ID, period).Return(w.loans, nil)
+				mockReimbursementRepository.On("FetchPaidIn", mock.Anything, user.ID, period.Code).Return(nil, nil)
+				mockReimbursementRepository.On("FetchPayable", mock.Anything, user.ID, period.End).Return(nil, nil)
+
+				if w.expectedErr == nil {
+					mockCompanyRepository.On("DebitBalance", mock.Anything, mock.Anything).
+						Return(func(ctx context.Context, trx *model.Transaction) *model.Transaction {
+							trx.ID = 10 + i
+							return trx
+						}, nil)
+					// only what no withdrawal paid yet is marked paid
+					var unpaid []int
+					for _, entry := range w.overtime {
+						unpaid = append(unpaid, entry.ID)
+					}
+					mockOvertimeRepository.On("MarkPaid", mock.Anything, unpaid, 10+i).Return(nil)
+					mockPaymentRepository.On("MarkPaid", mock.Anything, []int(nil), 10+i).Return(nil)
+					mockReimbursementRepository.On("MarkPaid", mock.Anything, []int(nil), 10+i).Return(nil)
+					mockContributionRepository.On("CreateMany", mock.Anything, mock.Anything).Return(nil)
+					for _, loan := range w.loans {
+						due := *loan
+						mockLoanRepository.On("Lock", mock.Anything, loan.ID).Return(&due, nil)
+						mockLoanRepository.On("UpdateByID", mock.Anything, loan.ID, mock.Anything).Return(nil, nil)
+						mockLoanRepository.On("CreateRepayment", mock.Anything, &model.LoanRepayment{
+							LoanID: loan.ID, Kind: model.LoanRepaymentInstallment, Period: period.Code, Amount: loan.Installment, TransactionID: 10 + i,
+						}).Return(nil, nil)
+					}
+					mockWithdrawalRepository.On("UpdateByID", mock.Anything, 3, &model.Withdrawal{Amount: money.New(w.withdrawn+w.expected, "IDR")}).Return(nil, nil)
+					mockPayslipRepository.On("Create", mock.Anything, mock.Anything).Return(&model.Payslip{}, nil)
+				}
+
+				s := salaryPayer{
+					companyRepo:    mockCompanyRepository,
+					withdrawalRepo: mockWithdrawalRepository,
+					payslipRepo:    mockPayslipRepository,
+					payCalculator: &payCalculator{
+						salaryRepo:        mockSalaryRepository,
+						userSalaryRepo:    mockUserSalaryRepository,
+						componentRepo:     mockComponentRepository,
+						companyRepo:       mockCompanyRepository,
+						bpjsRateRepo:      mockRateRepository,
+						contributionRepo:  mockContributionRepository,
+						overtimeRepo:      mockOvertimeRepository,
+						paymentRepo:       mockPaymentRepository,
+						loanRepo:          mockLoanRepository,
+						reimbursementRepo: mockReimbursementRepository,
+						leaveRepo:         mockLeaveRepository,
+						taxCalculator:     mockTaxCalculator,
+						now:               func() time.Time { return now },
+					},
+				}
+
+				summary, _, err := s.pay(context.TODO(), company, user, period, &model.Withdrawal{ID: 3, UserID: user.ID, Period: period.Code, Amount: money.New(w.withdrawn, "IDR")}, now, money.Money{})
+
+				assert.Equal(t, w.expectedErr, err, "withdrawal %d", i+1)
+				if err == nil {
+					assert.Equal(t, money.New(w.expected, "IDR"), summary.Amount, "withdrawal %d", i+1)
+				}
+
+				mockOvertimeRepository.AssertExpectations(t)
+				mockLoanRepository.AssertExpectations(t)
+				mockWithdrawalRepository.AssertExpectations(t)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"self-payrol/model"
 	"self-payrol/model/mocks"
+	"self-payrol/money"
 	"self-payrol/usecase"
 	"testing"
 
@@ -38,19 +39,19 @@ func Test_transactionUsecase_Fetch(t *testing.T) {
 			repoResponseTransactions: []*model.Transaction{
 				{
 					ID:     1,
-					Amount: 20000000,
+					Amount: money.New(20000000, "IDR"),
 					Note:   "Topup balance company",
 					Type:   "credit",
 				},
 				{
 					ID:     2,
-					Amount: 100,
+					Amount: money.New(100, "IDR"),
 					Note:   "test withdraw salary ",
 					Type:   "debit",
 				},
 				{
 					ID:     3,
-					Amount: 200,
+					Amount: money.New(200, "IDR"),
 					Note:   "test2 withdraw salary ",
 					Type:   "debit",
 				},
@@ -60,19 +61,19 @@ func Test_transactionUsecase_Fetch(t *testing.T) {
 			expectedTransactions: []*model.Transaction{
 				{
 					ID:     1,
-					Amount: 20000000,
+					Amount: money.New(20000000, "IDR"),
 					Note:   "Topup balance company",
 					Type:   "credit",
 				},
 				{
 					ID:     2,
-					Amount: 100,
+					Amount: money.New(100, "IDR"),
 					Note:   "test withdraw salary ",
 					Type:   "debit",
 				},
 				{
 					ID:     3,
-					Amount: 200,
+					Amount: money.New(200, "IDR"),
 					Note:   "test2 withdraw salary ",
 					Type:   "debit",
 				},
@@ -3,40 +3,125 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"self-payrol/model"
 	"self-payrol/request"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type userUsecase struct {
-	userRepository model.UserRepository
-	positionRepo   model.PositionRepository
-	companyRepo    model.CompanyRepository
+	userRepository   model.UserRepository
+	positionRepo     model.PositionRepository
+	companyRepo      model.CompanyRepository
+	withdrawalRepo   model.WithdrawalRepository
+	transactionRepo  model.TransactionRepository
+	payslipRepo      model.PayslipRepository
+	salaryRepo       model.UserSalaryRepository
+	exchangeRateRepo model.ExchangeRateRepository
+	payCalculator    model.PayCalculator
+	txManager        model.TxManager
+	notifier         model.Notifier
+	now              func() time.Time
 }
 
-func NewUserUsecase(user model.UserRepository, post model.PositionRepository, company model.CompanyRepository) model.UserUsecase {
-	return &userUsecase{userRepository: user, positionRepo: post, companyRepo: company}
+func NewUserUsecase(user model.UserRepository, post model.PositionRepository, company model.CompanyRepository, withdrawal model.WithdrawalRepository, transaction model.TransactionRepository, payslip model.PayslipRepository, salary model.UserSalaryRepository, exchangeRate model.ExchangeRateRepository, calculator model.PayCalculator, tx model.TxManager, notifier model.Notifier) model.UserUsecase {
+	return &userUsecase{userRepository: user, positionRepo: post, companyRepo: company, withdrawalRepo: withdrawal, transactionRepo: transaction, payslipRepo: payslip, salaryRepo: salary, exchangeRateRepo: exchangeRate, payCalculator: calculator, txManager: tx, notifier: notifier, now: time.Now}
 }
 
-func (p *userUsecase) WithdrawSalary(ctx context.Context, req *request.WithdrawRequest) error {
+func (p *userUsecase) WithdrawSalary(ctx context.Context, req *request.WithdrawRequest) (*model.WithdrawalSummary, error) {
 	user, err := p.userRepository.FindByID(ctx, req.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if user.SecretID != req.SecretID {
-		return errors.New("secret id not valid")
+		return nil, errors.New("secret id not valid")
 	}
 
-	notes := user.Name + " withdraw salary "
-
-	err = p.companyRepo.DebitBalance(ctx, user.Position.Salary, notes)
+	company, err := p.companyRepo.Get(ctx)
 	if err != nil {
+		return nil, err
+	}
+
+	now := p.now()
+	period := model.PayrollPeriodOf(company.PayrollCycle, now)
+
+	if err := user.Payable(period); err != nil {
+		return nil, err
+	}
+
+	var summary *model.WithdrawalSummary
+	var payslip *model.Payslip
+
+	err = p.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// the row lock serializes withdrawals of the same employee and period
+		withdrawal, err := p.withdrawalRepo.Lock(ctx, user.ID, period.Code, user.PaidIn(company.Balance.Currency))
+		if err != nil {
+			return err
+		}
+
+		payer := salaryPayer{
+			companyRepo:      p.companyRepo,
+			withdrawalRepo:   p.withdrawalRepo,
+			payslipRepo:      p.payslipRepo,
+			exchangeRateRepo: p.exchangeRateRepo,
+			payCalculator:    p.payCalculator,
+			earnedOnly:       true,
+		}
+		summary, payslip, err = payer.pay(ctx, company, user, period, withdrawal, now, req.Amount)
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	// the money has moved, a failed email is not a reason to report otherwise
+	p.notifier.SalaryWithdrawn(ctx, user, payslip)
+
+	return summary, nil
+}
+
+func (p *userUsecase) FetchTransactions(ctx context.Context, id, limit, offset int) ([]*model.Transaction, error) {
+	_, err := p.userRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := p.transactionRepo.FetchByUserID(ctx, id, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+func (p *userUsecase) TaxPreview(ctx context.Context, id int, periodCode string) (*model.PayBreakdown, error) {
+	user, err := p.userRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	company, err := p.companyRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	period := model.PayrollPeriodOf(company.PayrollCycle, p.now())
+	if periodCode != "" {
+		period, err = model.ParsePayrollPeriod(periodCode, p.now().Location())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	breakdown, err := p.payCalculator.Calculate(ctx, user, period)
+	if err != nil {
+		return nil, err
+	}
+
+	return breakdown, nil
 }
 
 func (p *userUsecase) GetByID(ctx context.Context, id int) (*model.User, error) {
@@ -45,9 +130,74 @@ func (p *userUsecase) GetByID(ctx context.Context, id int) (*model.User, error)
 		return nil, err
 	}
 
+	history, err := p.salaryRepo.FetchByUserID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.SalaryOverride = model.OverrideOn(history, p.now())
+
 	return user, nil
 }
 
+// SetSalary records a negotiated base salary for the employee, or ends one
+// when the request salary is nil, from the effective date on.
+func (p *userUsecase) SetSalary(ctx context.Context, id int, req *request.UserSalaryRequest) (*model.UserSalary, error) {
+	user, err := p.userRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Salary != nil {
+		// the salary is agreed in the currency the employee is paid in
+		company, err := p.companyRepo.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if currency := user.PaidIn(company.Balance.Currency); req.Salary.Currency != currency {
+			return nil, fmt.Errorf("%w: %s, the employee is paid in %s", model.ErrSalaryCurrency, req.Salary.Currency, currency)
+		}
+
+		if !user.Position.InBand(*req.Salary) {
+			return nil, model.ErrSalaryOutsideBand
+		}
+	}
+
+	now := p.now()
+	effectiveFrom := dateOf(now)
+	if req.EffectiveFrom != "" {
+		effectiveFrom, err = time.ParseInLocation("2006-01-02", req.EffectiveFrom, now.Location())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	salary, err := p.salaryRepo.Upsert(ctx, &model.UserSalary{
+		UserID:        id,
+		Salary:        req.Salary,
+		EffectiveFrom: effectiveFrom,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return salary, nil
+}
+
+func (p *userUsecase) FetchSalaryHistory(ctx context.Context, id int) ([]*model.UserSalary, error) {
+	_, err := p.userRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := p.salaryRepo.FetchByUserID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
 func (p *userUsecase) FetchUser(ctx context.Context, limit, offset int) ([]*model.User, error) {
 
 	users, err := p.userRepository.Fetch(ctx, limit, offset)
@@ -74,13 +224,25 @@ func (p *userUsecase) EditUser(ctx context.Context, id int, req *request.UserReq
 		return nil, err
 	}
 
+	startDate, endDate, err := p.employmentDates(req)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := p.userRepository.UpdateByID(ctx, id, &model.User{
-		SecretID:   req.SecretID,
-		Name:       req.Name,
-		Email:      req.Email,
-		Phone:      req.Phone,
-		Address:    req.Address,
-		PositionID: req.PositionID,
+		SecretID:          req.SecretID,
+		Name:              req.Name,
+		Email:             req.Email,
+		Phone:             req.Phone,
+		Address:           req.Address,
+		TaxStatus:         req.TaxStatus,
+		PositionID:        req.PositionID,
+		StartDate:         startDate,
+		EndDate:           endDate,
+		PayCurrency:       req.PayCurrency,
+		BankCode:          req.BankCode,
+		BankAccountNumber: req.BankAccountNumber,
+		BankAccountName:   req.BankAccountName,
 	})
 
 	if err != nil {
@@ -91,16 +253,28 @@ func (p *userUsecase) EditUser(ctx context.Context, id int, req *request.UserReq
 }
 
 func (p *userUsecase) StoreUser(ctx context.Context, req *request.UserRequest) (*model.User, error) {
+	startDate, endDate, err := p.employmentDates(req)
+	if err != nil {
+		return nil, err
+	}
+
 	newUser := &model.User{
-		SecretID:   req.SecretID,
-		Name:       req.Name,
-		Email:      req.Email,
-		Phone:      req.Phone,
-		Address:    req.Address,
-		PositionID: req.PositionID,
+		SecretID:          req.SecretID,
+		Name:              req.Name,
+		Email:             req.Email,
+		Phone:             req.Phone,
+		Address:           req.Address,
+		TaxStatus:         req.TaxStatus,
+		PositionID:        req.PositionID,
+		StartDate:         startDate,
+		EndDate:           endDate,
+		PayCurrency:       req.PayCurrency,
+		BankCode:          req.BankCode,
+		BankAccountNumber: req.BankAccountNumber,
+		BankAccountName:   req.BankAccountName,
 	}
 
-	_, err := p.positionRepo.FindByID(ctx, req.PositionID)
+	_, err = p.positionRepo.FindByID(ctx, req.PositionID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("position id not valid ")
@@ -117,3 +291,36 @@ func (p *userUsecase) StoreUser(ctx context.Context, req *request.UserRequest) (
 
 	return user, nil
 }
+
+// employmentDates parses the optional start and end dates of the request.
+func (p *userUsecase) employmentDates(req *request.UserRequest) (*time.Time, *time.Time, error) {
+	startDate, err := p.parseDate(req.StartDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	endDate, err := p.parseDate(req.EndDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if startDate != nil && endDate != nil && endDate.Before(*startDate) {
+		return nil, nil, model.ErrEmploymentEndsBeforeStart
+	}
+
+	return startDate, endDate, nil
+}
+
+// parseDate reads an optional 2006-01-02 date, nil when it is empty.
+func (p *userUsecase) parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.ParseInLocation("2006-01-02", value, p.now().Location())
+	if err != nil {
+		return nil, err
+	}
+
+	return &date, nil
+}
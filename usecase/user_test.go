@@ -3,10 +3,13 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"self-payrol/model"
 	"self-payrol/model/mocks"
+	"self-payrol/money"
 	"self-payrol/request"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -14,127 +17,218 @@ import (
 )
 
 func Test_userUsecase_WithdrawSalary(t *testing.T) {
-	type args struct {
-		ctx context.Context
-		req *request.WithdrawRequest
-	}
 	type repoUserResponse struct {
 		user *model.User
 		err  error
 	}
 	type repoCompanyResponse struct {
-		err error
+		company *model.Company
+		err     error
 	}
+	type repoWithdrawalResponse struct {
+		withdrawal *model.Withdrawal
+		errLock    error
+		errUpdate  error
+	}
+	user := &model.User{
+		ID:         1,
+		Name:       "test",
+		SecretID:   "secret",
+		PositionID: 1,
+		Position: &model.Position{
+			ID:     1,
+			Name:   "CEO",
+			Salary: money.New(5000, "IDR"),
+		},
+		Status: model.EmploymentActive,
+	}
+	suspended := *user
+	suspended.Status = model.EmploymentSuspended
+	// terminated mid-October, the last period is still paid
+	lastDay := time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC)
+	terminated := *user
+	terminated.Status, terminated.EndDate = model.EmploymentTerminated, &lastDay
+	leftInSeptember := time.Date(2026, time.September, 30, 0, 0, 0, 0, time.UTC)
+	terminatedBefore := terminated
+	terminatedBefore.EndDate = &leftInSeptember
+	company := &model.Company{ID: 1, Balance: money.New(20000, "IDR"), PayrollCycle: model.PayrollCycleMonthly}
+	breakdown := model.NewPayBreakdown(1, "2026-10", "IDR")
+	breakdown.Add(model.PayLine{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(5000, "IDR"), Taxable: true})
+	baseLine := model.TransactionLine{Code: model.PayCodeBaseSalary, Name: "Base salary", Kind: model.PayLineEarning, Amount: money.New(5000, "IDR"), Taxable: true}
+	// 12 of the 22 working days of October 2026 have passed, 5000 * 12 / 22 = 2727 accrued
+	now := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+
 	tests := []struct {
-		name                string
-		args                args
-		repoUserID          int
-		repoUserResponse    repoUserResponse
-		repoCompanyResponse repoCompanyResponse
-		expectedErr         error
+		name                   string
+		req                    *request.WithdrawRequest
+		repoUserResponse       repoUserResponse
+		repoCompanyResponse    repoCompanyResponse
+		repoWithdrawalResponse repoWithdrawalResponse
+		repoCalculatorErr      error
+		debitAmount            int64
+		debitLines             []model.TransactionLine
+		errDebit               error
+		expectedSummary        *model.WithdrawalSummary
+		expectedErr            error
 	}{
-		// TODO(Bagus): Add test cases.
 		{
-			name: "Successfully withdraw salary",
-			args: args{
-				ctx: context.TODO(),
-				req: &request.WithdrawRequest{
-					ID:       1,
-					SecretID: "secret",
-				},
-			},
-			repoUserID: 1,
-			repoUserResponse: repoUserResponse{
-				user: &model.User{
-					ID:         1,
-					Name:       "test",
-					SecretID:   "secret",
-					PositionID: 1,
-					Position: &model.Position{
-						ID:     1,
-						Name:   "CEO",
-						Salary: 5000,
-					},
-				},
-				err: nil,
-			},
-			repoCompanyResponse: repoCompanyResponse{
-				err: nil,
+			name:                   "Successfully withdraw full salary",
+			req:                    &request.WithdrawRequest{ID: 1, SecretID: "secret"},
+			repoUserResponse:       repoUserResponse{user: user},
+			repoCompanyResponse:    repoCompanyResponse{company: company},
+			repoWithdrawalResponse: repoWithdrawalResponse{withdrawal: &model.Withdrawal{ID: 7, UserID: 1, Period: "2026-10"}},
+			debitAmount:            5000,
+			debitLines:             []model.TransactionLine{baseLine},
+			expectedSummary: &model.WithdrawalSummary{
+				Period: "2026-10", Amount: money.New(5000, "IDR"), Salary: money.New(5000, "IDR"), Accrued: money.New(2727, "IDR"), Withdrawn: money.New(5000, "IDR"), Remaining: money.New(0, "IDR"), Available: money.New(0, "IDR"), Payslip: "PS/2026-10/1/1",
 			},
 			expectedErr: nil,
 		},
 		{
-			name: "Invalid user id",
-			args: args{
-				ctx: context.TODO(),
-				req: &request.WithdrawRequest{
-					ID:       0,
-					SecretID: "secret",
-				},
+			name:                   "Terminated mid-period employee withdraws the last period",
+			req:                    &request.WithdrawRequest{ID: 1, SecretID: "secret"},
+			repoUserResponse:       repoUserResponse{user: &terminated},
+			repoCompanyResponse:    repoCompanyResponse{company: company},
+			repoWithdrawalResponse: repoWithdrawalResponse{withdrawal: &model.Withdrawal{ID: 7, UserID: 1, Period: "2026-10"}},
+			debitAmount:            5000,
+			debitLines:             []model.TransactionLine{baseLine},
+			expectedSummary: &model.WithdrawalSummary{
+				Period: "2026-10", Amount: money.New(5000, "IDR"), Salary: money.New(5000, "IDR"), Accrued: money.New(2727, "IDR"), Withdrawn: money.New(5000, "IDR"), Remaining: money.New(0, "IDR"), Available: money.New(0, "IDR"), Payslip: "PS/2026-10/1/1",
 			},
-			repoUserID: 0,
-			repoUserResponse: repoUserResponse{
-				user: nil,
-				err:  assert.AnError,
-			},
-			expectedErr: assert.AnError,
+			expectedErr: nil,
 		},
 		{
-			name: "Invalid secret id",
-			args: args{
-				ctx: context.TODO(),
-				req: &request.WithdrawRequest{
-					ID:       1,
-					SecretID: "not-a-secret",
-				},
-			},
-			repoUserID: 1,
-			repoUserResponse: repoUserResponse{
-				user: &model.User{
-					ID:         1,
-					Name:       "test",
-					SecretID:   "secret",
-					PositionID: 1,
-					Position: &model.Position{
-						ID:     1,
-						Name:   "CEO",
-						Salary: 5000,
-					},
-				},
-				err: nil,
+			name:                "Terminated employee cannot withdraw after the last period",
+			req:                 &request.WithdrawRequest{ID: 1, SecretID: "secret"},
+			repoUserResponse:    repoUserResponse{user: &terminatedBefore},
+			repoCompanyResponse: repoCompanyResponse{company: company},
+			expectedErr:         fmt.Errorf("%w: %s", model.ErrEmployeeNotActive, model.EmploymentTerminated),
+		},
+		{
+			name:                   "Successfully withdraw part of accrued salary",
+			req:                    &request.WithdrawRequest{ID: 1, SecretID: "secret", Amount: money.New(1000, "IDR")},
+			repoUserResponse:       repoUserResponse{user: user},
+			repoCompanyResponse:    repoCompanyResponse{company: company},
+			repoWithdrawalResponse: repoWithdrawalResponse{withdrawal: &model.Withdrawal{ID: 7, UserID: 1, Period: "2026-10"}},
+			debitAmount:            1000,
+			debitLines: []model.TransactionLine{
+				{Code: model.PayCodeAdvance, Name: "Earned wage advance", Kind: model.PayLineEarning, Amount: money.New(1000, "IDR")},
 			},
-			repoCompanyResponse: repoCompanyResponse{
-				err: nil,
+			expectedSummary: &model.WithdrawalSummary{
+				Period: "2026-10", Amount: money.New(1000, "IDR"), Salary: money.New(5000, "IDR"), Accrued: money.New(2727, "IDR"), Withdrawn: money.New(1000, "IDR"), Remaining: money.New(4000, "IDR"), Available: money.New(1727, "IDR"), Payslip: "PS/2026-10/1/1",
 			},
-			expectedErr: errors.New("secret id not valid"),
+			expectedErr: nil,
 		},
 		{
-			name: "Failed to debit balance",
-			args: args{
-				ctx: context.TODO(),
-				req: &request.WithdrawRequest{
-					ID:       1,
-					SecretID: "secret",
-				},
+			name:                   "Withdraw the rest after a partial withdrawal",
+			req:                    &request.WithdrawRequest{ID: 1, SecretID: "secret"},
+			repoUserResponse:       repoUserResponse{user: user},
+			repoCompanyResponse:    repoCompanyResponse{company: company},
+			repoWithdrawalResponse: repoWithdrawalResponse{withdrawal: &model.Withdrawal{ID: 7, UserID: 1, Period: "2026-10", Amount: money.New(2000, "IDR")}},
+			debitAmount:            3000,
+			debitLines: []model.TransactionLine{
+				baseLine,
+				{Code: model.PayCodeAdvanceRecovery, Name: "Earned wage advances already paid", Kind: model.PayLineDeduction, Amount: money.New(2000, "IDR")},
 			},
-			repoUserID: 1,
-			repoUserResponse: repoUserResponse{
-				user: &model.User{
-					ID:         1,
-					Name:       "test",
-					SecretID:   "secret",
-					PositionID: 1,
-					Position: &model.Position{
-						ID:     1,
-						Name:   "CEO",
-						Salary: 5000,
-					},
-				},
-				err: nil,
+			expectedSummary: &model.WithdrawalSummary{
+				Period: "2026-10", Amount: money.New(3000, "IDR"), Salary: money.New(5000, "IDR"), Accrued: money.New(2727, "IDR"), Withdrawn: money.New(5000, "IDR"), Remaining: money.New(0, "IDR"), Available: money.New(0, "IDR"), Payslip: "PS/2026-10/1/1",
 			},
+			expectedErr: nil,
+		},
+		{
+			name:                   "Partial amount exceeds accrued salary",
+			req:                    &request.WithdrawRequest{ID: 1, SecretID: "secret", Amount: money.New(1000, "IDR")},
+			repoUserResponse:       repoUserResponse{user: user},
+			repoCompanyResponse:    repoCompanyResponse{company: company},
+			repoWithdrawalResponse: repoWithdrawalResponse{withdrawal: &model.Withdrawal{ID: 7, UserID: 1, Period: "2026-10", Amount: money.New(2000, "IDR")}},
+			expectedErr:            model.ErrWithdrawAmountExceedsAccrued,
+		},
+		{
+			name:                   "Salary already withdrawn this period",
+			req:                    &request.WithdrawRequest{ID: 1, SecretID: "secret"},
+			repoUserResponse:       repoUserResponse{user: user},
+			repoCompanyResponse:    repoCompanyResponse{company: company},
+			repoWithdrawalResponse: repoWithdrawalResponse{withdrawal: &model.Withdrawal{ID: 7, UserID: 1, Period: "2026-10", Amount: money.New(5000, "IDR")}},
+			expectedErr:            model.ErrSalaryAlreadyWithdrawn,
+		},
+		{
+			name:                   "Failed to calculate pay",
+			req:                    &request.WithdrawRequest{ID: 1, SecretID: "secret"},
+			repoUserResponse:       repoUserResponse{user: user},
+			repoCompanyResponse:    repoCompanyResponse{company: company},
+			repoWithdrawalResponse: repoWithdrawalResponse{withdrawal: &model.Withdrawal{ID: 7, UserID: 1, Period: "2026-10"}},
+			repoCalculatorErr:      assert.AnError,
+			expectedErr:            assert.AnError,
+		},
+		{
+			name:             "Invalid user id",
+			req:              &request.WithdrawRequest{ID: 0, SecretID: "secret"},
+			repoUserResponse: repoUserResponse{err: assert.AnError},
+			expectedErr:      assert.AnError,
+		},
+		{
+			name:             "Invalid secret id",
+			req:              &request.WithdrawRequest{ID: 1, SecretID: "not-a-secret"},
+			repoUserResponse: repoUserResponse{user: user},
+			expectedErr:      errors.New("secret id not valid"),
+		},
+		{
+			name:                "Suspended employee cannot withdraw",
+			req:                 &request.WithdrawRequest{ID: 1, SecretID: "secret"},
+			repoUserResponse:    repoUserResponse{user: &suspended},
+			repoCompanyResponse: repoCompanyResponse{company: company},
+			expectedErr:         fmt.Errorf("%w: %s", model.ErrEmployeeNotActive, model.EmploymentSuspended),
+		},
+		{
+			name:                "Failed to get company",
+			req:                 &request.WithdrawRequest{ID: 1, SecretID: "secret"},
+			repoUserResponse:    repoUserResponse{user: user},
+			repoCompanyResponse: repoCompanyResponse{err: assert.AnError},
+			expectedErr:         assert.AnError,
+		},
+		{
+			name:                   "Failed to lock withdrawal",
+			req:                    &request.WithdrawRequest{ID: 1, SecretID: "secret"},
+			repoUserResponse:       repoUserResponse{user: user},
+			repoCompanyResponse:    repoCompanyResponse{company: company},
+			repoWithdrawalResponse: repoWithdrawalResponse{errLock: assert.AnError},
+			expectedErr:            assert.AnError,
+		},
+		{
+			name:                   "Failed to debit balance",
+			req:                    &request.WithdrawRequest{ID: 1, SecretID: "secret"},
+			repoUserResponse:       repoUserResponse{user: user},
+			repoCompanyResponse:    repoCompanyResponse{company: company},
+			repoWithdrawalResponse: repoWithdrawalResponse{withdrawal: &model.Withdrawal{ID: 7, UserID: 1, Period: "2026-10"}},
+			debitAmount:            5000,
+			debitLines:             []model.TransactionLine{baseLine},
+			errDebit:               assert.AnError,
+			expectedErr:            assert.AnError,
+		},
+		{
+			name:                   "Company balance not sufficient",
+			req:                    &request.WithdrawRequest{ID: 1, SecretID: "secret"},
+			repoUserResponse:       repoUserResponse{user: user},
+			repoCompanyResponse:    repoCompanyResponse{company: company},
+			repoWithdrawalResponse: repoWithdrawalResponse{withdrawal: &model.Withdrawal{ID: 7, UserID: 1, Period: "2026-10"}},
+			debitAmount:            5000,
+			debitLines:             []model.TransactionLine{baseLine},
+			errDebit:               model.ErrInsufficientBalance,
+			expectedErr:            model.ErrInsufficientBalance,
+		},
+		{
+			name:             "Failed to update withdrawal",
+			req:              &request.WithdrawRequest{ID: 1, SecretID: "secret"},
+			repoUserResponse: repoUserResponse{user: user},
 			repoCompanyResponse: repoCompanyResponse{
-				err: assert.AnError,
+				company: company,
 			},
+			repoWithdrawalResponse: repoWithdrawalResponse{
+				withdrawal: &model.Withdrawal{ID: 7, UserID: 1, Period: "2026-10"},
+				errUpdate:  assert.AnError,
+			},
+			debitAmount: 5000,
+			debitLines:  []model.TransactionLine{baseLine},
 			expectedErr: assert.AnError,
 		},
 	}
@@ -143,27 +237,93 @@ func Test_userUsecase_WithdrawSalary(t *testing.T) {
 			mockUserRepository := new(mocks.UserRepository)
 			mockPositionRepository := new(mocks.PositionRepository)
 			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockWithdrawalRepository := new(mocks.WithdrawalRepository)
+			mockTxManager := new(mocks.TxManager)
+			mockPayCalculator := new(mocks.PayCalculator)
+			mockPayslipRepository := new(mocks.PayslipRepository)
+			mockNotifier := new(mocks.Notifier)
 
-			//? should we assert mockUserRepository.FindByID and mockCompanyRepository.DebitBalance error?
-			mockUserRepository.On("FindByID", mock.Anything, tt.repoUserID).
+			mockUserRepository.On("FindByID", mock.Anything, tt.req.ID).
 				Return(tt.repoUserResponse.user, tt.repoUserResponse.err)
 
-			if tt.repoUserResponse.err == nil {
-				mockCompanyRepository.On("DebitBalance", mock.Anything, tt.repoUserResponse.user.Position.Salary, tt.repoUserResponse.user.Name+" withdraw salary ").
-					Return(tt.repoCompanyResponse.err)
+			if tt.repoCompanyResponse.company != nil || tt.repoCompanyResponse.err != nil {
+				mockCompanyRepository.On("Get", mock.Anything).
+					Return(tt.repoCompanyResponse.company, tt.repoCompanyResponse.err)
+			}
+
+			if tt.repoCompanyResponse.company != nil && !errors.Is(tt.expectedErr, model.ErrEmployeeNotActive) {
+				mockTxManager.On("WithinTransaction", mock.Anything, mock.Anything).
+					Return(func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+
+				mockWithdrawalRepository.On("Lock", mock.Anything, user.ID, "2026-10", "IDR").
+					Return(tt.repoWithdrawalResponse.withdrawal, tt.repoWithdrawalResponse.errLock)
+			}
+
+			if tt.repoWithdrawalResponse.withdrawal != nil {
+				mockPayCalculator.On("Calculate", mock.Anything, tt.repoUserResponse.user, model.PayrollPeriodOf(model.PayrollCycleMonthly, now)).
+					Return(breakdown, tt.repoCalculatorErr)
+			}
+
+			if tt.debitAmount > 0 {
+				period := "2026-10"
+				mockCompanyRepository.On("DebitBalance", mock.Anything, &model.Transaction{
+					Amount:     money.New(tt.debitAmount, "IDR"),
+					Note:       user.Name + " withdraw salary ",
+					Category:   model.TransactionCategorySalary,
+					UserID:     &user.ID,
+					PositionID: &user.PositionID,
+					Period:     &period,
+					Lines:      tt.debitLines,
+				}).Return(&model.Transaction{ID: 1, Amount: money.New(tt.debitAmount, "IDR")}, tt.errDebit)
+
+				if tt.errDebit == nil && tt.repoWithdrawalResponse.withdrawal.Amount.Amount+tt.debitAmount >= breakdown.Net.Amount {
+					mockPayCalculator.On("Settle", mock.Anything, breakdown, &model.Transaction{ID: 1, Amount: money.New(tt.debitAmount, "IDR")}).Return(nil)
+				}
+
+				if tt.errDebit == nil {
+					mockWithdrawalRepository.On("UpdateByID", mock.Anything, tt.repoWithdrawalResponse.withdrawal.ID, &model.Withdrawal{
+						Amount: money.New(tt.repoWithdrawalResponse.withdrawal.Amount.Amount+tt.debitAmount, "IDR"),
+					}).Return(nil, tt.repoWithdrawalResponse.errUpdate)
+				}
+
+				if tt.errDebit == nil && tt.repoWithdrawalResponse.errUpdate == nil {
+					withdrawn := tt.repoWithdrawalResponse.withdrawal.Amount.Amount + tt.debitAmount
+					mockPayslipRepository.On("Create", mock.Anything, mock.MatchedBy(func(payslip *model.Payslip) bool {
+						return payslip.Number == "PS/2026-10/1/1" && payslip.TransactionID == 1 &&
+							payslip.Data.Amount == money.New(tt.debitAmount, "IDR") && payslip.Data.Withdrawn == money.New(withdrawn, "IDR") &&
+							payslip.Data.Remaining == money.New(breakdown.Net.Amount-withdrawn, "IDR") && payslip.Data.PositionName == "CEO" &&
+							payslip.Data.IssuedAt.Equal(now)
+					})).Return(&model.Payslip{ID: 3, Number: "PS/2026-10/1/1"}, nil)
+
+					mockNotifier.On("SalaryWithdrawn", mock.Anything, tt.repoUserResponse.user, &model.Payslip{ID: 3, Number: "PS/2026-10/1/1"}).Return()
+				}
 			}
 
-			p := NewUserUsecase(mockUserRepository, mockPositionRepository, mockCompanyRepository)
+			p := &userUsecase{
+				userRepository: mockUserRepository,
+				positionRepo:   mockPositionRepository,
+				companyRepo:    mockCompanyRepository,
+				withdrawalRepo: mockWithdrawalRepository,
+				payslipRepo:    mockPayslipRepository,
+				payCalculator:  mockPayCalculator,
+				txManager:      mockTxManager,
+				notifier:       mockNotifier,
+				now:            func() time.Time { return now },
+			}
 
-			err := p.WithdrawSalary(tt.args.ctx, tt.args.req)
+			summary, err := p.WithdrawSalary(context.TODO(), tt.req)
 
+			assert.Equal(t, tt.expectedSummary, summary)
 			assert.Equal(t, tt.expectedErr, err)
 
 			mockUserRepository.AssertExpectations(t)
 			mockPositionRepository.AssertExpectations(t)
-			if err == nil {
-				mockCompanyRepository.AssertExpectations(t)
-			}
+			mockCompanyRepository.AssertExpectations(t)
+			mockWithdrawalRepository.AssertExpectations(t)
+			mockTxManager.AssertExpectations(t)
+			mockPayCalculator.AssertExpectations(t)
+			mockPayslipRepository.AssertExpectations(t)
+			mockNotifier.AssertExpectations(t)
 		})
 	}
 }
@@ -177,10 +337,13 @@ func Test_userUsecase_GetByID(t *testing.T) {
 		ctx context.Context
 		id  int
 	}
+	negotiated := money.New(7500000, "IDR")
+
 	tests := []struct {
 		name             string
 		args             args
 		repoUserResponse repoUserResponse
+		repoSalaries     []*model.UserSalary
 		expectedUser     *model.User
 		expectedErr      error
 	}{
@@ -209,6 +372,17 @@ func Test_userUsecase_GetByID(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "Negotiated salary is shown",
+			args: args{ctx: context.Background(), id: 1},
+			repoUserResponse: repoUserResponse{
+				user: &model.User{ID: 1, Name: "test", PositionID: 1},
+			},
+			repoSalaries: []*model.UserSalary{
+				{ID: 1, UserID: 1, Salary: &negotiated, EffectiveFrom: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			expectedUser: &model.User{ID: 1, Name: "test", PositionID: 1, SalaryOverride: &negotiated},
+		},
 		{
 			name: "User by id not found",
 			args: args{ctx: context.Background(), id: 2},
@@ -225,11 +399,16 @@ func Test_userUsecase_GetByID(t *testing.T) {
 			mockUserRepository := new(mocks.UserRepository)
 			mockPositionRepository := new(mocks.PositionRepository)
 			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockSalaryRepository := new(mocks.UserSalaryRepository)
 
 			mockUserRepository.On("FindByID", mock.Anything, tt.args.id).
 				Return(tt.repoUserResponse.user, tt.repoUserResponse.err)
 
-			p := NewUserUsecase(mockUserRepository, mockPositionRepository, mockCompanyRepository)
+			if tt.repoUserResponse.err == nil {
+				mockSalaryRepository.On("FetchByUserID", mock.Anything, tt.args.id).Return(tt.repoSalaries, nil)
+			}
+
+			p := NewUserUsecase(mockUserRepository, mockPositionRepository, mockCompanyRepository, new(mocks.WithdrawalRepository), new(mocks.TransactionRepository), new(mocks.PayslipRepository), mockSalaryRepository, new(mocks.ExchangeRateRepository), new(mocks.PayCalculator), new(mocks.TxManager), new(mocks.Notifier))
 
 			user, err := p.GetByID(tt.args.ctx, tt.args.id)
 
@@ -239,6 +418,7 @@ func Test_userUsecase_GetByID(t *testing.T) {
 			mockUserRepository.AssertExpectations(t)
 			mockPositionRepository.AssertExpectations(t)
 			mockCompanyRepository.AssertExpectations(t)
+			mockSalaryRepository.AssertExpectations(t)
 		})
 	}
 }
@@ -333,7 +513,7 @@ func Test_userUsecase_FetchUser(t *testing.T) {
 			mockUserRepository.On("Fetch", mock.Anything, tt.args.limit, tt.args.offset).
 				Return(tt.repoUserResponse.users, tt.repoUserResponse.err)
 
-			p := NewUserUsecase(mockUserRepository, mockPositionRepository, mockCompanyRepository)
+			p := NewUserUsecase(mockUserRepository, mockPositionRepository, mockCompanyRepository, new(mocks.WithdrawalRepository), new(mocks.TransactionRepository), new(mocks.PayslipRepository), new(mocks.UserSalaryRepository), new(mocks.ExchangeRateRepository), new(mocks.PayCalculator), new(mocks.TxManager), new(mocks.Notifier))
 
 			users, err := p.FetchUser(tt.args.ctx, tt.args.limit, tt.args.offset)
 
@@ -394,7 +574,7 @@ func Test_userUsecase_DestroyUser(t *testing.T) {
 			mockUserRepository.On("Delete", mock.Anything, tt.args.id).
 				Return(tt.repoUserResponse.err)
 
-			p := NewUserUsecase(mockUserRepository, mockPositionRepository, mockCompanyRepository)
+			p := NewUserUsecase(mockUserRepository, mockPositionRepository, mockCompanyRepository, new(mocks.WithdrawalRepository), new(mocks.TransactionRepository), new(mocks.PayslipRepository), new(mocks.UserSalaryRepository), new(mocks.ExchangeRateRepository), new(mocks.PayCalculator), new(mocks.TxManager), new(mocks.Notifier))
 
 			err := p.DestroyUser(tt.args.ctx, tt.args.id)
 
@@ -548,7 +728,7 @@ func Test_userUsecase_EditUser(t *testing.T) {
 					Return(tt.repoUserResponse.user, tt.repoUserResponse.err2)
 			}
 
-			p := NewUserUsecase(mockUserRepository, mockPositionRepository, mockCompanyRepository)
+			p := NewUserUsecase(mockUserRepository, mockPositionRepository, mockCompanyRepository, new(mocks.WithdrawalRepository), new(mocks.TransactionRepository), new(mocks.PayslipRepository), new(mocks.UserSalaryRepository), new(mocks.ExchangeRateRepository), new(mocks.PayCalculator), new(mocks.TxManager), new(mocks.Notifier))
 
 			user, err := p.EditUser(tt.args.ctx, tt.args.id, tt.args.req)
 
@@ -732,7 +912,7 @@ func Test_userUsecase_StoreUser(t *testing.T) {
 					Return(tt.repoUserResponse.user, tt.repoUserResponse.err)
 			}
 
-			p := NewUserUsecase(mockUserRepository, mockPositionRepository, mockCompanyRepository)
+			p := NewUserUsecase(mockUserRepository, mockPositionRepository, mockCompanyRepository, new(mocks.WithdrawalRepository), new(mocks.TransactionRepository), new(mocks.PayslipRepository), new(mocks.UserSalaryRepository), new(mocks.ExchangeRateRepository), new(mocks.PayCalculator), new(mocks.TxManager), new(mocks.Notifier))
 
 			user, err := p.StoreUser(tt.args.ctx, tt.args.req)
 
@@ -747,3 +927,260 @@ func Test_userUsecase_StoreUser(t *testing.T) {
 		})
 	}
 }
+
+func Test_userUsecase_FetchTransactions(t *testing.T) {
+	type args struct {
+		ctx    context.Context
+		id     int
+		limit  int
+		offset int
+	}
+	type repoUserResponse struct {
+		user *model.User
+		err  error
+	}
+	type repoTransactionResponse struct {
+		transactions []*model.Transaction
+		err          error
+	}
+	userID := 1
+	period := "2026-10"
+	tests := []struct {
+		name                    string
+		args                    args
+		repoUserResponse        repoUserResponse
+		repoTransactionResponse repoTransactionResponse
+		expectedTransactions    []*model.Transaction
+		expectedErr             error
+	}{
+		{
+			name: "Successfully fetch user transactions",
+			args: args{ctx: context.TODO(), id: 1, limit: 10, offset: 0},
+			repoUserResponse: repoUserResponse{
+				user: &model.User{ID: 1, Name: "test"},
+			},
+			repoTransactionResponse: repoTransactionResponse{
+				transactions: []*model.Transaction{
+					{ID: 2, Amount: money.New(5000, "IDR"), Note: "test withdraw salary ", Type: "debit", UserID: &userID, Period: &period},
+				},
+			},
+			expectedTransactions: []*model.Transaction{
+				{ID: 2, Amount: money.New(5000, "IDR"), Note: "test withdraw salary ", Type: "debit", UserID: &userID, Period: &period},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "User not found",
+			args: args{ctx: context.TODO(), id: 2, limit: 10, offset: 0},
+			repoUserResponse: repoUserResponse{
+				err: gorm.ErrRecordNotFound,
+			},
+			expectedTransactions: nil,
+			expectedErr:          gorm.ErrRecordNotFound,
+		},
+		{
+			name: "Failed to fetch user transactions",
+			args: args{ctx: context.TODO(), id: 1, limit: 10, offset: 0},
+			repoUserResponse: repoUserResponse{
+				user: &model.User{ID: 1, Name: "test"},
+			},
+			repoTransactionResponse: repoTransactionResponse{
+				err: assert.AnError,
+			},
+			expectedTransactions: nil,
+			expectedErr:          assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(mocks.UserRepository)
+			mockTransactionRepository := new(mocks.TransactionRepository)
+
+			mockUserRepository.On("FindByID", mock.Anything, tt.args.id).
+				Return(tt.repoUserResponse.user, tt.repoUserResponse.err)
+
+			if tt.repoUserResponse.err == nil {
+				mockTransactionRepository.On("FetchByUserID", mock.Anything, tt.args.id, tt.args.limit, tt.args.offset).
+					Return(tt.repoTransactionResponse.transactions, tt.repoTransactionResponse.err)
+			}
+
+			p := NewUserUsecase(mockUserRepository, new(mocks.PositionRepository), new(mocks.CompanyRepository), new(mocks.WithdrawalRepository), mockTransactionRepository, new(mocks.PayslipRepository), new(mocks.UserSalaryRepository), new(mocks.ExchangeRateRepository), new(mocks.PayCalculator), new(mocks.TxManager), new(mocks.Notifier))
+
+			transactions, err := p.FetchTransactions(tt.args.ctx, tt.args.id, tt.args.limit, tt.args.offset)
+
+			assert.Equal(t, tt.expectedTransactions, transactions)
+			assert.Equal(t, tt.expectedErr, err)
+
+			mockUserRepository.AssertExpectations(t)
+			mockTransactionRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_userUsecase_TaxPreview(t *testing.T) {
+	user := &model.User{ID: 1, Name: "test", TaxStatus: "TK/0", PositionID: 1, Position: &model.Position{ID: 1, Salary: money.New(10000000, "IDR")}}
+	company := &model.Company{ID: 1, PayrollCycle: model.PayrollCycleMonthly}
+	now := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+	breakdown := &model.PayBreakdown{UserID: 1, Period: "2026-10", Gross: money.New(10000000, "IDR"), Deductions: money.New(250000, "IDR"), Net: money.New(9750000, "IDR")}
+
+	tests := []struct {
+		name              string
+		period            string
+		repoUserErr       error
+		repoCompanyErr    error
+		calculatePeriod   string
+		calculateErr      error
+		expectedBreakdown *model.PayBreakdown
+		expectedErr       error
+	}{
+		{
+			name:              "Preview the current period",
+			calculatePeriod:   "2026-10",
+			expectedBreakdown: breakdown,
+		},
+		{
+			name:              "Preview a given period",
+			period:            "2026-11",
+			calculatePeriod:   "2026-11",
+			expectedBreakdown: breakdown,
+		},
+		{
+			name:        "User not found",
+			repoUserErr: gorm.ErrRecordNotFound,
+			expectedErr: gorm.ErrRecordNotFound,
+		},
+		{
+			name:           "Failed to get company",
+			repoCompanyErr: assert.AnError,
+			expectedErr:    assert.AnError,
+		},
+		{
+			name:        "Invalid period",
+			period:      "october",
+			expectedErr: model.ErrInvalidPayrollPeriod,
+		},
+		{
+			name:            "Failed to calculate",
+			calculatePeriod: "2026-10",
+			calculateErr:    assert.AnError,
+			expectedErr:     assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(mocks.UserRepository)
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockPayCalculator := new(mocks.PayCalculator)
+
+			mockUserRepository.On("FindByID", mock.Anything, 1).Return(user, tt.repoUserErr)
+
+			if tt.repoUserErr == nil {
+				mockCompanyRepository.On("Get", mock.Anything).Return(company, tt.repoCompanyErr)
+			}
+
+			if tt.calculatePeriod != "" {
+				period, _ := model.ParsePayrollPeriod(tt.calculatePeriod, time.UTC)
+				mockPayCalculator.On("Calculate", mock.Anything, user, period).Return(breakdown, tt.calculateErr)
+			}
+
+			p := &userUsecase{
+				userRepository: mockUserRepository,
+				companyRepo:    mockCompanyRepository,
+				payCalculator:  mockPayCalculator,
+				now:            func() time.Time { return now },
+			}
+
+			result, err := p.TaxPreview(context.TODO(), 1, tt.period)
+
+			if tt.expectedErr == nil {
+				assert.Equal(t, tt.expectedBreakdown, result)
+			} else {
+				assert.Nil(t, result)
+			}
+			assert.Equal(t, tt.expectedErr, err)
+
+			mockUserRepository.AssertExpectations(t)
+			mockCompanyRepository.AssertExpectations(t)
+			mockPayCalculator.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_userUsecase_SetSalary(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+	minSalary, maxSalary := money.New(5000000, "IDR"), money.New(9000000, "IDR")
+	user := &model.User{ID: 1, PositionID: 1, Position: &model.Position{ID: 1, Salary: money.New(6000000, "IDR"), MinSalary: &minSalary, MaxSalary: &maxSalary}}
+	negotiated, tooHigh := money.New(7500000, "IDR"), money.New(9500000, "IDR")
+	dollars := money.New(500000, "USD")
+	expat := &model.User{ID: 1, PositionID: 2, PayCurrency: "USD", Position: &model.Position{ID: 2, Salary: money.New(400000, "USD")}}
+
+	tests := []struct {
+		name        string
+		user        *model.User
+		req         *request.UserSalaryRequest
+		expected    *model.UserSalary
+		expectedErr error
+	}{
+		{
+			name:     "Negotiated salary from today",
+			req:      &request.UserSalaryRequest{Salary: &negotiated},
+			expected: &model.UserSalary{UserID: 1, Salary: &negotiated, EffectiveFrom: time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "Back to the position salary next month",
+			req:      &request.UserSalaryRequest{EffectiveFrom: "2026-11-01"},
+			expected: &model.UserSalary{UserID: 1, EffectiveFrom: time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:        "Above the salary band",
+			req:         &request.UserSalaryRequest{Salary: &tooHigh},
+			expectedErr: model.ErrSalaryOutsideBand,
+		},
+		{
+			name:        "In another currency than the balance",
+			req:         &request.UserSalaryRequest{Salary: &dollars},
+			expectedErr: fmt.Errorf("%w: USD, the employee is paid in IDR", model.ErrSalaryCurrency),
+		},
+		{
+			name:     "In the currency the employee is paid in",
+			user:     expat,
+			req:      &request.UserSalaryRequest{Salary: &dollars},
+			expected: &model.UserSalary{UserID: 1, Salary: &dollars, EffectiveFrom: time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserRepository := new(mocks.UserRepository)
+			mockCompanyRepository := new(mocks.CompanyRepository)
+			mockSalaryRepository := new(mocks.UserSalaryRepository)
+
+			employee := user
+			if tt.user != nil {
+				employee = tt.user
+			}
+			mockUserRepository.On("FindByID", mock.Anything, 1).Return(employee, nil)
+			if tt.req.Salary != nil {
+				mockCompanyRepository.On("Get", mock.Anything).Return(&model.Company{ID: 1, Balance: money.New(20000000, "IDR")}, nil)
+			}
+			if tt.expected != nil {
+				mockSalaryRepository.On("Upsert", mock.Anything, tt.expected).Return(tt.expected, nil)
+			}
+
+			p := &userUsecase{
+				userRepository: mockUserRepository,
+				companyRepo:    mockCompanyRepository,
+				salaryRepo:     mockSalaryRepository,
+				now:            func() time.Time { return now },
+			}
+
+			salary, err := p.SetSalary(context.TODO(), 1, tt.req)
+
+			assert.Equal(t, tt.expected, salary)
+			assert.Equal(t, tt.expectedErr, err)
+
+			mockUserRepository.AssertExpectations(t)
+			mockCompanyRepository.AssertExpectations(t)
+			mockSalaryRepository.AssertExpectations(t)
+		})
+	}
+}